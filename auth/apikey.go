@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"goyave.dev/goyave/v5"
+	errorutil "goyave.dev/goyave/v5/util/errors"
+)
+
+// ExtraAPIKey when using the built-in `APIKeyAuthenticator`, this key can be used
+// to retrieve the `*APIKey` used to authenticate, and therefore its scopes,
+// in the request's `Extra`.
+type ExtraAPIKey struct{}
+
+// APIKey the stored representation of an API key. The plain key is never stored:
+// only its public prefix, used to find the key, and its hash.
+type APIKey struct {
+	// ExpiresAt the expiry of the key. The key never expires if zero.
+	ExpiresAt time.Time
+
+	// Prefix the public identifier of the key. This is the part of the
+	// plain key before the ".".
+	Prefix string
+
+	// Hash the hex-encoded SHA-256 hash of the plain key.
+	Hash string
+
+	// Scopes the permissions granted to the key.
+	Scopes []string
+}
+
+// HasScope returns true if the key grants the given scope.
+func (k *APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+// NewAPIKey generates a new random API key with the given scopes. Returns the plain key,
+// which should be given to the client and cannot be retrieved later, and the `*APIKey` containing
+// its prefix and hash, which should be stored.
+//
+// The plain key has the format "<prefix>.<secret>".
+func NewAPIKey(scopes ...string) (string, *APIKey, error) {
+	prefix := make([]byte, 6)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", nil, errorutil.New(err)
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", nil, errorutil.New(err)
+	}
+	apiKey := &APIKey{
+		Prefix: hex.EncodeToString(prefix),
+		Scopes: scopes,
+	}
+	key := apiKey.Prefix + "." + base64.RawURLEncoding.EncodeToString(secret)
+	apiKey.Hash = HashAPIKey(key)
+	return key, apiKey, nil
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 hash of the given plain API key.
+func HashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// APIKeyService is the dependency of `APIKeyAuthenticator` used to retrieve an API key
+// and the user owning it.
+//
+// If the key could not be found, the error returned should be of type `gorm.ErrRecordNotFound`.
+type APIKeyService[T any] interface {
+	FindByPrefix(ctx context.Context, prefix string) (*APIKey, *T, error)
+}
+
+// APIKeyAuthenticator implementation of Authenticator using long-lived API keys,
+// for machine-to-machine clients. The key is read from a header (defaults to "X-API-Key")
+// or from a query parameter if `QueryParameter` is set. Keys are generated with `NewAPIKey()`.
+//
+// On success, the `*APIKey` is added to `request.Extra` with the key `ExtraAPIKey{}`.
+//
+// The T parameter represents the user DTO and should not be a pointer.
+type APIKeyAuthenticator[T any] struct {
+	goyave.Component
+
+	APIKeyService APIKeyService[T]
+
+	// Header the name of the header containing the API key.
+	// Defaults to "X-API-Key".
+	Header string
+
+	// QueryParameter the name of the query parameter containing the API key, used if
+	// the header is not present. Keys are not read from the query if empty (default).
+	// Query parameters tend to end up in logs, prefer using the header if possible.
+	QueryParameter string
+
+	// Optional defines if the authenticator allows requests that
+	// don't provide credentials. Handlers should therefore check
+	// if `request.User` is not `nil` before accessing it.
+	Optional bool
+}
+
+// NewAPIKeyAuthenticator create a new authenticator for API keys.
+//
+// The T parameter represents the user DTO and should not be a pointer.
+func NewAPIKeyAuthenticator[T any](service APIKeyService[T]) *APIKeyAuthenticator[T] {
+	return &APIKeyAuthenticator[T]{
+		APIKeyService: service,
+	}
+}
+
+// Authenticate fetch the user owning the API key found in the
+// given request and returns it.
+// If no user can be authenticated, returns an error.
+// The key hash is compared in constant time.
+func (a *APIKeyAuthenticator[T]) Authenticate(request *goyave.Request) (*T, error) {
+	key := a.readKey(request)
+	if key == "" {
+		if a.Optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf(request.Lang.Get("auth.no-credentials-provided"))
+	}
+
+	prefix, _, ok := strings.Cut(key, ".")
+	if !ok || prefix == "" {
+		return nil, fmt.Errorf(request.Lang.Get("auth.invalid-credentials"))
+	}
+
+	apiKey, user, err := a.APIKeyService.FindByPrefix(request.Context(), prefix)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf(request.Lang.Get("auth.invalid-credentials"))
+		}
+		panic(errorutil.New(err))
+	}
+
+	if subtle.ConstantTimeCompare([]byte(HashAPIKey(key)), []byte(apiKey.Hash)) != 1 {
+		return nil, fmt.Errorf(request.Lang.Get("auth.invalid-credentials"))
+	}
+	if !apiKey.ExpiresAt.IsZero() && apiKey.ExpiresAt.Before(time.Now()) {
+		return nil, fmt.Errorf(request.Lang.Get("auth.api-key-expired"))
+	}
+
+	request.Extra[ExtraAPIKey{}] = apiKey
+	return user, nil
+}
+
+func (a *APIKeyAuthenticator[T]) readKey(request *goyave.Request) string {
+	header := a.Header
+	if header == "" {
+		header = "X-API-Key"
+	}
+	if key := request.Header().Get(header); key != "" {
+		return key
+	}
+	if a.QueryParameter != "" {
+		return request.URL().Query().Get(a.QueryParameter)
+	}
+	return ""
+}
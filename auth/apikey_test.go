@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/util/testutil"
+)
+
+type MockAPIKeyService[T any] struct {
+	apiKey *APIKey
+	user   *T
+	err    error
+}
+
+func (s MockAPIKeyService[T]) FindByPrefix(_ context.Context, prefix string) (*APIKey, *T, error) {
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+	if s.apiKey == nil || s.apiKey.Prefix != prefix {
+		return nil, nil, gorm.ErrRecordNotFound
+	}
+	return s.apiKey, s.user, nil
+}
+
+func TestNewAPIKey(t *testing.T) {
+	key, apiKey, err := NewAPIKey("read", "write")
+	require.NoError(t, err)
+
+	prefix, secret, ok := strings.Cut(key, ".")
+	require.True(t, ok)
+	assert.Len(t, prefix, 12)
+	assert.Len(t, secret, 43)
+	assert.Equal(t, prefix, apiKey.Prefix)
+	assert.Equal(t, HashAPIKey(key), apiKey.Hash)
+	assert.Len(t, apiKey.Hash, 64)
+	assert.Equal(t, []string{"read", "write"}, apiKey.Scopes)
+	assert.True(t, apiKey.ExpiresAt.IsZero())
+	assert.True(t, apiKey.HasScope("read"))
+	assert.False(t, apiKey.HasScope("admin"))
+
+	other, _, err := NewAPIKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	key, apiKey, err := NewAPIKey("read")
+	require.NoError(t, err)
+
+	t.Run("Middleware", func(t *testing.T) {
+		server, user := prepareAuthenticatorTest(t)
+		authenticator := Middleware[TestUser](NewAPIKeyAuthenticator(&MockAPIKeyService[TestUser]{apiKey: apiKey, user: user}))
+
+		request := server.NewTestRequest(http.MethodGet, "/protected", nil)
+		request.Request().Header.Set("X-API-Key", key)
+		request.Route = &goyave.Route{Meta: map[string]any{MetaAuth: true}}
+		resp := server.TestMiddleware(authenticator, request, func(response *goyave.Response, request *goyave.Request) {
+			assert.Equal(t, user, request.User)
+			if assert.Contains(t, request.Extra, ExtraAPIKey{}) {
+				assert.Equal(t, []string{"read"}, request.Extra[ExtraAPIKey{}].(*APIKey).Scopes)
+			}
+			response.Status(http.StatusOK)
+		})
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NoError(t, resp.Body.Close())
+	})
+
+	t.Run("Middleware_invalid", func(t *testing.T) {
+		server, user := prepareAuthenticatorTest(t)
+		authenticator := Middleware[TestUser](NewAPIKeyAuthenticator(&MockAPIKeyService[TestUser]{apiKey: apiKey, user: user}))
+
+		request := server.NewTestRequest(http.MethodGet, "/protected", nil)
+		request.Request().Header.Set("X-API-Key", apiKey.Prefix+".wrongsecret")
+		request.Route = &goyave.Route{Meta: map[string]any{MetaAuth: true}}
+		resp := server.TestMiddleware(authenticator, request, func(response *goyave.Response, _ *goyave.Request) {
+			assert.Fail(t, "middleware passed despite failed authentication")
+			response.Status(http.StatusOK)
+		})
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		body, err := testutil.ReadJSONBody[map[string]string](resp.Body)
+		assert.NoError(t, resp.Body.Close())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"error": server.Lang.GetDefault().Get("auth.invalid-credentials")}, body)
+	})
+
+	authenticate := func(t *testing.T, authenticator *APIKeyAuthenticator[TestUser], setup func(request *goyave.Request)) (*goyave.Request, *TestUser, error) {
+		server, _ := prepareAuthenticatorTest(t)
+		authenticator.Init(server.Server)
+		request := server.NewTestRequest(http.MethodGet, "/protected?key="+key, nil)
+		if setup != nil {
+			setup(request)
+		}
+		user, err := authenticator.Authenticate(request)
+		return request, user, err
+	}
+	withHeader := func(name, value string) func(request *goyave.Request) {
+		return func(request *goyave.Request) {
+			request.Header().Set(name, value)
+		}
+	}
+
+	t.Run("custom_header", func(t *testing.T) {
+		_, user := prepareAuthenticatorTest(t)
+		a := NewAPIKeyAuthenticator(&MockAPIKeyService[TestUser]{apiKey: apiKey, user: user})
+		a.Header = "Api-Token"
+		_, u, err := authenticate(t, a, withHeader("Api-Token", key))
+		require.NoError(t, err)
+		assert.Equal(t, user, u)
+
+		request, u, err := authenticate(t, a, withHeader("X-API-Key", key))
+		require.EqualError(t, err, request.Lang.Get("auth.no-credentials-provided"))
+		assert.Nil(t, u)
+	})
+
+	t.Run("query", func(t *testing.T) {
+		_, user := prepareAuthenticatorTest(t)
+		a := NewAPIKeyAuthenticator(&MockAPIKeyService[TestUser]{apiKey: apiKey, user: user})
+
+		// Query is not read by default
+		request, _, err := authenticate(t, a, nil)
+		require.EqualError(t, err, request.Lang.Get("auth.no-credentials-provided"))
+
+		a.QueryParameter = "key"
+		_, u, err := authenticate(t, a, nil)
+		require.NoError(t, err)
+		assert.Equal(t, user, u)
+
+		// Header has priority
+		request, _, err = authenticate(t, a, withHeader("X-API-Key", "unknown.key"))
+		require.EqualError(t, err, request.Lang.Get("auth.invalid-credentials"))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, user := prepareAuthenticatorTest(t)
+		a := NewAPIKeyAuthenticator(&MockAPIKeyService[TestUser]{apiKey: apiKey, user: user})
+
+		for _, k := range []string{"nodot", ".secret", "unknown.secret", apiKey.Prefix + ".wrong", apiKey.Prefix} {
+			request, u, err := authenticate(t, a, withHeader("X-API-Key", k))
+			require.EqualError(t, err, request.Lang.Get("auth.invalid-credentials"), k)
+			assert.Nil(t, u)
+			assert.NotContains(t, request.Extra, ExtraAPIKey{})
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		_, user := prepareAuthenticatorTest(t)
+		expired := *apiKey
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		a := NewAPIKeyAuthenticator(&MockAPIKeyService[TestUser]{apiKey: &expired, user: user})
+		request, u, err := authenticate(t, a, withHeader("X-API-Key", key))
+		require.EqualError(t, err, request.Lang.Get("auth.api-key-expired"))
+		assert.Nil(t, u)
+
+		expired.ExpiresAt = time.Now().Add(time.Hour)
+		_, u, err = authenticate(t, a, withHeader("X-API-Key", key))
+		require.NoError(t, err)
+		assert.Equal(t, user, u)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		_, user := prepareAuthenticatorTest(t)
+		a := NewAPIKeyAuthenticator(&MockAPIKeyService[TestUser]{apiKey: apiKey, user: user})
+		a.Optional = true
+		_, u, err := authenticate(t, a, nil)
+		require.NoError(t, err)
+		assert.Nil(t, u)
+
+		request, u, err := authenticate(t, a, withHeader("X-API-Key", "unknown.secret"))
+		require.EqualError(t, err, request.Lang.Get("auth.invalid-credentials"))
+		assert.Nil(t, u)
+	})
+
+	t.Run("service_error", func(t *testing.T) {
+		a := NewAPIKeyAuthenticator(&MockAPIKeyService[TestUser]{err: fmt.Errorf("service error")})
+		assert.Panics(t, func() {
+			_, _, _ = authenticate(t, a, withHeader("X-API-Key", key))
+		})
+	})
+}
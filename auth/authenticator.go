@@ -59,6 +59,7 @@ type Handler[T any] struct {
 }
 
 // Handle set the request's `User` to the user returned by the authenticator if it succeeds.
+// If the user implements `goyave.LanguagePreferrer`, the language of the request is negotiated again.
 // Blocks if the authentication is not successful.
 // If the authenticator implements `Unauthorizer`, `OnUnauthorized` is called,
 // otherwise returns a default `401 Unauthorized` error.
@@ -81,6 +82,9 @@ func (m *Handler[T]) Handle(next goyave.Handler) goyave.Handler {
 			return
 		}
 		request.User = user
+		if _, ok := any(user).(goyave.LanguagePreferrer); ok {
+			request.Lang = m.Server().NegotiateLanguage(request)
+		}
 		next(response, request)
 	}
 }
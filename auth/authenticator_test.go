@@ -23,6 +23,15 @@ type TestUser struct {
 	Email    string `gorm:"type:varchar(100);uniqueIndex" auth:"username"`
 }
 
+type TestLanguageUser struct {
+	TestUser
+	Lang string
+}
+
+func (u *TestLanguageUser) PreferredLanguage() string {
+	return u.Lang
+}
+
 type MockUserService[T any] struct {
 	user *T
 	err  error
@@ -116,6 +125,46 @@ func TestAuthenticator(t *testing.T) {
 		assert.NoError(t, resp.Body.Close())
 	})
 
+	t.Run("MiddlewareLanguagePreferrer", func(t *testing.T) {
+		_, user := prepareAuthenticatorTest(t)
+		cfg := config.LoadDefault()
+		cfg.Set("app.debug", false)
+		server := testutil.NewTestServerWithOptions(t, goyave.Options{
+			Config: cfg,
+			LanguageResolvers: []goyave.LanguageResolver{
+				&goyave.QueryLanguageResolver{},
+				&goyave.UserLanguageResolver{},
+				&goyave.HeaderLanguageResolver{},
+			},
+		})
+		require.True(t, server.Lang.IsAvailable("en-UK"))
+
+		mockUserService := &MockUserService[TestLanguageUser]{user: &TestLanguageUser{TestUser: *user, Lang: "en-UK"}}
+		authenticator := Middleware(NewBasicAuthenticator(mockUserService, "Password"))
+
+		request := server.NewTestRequest(http.MethodGet, "/protected", nil)
+		request.Request().SetBasicAuth(user.Email, "secret")
+		request.Route = &goyave.Route{Meta: map[string]any{MetaAuth: true}}
+		assert.Equal(t, "en-US", request.Lang.Name())
+		resp := server.TestMiddleware(authenticator, request, func(response *goyave.Response, request *goyave.Request) {
+			assert.Equal(t, "en-UK", request.Lang.Name())
+			response.Status(http.StatusOK)
+		})
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NoError(t, resp.Body.Close())
+
+		// The query parameter has priority over the user preference
+		request = server.NewTestRequest(http.MethodGet, "/protected?lang=en-US", nil)
+		request.Request().SetBasicAuth(user.Email, "secret")
+		request.Route = &goyave.Route{Meta: map[string]any{MetaAuth: true}}
+		resp = server.TestMiddleware(authenticator, request, func(response *goyave.Response, request *goyave.Request) {
+			assert.Equal(t, "en-US", request.Lang.Name())
+			response.Status(http.StatusOK)
+		})
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NoError(t, resp.Body.Close())
+	})
+
 	t.Run("MiddlewareUnauthorizer", func(t *testing.T) {
 		server, user := prepareAuthenticatorTest(t)
 		t.Cleanup(func() { server.CloseDB() })
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"net/http"
+
+	"goyave.dev/goyave/v5"
+	errorutil "goyave.dev/goyave/v5/util/errors"
+)
+
+const (
+	// MetaPermissions the authorization middleware checks that the user is granted all the
+	// permissions defined by this meta, using `Gate.Can()` without resource.
+	// The value can be a `string` or a `[]string`.
+	MetaPermissions = "goyave.permissions"
+
+	// MetaRoles the authorization middleware checks that the user has at least one of the
+	// roles defined by this meta. The user must implement `RoleHolder`.
+	// The value can be a `string` or a `[]string`.
+	MetaRoles = "goyave.roles"
+)
+
+// AuthorizationHandler a middleware checking the `MetaPermissions` and `MetaRoles`
+// of the matched route (or any of its parents) using a `Gate`.
+type AuthorizationHandler struct {
+	goyave.Component
+
+	Gate *Gate
+}
+
+// AuthorizationMiddleware returns an authorization middleware using the given gate.
+//
+// This middleware should be used as a global middleware, registered after the authentication
+// middleware (see `Middleware()`). Routes that require permissions or roles should also require
+// authentication with `MetaAuth`. If neither `MetaPermissions` nor `MetaRoles` are defined for
+// the matched route, the middleware is skipped.
+//
+// Requests that are not authenticated are answered with "401 Unauthorized". Requests whose
+// user lacks the required permissions or roles are answered with "403 Forbidden". Both use
+// the status handlers.
+func AuthorizationMiddleware(gate *Gate) *AuthorizationHandler {
+	return &AuthorizationHandler{
+		Gate: gate,
+	}
+}
+
+// Handle checks the permissions and roles required by the matched route.
+func (m *AuthorizationHandler) Handle(next goyave.Handler) goyave.Handler {
+	return func(response *goyave.Response, request *goyave.Request) {
+		permissions, hasPermissions := request.Route.LookupMeta(MetaPermissions)
+		roles, hasRoles := request.Route.LookupMeta(MetaRoles)
+		if !hasPermissions && !hasRoles {
+			next(response, request)
+			return
+		}
+
+		if isNilUser(request.User) {
+			response.Status(http.StatusUnauthorized)
+			return
+		}
+
+		if hasRoles && !hasAnyRole(request.User, metaStrings(MetaRoles, roles)) {
+			response.Status(http.StatusForbidden)
+			return
+		}
+
+		if hasPermissions {
+			for _, permission := range metaStrings(MetaPermissions, permissions) {
+				if !m.Gate.Can(request, permission, nil) {
+					response.Status(http.StatusForbidden)
+					return
+				}
+			}
+		}
+
+		next(response, request)
+	}
+}
+
+func hasAnyRole(user any, roles []string) bool {
+	holder, ok := user.(RoleHolder)
+	if !ok {
+		return false
+	}
+	for _, role := range roles {
+		if holder.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+func metaStrings(key string, value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	default:
+		panic(errorutil.Errorf("invalid value for meta %q: expected string or []string, got %T", key, value))
+	}
+}
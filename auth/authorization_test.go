@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/util/testutil"
+)
+
+func TestAuthorizationMiddleware(t *testing.T) {
+	gate := NewGate()
+	gate.Define("articles.publish", func(request *goyave.Request, _ any) bool {
+		return request.User.(*TestAuthorizedUser).Name == "editor"
+	})
+
+	cases := []struct {
+		user         any
+		meta         map[string]any
+		desc         string
+		expectStatus int
+	}{
+		{desc: "no_meta", user: nil, meta: map[string]any{}, expectStatus: http.StatusOK},
+		{desc: "unauthenticated", user: nil, meta: map[string]any{MetaPermissions: "articles.create"}, expectStatus: http.StatusUnauthorized},
+		{desc: "unauthenticated_nil_ptr", user: (*TestAuthorizedUser)(nil), meta: map[string]any{MetaRoles: "admin"}, expectStatus: http.StatusUnauthorized},
+		{desc: "permission", user: &TestAuthorizedUser{Permissions: []string{"articles.create"}}, meta: map[string]any{MetaPermissions: "articles.create"}, expectStatus: http.StatusOK},
+		{desc: "missing_permission", user: &TestAuthorizedUser{Permissions: []string{"articles.create"}}, meta: map[string]any{MetaPermissions: []string{"articles.create", "articles.delete"}}, expectStatus: http.StatusForbidden},
+		{desc: "all_permissions", user: &TestAuthorizedUser{Permissions: []string{"articles.create", "articles.delete"}}, meta: map[string]any{MetaPermissions: []string{"articles.create", "articles.delete"}}, expectStatus: http.StatusOK},
+		{desc: "ability", user: &TestAuthorizedUser{TestUser: TestUser{Name: "editor"}}, meta: map[string]any{MetaPermissions: "articles.publish"}, expectStatus: http.StatusOK},
+		{desc: "ability_denied", user: &TestAuthorizedUser{TestUser: TestUser{Name: "johndoe"}, Permissions: []string{"articles.publish"}}, meta: map[string]any{MetaPermissions: "articles.publish"}, expectStatus: http.StatusForbidden},
+		{desc: "role", user: &TestAuthorizedUser{Roles: []string{"editor"}}, meta: map[string]any{MetaRoles: []string{"admin", "editor"}}, expectStatus: http.StatusOK},
+		{desc: "missing_role", user: &TestAuthorizedUser{Roles: []string{"editor"}}, meta: map[string]any{MetaRoles: "admin"}, expectStatus: http.StatusForbidden},
+		{desc: "not_role_holder", user: &TestUser{}, meta: map[string]any{MetaRoles: "admin"}, expectStatus: http.StatusForbidden},
+		{desc: "role_and_permission", user: &TestAuthorizedUser{Roles: []string{"admin"}}, meta: map[string]any{MetaRoles: "admin", MetaPermissions: "articles.create"}, expectStatus: http.StatusForbidden},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			server, _ := prepareAuthenticatorTest(t)
+			request := server.NewTestRequest(http.MethodGet, "/protected", nil)
+			request.User = c.user
+			request.Route = &goyave.Route{Meta: c.meta}
+			resp := server.TestMiddleware(AuthorizationMiddleware(gate), request, func(response *goyave.Response, _ *goyave.Request) {
+				response.Status(http.StatusOK)
+			})
+			assert.Equal(t, c.expectStatus, resp.StatusCode)
+			if c.expectStatus != http.StatusOK {
+				body, err := testutil.ReadJSONBody[map[string]string](resp.Body)
+				require.NoError(t, err)
+				assert.Equal(t, map[string]string{"error": http.StatusText(c.expectStatus)}, body)
+			}
+			assert.NoError(t, resp.Body.Close())
+		})
+	}
+
+	t.Run("parent_meta", func(t *testing.T) {
+		server, _ := prepareAuthenticatorTest(t)
+		server.RegisterRoutes(func(_ *goyave.Server, router *goyave.Router) {
+			router.GlobalMiddleware(AuthorizationMiddleware(gate))
+			admin := router.Subrouter("/admin").SetMeta(MetaRoles, "admin")
+			admin.Get("/dashboard", func(response *goyave.Response, _ *goyave.Request) {
+				response.Status(http.StatusOK)
+			})
+		})
+
+		request := server.NewTestRequest(http.MethodGet, "/admin/dashboard", nil)
+		resp := server.TestRequest(request.Request())
+		assert.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("invalid_meta", func(t *testing.T) {
+		server, _ := prepareAuthenticatorTest(t)
+		request := server.NewTestRequest(http.MethodGet, "/protected", nil)
+		request.User = &TestAuthorizedUser{}
+		request.Route = &goyave.Route{Meta: map[string]any{MetaPermissions: 1}}
+		assert.Panics(t, func() {
+			AuthorizationMiddleware(gate).Handle(func(_ *goyave.Response, _ *goyave.Request) {})(nil, request)
+		})
+	})
+}
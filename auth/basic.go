@@ -99,6 +99,7 @@ func init() {
 		IsSlice:          false,
 		AuthorizedValues: []any{},
 	})
+	config.RegisterSecret("auth.basic.password")
 }
 
 // BasicUser a simple user for config-based basic authentication.
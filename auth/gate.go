@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"net/http"
+	"reflect"
+
+	"goyave.dev/goyave/v5"
+	errorutil "goyave.dev/goyave/v5/util/errors"
+)
+
+const (
+	// GateServiceName identifier for the `Gate` service.
+	GateServiceName = "goyave.gate"
+)
+
+// PermissionHolder can be implemented by user DTOs to define the permissions granted
+// to the user. Used by `Gate` as a fallback when no policy nor ability matches an action.
+type PermissionHolder interface {
+	// HasPermission returns true if the user is granted the given permission.
+	HasPermission(permission string) bool
+}
+
+// RoleHolder can be implemented by user DTOs to define the roles of the user.
+// Used by the authorization middleware to check `MetaRoles`.
+type RoleHolder interface {
+	// HasRole returns true if the user has the given role.
+	HasRole(role string) bool
+}
+
+// Ability checks if the user of the given request can perform an action.
+// The resource may be nil for actions that don't target a specific resource.
+type Ability func(request *goyave.Request, resource any) bool
+
+// Policy checks if the user of the given request can perform an action on a resource.
+// Policies are registered per resource type with `Gate.Policy()` or `RegisterPolicy()`.
+type Policy interface {
+	Authorize(request *goyave.Request, action string, resource any) bool
+}
+
+// PolicyFunc an adapter allowing the use of ordinary functions as `Policy`.
+type PolicyFunc func(request *goyave.Request, action string, resource any) bool
+
+// Authorize calls f(request, action, resource).
+func (f PolicyFunc) Authorize(request *goyave.Request, action string, resource any) bool {
+	return f(request, action, resource)
+}
+
+// Gate determines if the user of a request is allowed to perform actions, using
+// policies registered per resource type and abilities registered per action name.
+// Authorization is denied by default.
+//
+// The gate should be registered as a service so it can be used in handlers:
+//
+//	gate := auth.NewGate()
+//	gate.Define("articles.create", func(request *goyave.Request, _ any) bool {
+//		return request.User.(*dto.InternalUser).Verified
+//	})
+//	auth.RegisterPolicy(gate, func(request *goyave.Request, action string, article *dto.Article) bool {
+//		return action == "view" || article.AuthorID == request.User.(*dto.InternalUser).ID
+//	})
+//	server.RegisterService(gate)
+//
+//	// In a handler
+//	if !c.gate.Authorize(response, request, "update", article) {
+//		return
+//	}
+//
+// This service is identified by `auth.GateServiceName`.
+type Gate struct {
+	policies  map[reflect.Type]Policy
+	abilities map[string]Ability
+}
+
+// NewGate create a new empty `Gate`.
+func NewGate() *Gate {
+	return &Gate{
+		policies:  map[reflect.Type]Policy{},
+		abilities: map[string]Ability{},
+	}
+}
+
+// Name returns the name of the service.
+func (g *Gate) Name() string {
+	return GateServiceName
+}
+
+// Define register an ability for the given action. Abilities are used for actions
+// that are not bound to a resource type, such as permissions required by routes
+// with `MetaPermissions`. Overrides any ability previously defined for this action.
+func (g *Gate) Define(action string, ability Ability) {
+	g.abilities[action] = ability
+}
+
+// Policy register a policy for resources having the same type as the given resource.
+// The type must match exactly: a policy registered for `*Article` is not used for `Article`.
+// Overrides any policy previously registered for this type.
+//
+// Panics if the given resource is nil.
+func (g *Gate) Policy(resource any, policy Policy) {
+	if resource == nil {
+		panic(errorutil.New("cannot register a policy for a nil resource"))
+	}
+	g.policies[reflect.TypeOf(resource)] = policy
+}
+
+// RegisterPolicy register a typed policy for resources of type R. Overrides any policy
+// previously registered for this type.
+//
+// Panics if R is an interface type.
+func RegisterPolicy[R any](gate *Gate, policy func(request *goyave.Request, action string, resource R) bool) {
+	t := reflect.TypeOf((*R)(nil)).Elem()
+	if t.Kind() == reflect.Interface {
+		panic(errorutil.Errorf("cannot register a policy for interface type %s", t))
+	}
+	gate.policies[t] = PolicyFunc(func(request *goyave.Request, action string, resource any) bool {
+		return policy(request, action, resource.(R))
+	})
+}
+
+// Can returns true if the user of the given request is allowed to perform the given action
+// on the given resource. The resource can be nil. The checks are made in the following order:
+//   - if the resource is not nil and a policy is registered for its type, the policy decides
+//   - if an ability is defined for the action, the ability decides
+//   - if the user implements `PermissionHolder`, the user must have the action as permission
+//
+// If none apply or if the request is not authenticated, the authorization is denied.
+func (g *Gate) Can(request *goyave.Request, action string, resource any) bool {
+	if isNilUser(request.User) {
+		return false
+	}
+	if resource != nil {
+		if policy, ok := g.policies[reflect.TypeOf(resource)]; ok {
+			return policy.Authorize(request, action, resource)
+		}
+	}
+	if ability, ok := g.abilities[action]; ok {
+		return ability(request, resource)
+	}
+	if holder, ok := request.User.(PermissionHolder); ok {
+		return holder.HasPermission(action)
+	}
+	return false
+}
+
+// Authorize checks if the user of the given request is allowed to perform the given
+// action on the given resource (see `Gate.Can()`). If not, sets the response status
+// to "403 Forbidden" and returns false, in which case the handler should return
+// immediately.
+func (g *Gate) Authorize(response *goyave.Response, request *goyave.Request, action string, resource any) bool {
+	if !g.Can(request, action, resource) {
+		response.Status(http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func isNilUser(user any) bool {
+	if user == nil {
+		return true
+	}
+	v := reflect.ValueOf(user)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/util/testutil"
+)
+
+type TestAuthorizedUser struct {
+	TestUser
+	Permissions []string
+	Roles       []string
+}
+
+func (u *TestAuthorizedUser) HasPermission(permission string) bool {
+	return slices.Contains(u.Permissions, permission)
+}
+
+func (u *TestAuthorizedUser) HasRole(role string) bool {
+	return slices.Contains(u.Roles, role)
+}
+
+type TestArticle struct {
+	AuthorID uint
+}
+
+func TestGate(t *testing.T) {
+	newRequest := func(user any) *goyave.Request {
+		request := goyave.NewRequest(httptest.NewRequest(http.MethodGet, "/test", nil))
+		request.User = user
+		return request
+	}
+
+	t.Run("Name", func(t *testing.T) {
+		assert.Equal(t, GateServiceName, NewGate().Name())
+	})
+
+	t.Run("deny_by_default", func(t *testing.T) {
+		gate := NewGate()
+		assert.False(t, gate.Can(newRequest(&TestUser{}), "update", &TestArticle{}))
+		assert.False(t, gate.Can(newRequest(&TestUser{}), "update", nil))
+		assert.False(t, gate.Can(newRequest(nil), "update", nil))
+	})
+
+	t.Run("RegisterPolicy", func(t *testing.T) {
+		gate := NewGate()
+		RegisterPolicy(gate, func(request *goyave.Request, action string, article *TestArticle) bool {
+			return action == "view" || article.AuthorID == request.User.(*TestUser).ID
+		})
+
+		author := &TestUser{}
+		author.ID = 1
+		other := &TestUser{}
+		other.ID = 2
+		article := &TestArticle{AuthorID: 1}
+
+		assert.True(t, gate.Can(newRequest(author), "update", article))
+		assert.False(t, gate.Can(newRequest(other), "update", article))
+		assert.True(t, gate.Can(newRequest(other), "view", article))
+		assert.False(t, gate.Can(newRequest(author), "update", TestArticle{AuthorID: 1})) // Type must match exactly
+		assert.False(t, gate.Can(newRequest((*TestUser)(nil)), "view", article))
+
+		assert.Panics(t, func() {
+			RegisterPolicy(gate, func(_ *goyave.Request, _ string, _ any) bool { return true })
+		})
+	})
+
+	t.Run("Policy", func(t *testing.T) {
+		gate := NewGate()
+		gate.Policy(TestArticle{}, PolicyFunc(func(_ *goyave.Request, action string, resource any) bool {
+			return action == "view" && resource.(TestArticle).AuthorID == 1
+		}))
+		assert.True(t, gate.Can(newRequest(&TestUser{}), "view", TestArticle{AuthorID: 1}))
+		assert.False(t, gate.Can(newRequest(&TestUser{}), "view", TestArticle{AuthorID: 2}))
+		assert.False(t, gate.Can(newRequest(&TestUser{}), "update", TestArticle{AuthorID: 1}))
+
+		assert.Panics(t, func() {
+			gate.Policy(nil, PolicyFunc(func(_ *goyave.Request, _ string, _ any) bool { return true }))
+		})
+	})
+
+	t.Run("Define", func(t *testing.T) {
+		gate := NewGate()
+		gate.Define("articles.create", func(request *goyave.Request, resource any) bool {
+			assert.Nil(t, resource)
+			return request.User.(*TestUser).Name == "admin"
+		})
+		assert.True(t, gate.Can(newRequest(&TestUser{Name: "admin"}), "articles.create", nil))
+		assert.False(t, gate.Can(newRequest(&TestUser{Name: "johndoe"}), "articles.create", nil))
+
+		// Policy has priority over abilities
+		RegisterPolicy(gate, func(_ *goyave.Request, _ string, _ *TestArticle) bool { return true })
+		gate.Define("update", func(_ *goyave.Request, _ any) bool { return false })
+		assert.True(t, gate.Can(newRequest(&TestUser{}), "update", &TestArticle{}))
+		assert.False(t, gate.Can(newRequest(&TestUser{}), "update", nil))
+	})
+
+	t.Run("PermissionHolder", func(t *testing.T) {
+		gate := NewGate()
+		user := &TestAuthorizedUser{Permissions: []string{"articles.create"}}
+		assert.True(t, gate.Can(newRequest(user), "articles.create", nil))
+		assert.False(t, gate.Can(newRequest(user), "articles.delete", nil))
+
+		// Abilities have priority over permissions
+		gate.Define("articles.create", func(_ *goyave.Request, _ any) bool { return false })
+		assert.False(t, gate.Can(newRequest(user), "articles.create", nil))
+	})
+
+	t.Run("Authorize", func(t *testing.T) {
+		gate := NewGate()
+		user := &TestAuthorizedUser{Permissions: []string{"articles.create"}}
+
+		request := newRequest(user)
+		response, _ := testutil.NewTestResponse(request)
+		assert.True(t, gate.Authorize(response, request, "articles.create", nil))
+		assert.Equal(t, 0, response.GetStatus())
+
+		response, _ = testutil.NewTestResponse(request)
+		assert.False(t, gate.Authorize(response, request, "articles.delete", nil))
+		assert.Equal(t, http.StatusForbidden, response.GetStatus())
+	})
+}
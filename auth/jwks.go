@@ -0,0 +1,380 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	errorutil "goyave.dev/goyave/v5/util/errors"
+)
+
+const (
+	// jwksMaxSize the maximum size of a JWKS document fetched by `RemoteJWKS`.
+	jwksMaxSize = 1 << 20
+
+	// jwksMinRefreshInterval the minimum duration between two fetches of a `RemoteJWKS`
+	// triggered by an unknown key ID.
+	jwksMinRefreshInterval = time.Minute
+
+	// jwksFetchTimeout the maximum duration of a fetch of a `RemoteJWKS`.
+	jwksFetchTimeout = 30 * time.Second
+)
+
+// KeySet provides public keys used to verify JWT signatures.
+// See `JWTAuthenticator.KeySet`.
+type KeySet interface {
+	// LookupKey returns the key identified by the given key ID ("kid" header of the JWT).
+	// Returns nil if the key cannot be found.
+	LookupKey(ctx context.Context, kid string) (*JWK, error)
+}
+
+// JWK a JSON Web Key (RFC 7517) representing an RSA or ECDSA public key.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use,omitempty"`
+	KeyID     string `json:"kid,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// ECDSA
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
+// NewJWK create a new signature JWK from the given RSA or ECDSA key. If a private key
+// is given, only its public part is used. The key ID is the key's thumbprint (RFC 7638).
+func NewJWK(key any) (*JWK, error) {
+	var jwk *JWK
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return NewJWK(&k.PublicKey)
+	case *ecdsa.PrivateKey:
+		return NewJWK(&k.PublicKey)
+	case *rsa.PublicKey:
+		jwk = &JWK{
+			KeyType: "RSA",
+			N:       base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		params := k.Curve.Params()
+		size := (params.BitSize + 7) / 8
+		jwk = &JWK{
+			KeyType: "EC",
+			Curve:   params.Name,
+			X:       base64.RawURLEncoding.EncodeToString(k.X.FillBytes(make([]byte, size))),
+			Y:       base64.RawURLEncoding.EncodeToString(k.Y.FillBytes(make([]byte, size))),
+		}
+	default:
+		return nil, errorutil.Errorf("unsupported JWK key type %T", key)
+	}
+	jwk.Use = "sig"
+	jwk.KeyID = jwk.Thumbprint()
+	return jwk, nil
+}
+
+// KeyID returns the key ID of the given RSA or ECDSA key, which is the thumbprint (RFC 7638)
+// of its public part. This key ID is used as the "kid" header of the tokens generated by
+// `JWTService`.
+func KeyID(key any) (string, error) {
+	jwk, err := NewJWK(key)
+	if err != nil {
+		return "", err
+	}
+	return jwk.KeyID, nil
+}
+
+// Thumbprint returns the base64url-encoded SHA-256 thumbprint of the key (RFC 7638).
+func (k *JWK) Thumbprint() string {
+	var members string
+	switch k.KeyType {
+	case "RSA":
+		members = fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, k.E, k.N)
+	case "EC":
+		members = fmt.Sprintf(`{"crv":%q,"kty":"EC","x":%q,"y":%q}`, k.Curve, k.X, k.Y)
+	default:
+		members = fmt.Sprintf(`{"kty":%q}`, k.KeyType)
+	}
+	hash := sha256.Sum256([]byte(members))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// PublicKey returns the `*rsa.PublicKey` or `*ecdsa.PublicKey` represented by this JWK.
+func (k *JWK) PublicKey() (any, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, errorutil.Errorf("invalid JWK %q: %w", k.KeyID, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, errorutil.Errorf("invalid JWK %q: %w", k.KeyID, err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 2 || exponent.Int64() > 1<<31-1 {
+			return nil, errorutil.Errorf("invalid JWK %q: invalid RSA modulus or exponent", k.KeyID)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errorutil.Errorf("invalid JWK %q: unsupported curve %q", k.KeyID, k.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, errorutil.Errorf("invalid JWK %q: %w", k.KeyID, err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, errorutil.Errorf("invalid JWK %q: %w", k.KeyID, err)
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if _, err := key.ECDH(); err != nil {
+			return nil, errorutil.Errorf("invalid JWK %q: %w", k.KeyID, err)
+		}
+		return key, nil
+	default:
+		return nil, errorutil.Errorf("unsupported JWK key type %q", k.KeyType)
+	}
+}
+
+// verifies returns true if this key can be used to verify a signature
+// created with the given signing method.
+func (k *JWK) verifies(signingMethod jwt.SigningMethod) bool {
+	if k.Use != "" && k.Use != "sig" {
+		return false
+	}
+	if k.Algorithm != "" && k.Algorithm != signingMethod.Alg() {
+		return false
+	}
+	switch signingMethod.(type) {
+	case *jwt.SigningMethodRSA:
+		return k.KeyType == "RSA"
+	case *jwt.SigningMethodECDSA:
+		return k.KeyType == "EC"
+	default:
+		return false
+	}
+}
+
+// JWKS a JSON Web Key Set (RFC 7517). Implements `KeySet`.
+type JWKS struct {
+	Keys []*JWK `json:"keys"`
+}
+
+// ParseJWKS parse the given JSON Web Key Set document.
+func ParseJWKS(data []byte) (*JWKS, error) {
+	set := &JWKS{}
+	if err := json.Unmarshal(data, set); err != nil {
+		return nil, errorutil.Errorf("invalid JWKS: %w", err)
+	}
+	return set, nil
+}
+
+// LoadJWKS read and parse the JSON Web Key Set document located at the given path.
+func LoadJWKS(filesystem fs.FS, path string) (*JWKS, error) {
+	data, err := fs.ReadFile(filesystem, path)
+	if err != nil {
+		return nil, errorutil.New(err)
+	}
+	return ParseJWKS(data)
+}
+
+// Key returns the key identified by the given key ID. If the key ID is empty and
+// the set only contains one key, this key is returned. Returns nil if the key cannot
+// be found.
+func (s *JWKS) Key(kid string) *JWK {
+	if kid == "" {
+		if len(s.Keys) == 1 {
+			return s.Keys[0]
+		}
+		return nil
+	}
+	for _, k := range s.Keys {
+		if k.KeyID == kid {
+			return k
+		}
+	}
+	return nil
+}
+
+// LookupKey returns the key identified by the given key ID. See `JWKS.Key()`.
+func (s *JWKS) LookupKey(_ context.Context, kid string) (*JWK, error) {
+	return s.Key(kid), nil
+}
+
+// RemoteJWKS a `KeySet` fetching a JSON Web Key Set document from a remote issuer.
+// The key set is cached and fetched again when the cache expires. If a token uses
+// an unknown key ID, the key set is fetched again so keys rotated by the issuer
+// are picked up without waiting for the cache to expire.
+//
+// Concurrent lookups share a single fetch, which is performed without holding the lock
+// and isn't cancelled if the request that triggered it is. If a fetch fails, the last
+// key set fetched successfully keeps being used. The key set is not fetched again
+// for one minute after an attempt.
+type RemoteJWKS struct {
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	fetchErr    error
+	keySet      *JWKS
+	fetching    *jwksFetch
+
+	// Client the HTTP client used to fetch the key set. Defaults to `http.DefaultClient`.
+	Client *http.Client
+
+	// URL the location of the JWKS document, for example "https://issuer.example.org/.well-known/jwks.json".
+	URL string
+
+	// CacheDuration the duration for which the key set is cached. Defaults to one hour.
+	CacheDuration time.Duration
+
+	mu sync.Mutex
+}
+
+// jwksFetch a fetch of a `RemoteJWKS` in progress, shared by concurrent lookups.
+type jwksFetch struct {
+	done chan struct{}
+}
+
+// NewRemoteJWKS create a new `KeySet` fetching the JSON Web Key Set located at the given URL.
+func NewRemoteJWKS(url string) *RemoteJWKS {
+	return &RemoteJWKS{URL: url}
+}
+
+// LookupKey returns the key identified by the given key ID, fetching the key set if needed.
+func (r *RemoteJWKS) LookupKey(ctx context.Context, kid string) (*JWK, error) {
+	cacheDuration := r.CacheDuration
+	if cacheDuration == 0 {
+		cacheDuration = time.Hour
+	}
+
+	r.mu.Lock()
+	keySet, err := r.keySet, r.fetchErr
+	expired := keySet == nil || time.Since(r.fetchedAt) > cacheDuration
+	canFetch := time.Since(r.attemptedAt) > jwksMinRefreshInterval
+	r.mu.Unlock()
+
+	refreshed := false
+	if expired && canFetch {
+		keySet, err = r.refresh(ctx)
+		refreshed = true
+	}
+	if keySet == nil {
+		return nil, err
+	}
+
+	key := keySet.Key(kid)
+	if key == nil && !refreshed && canFetch {
+		keySet, err = r.refresh(ctx)
+		if err != nil {
+			return nil, err
+		}
+		key = keySet.Key(kid)
+	}
+	return key, nil
+}
+
+// refresh fetches the key set, or waits for the fetch already in progress. Returns the last
+// key set fetched successfully, which may be stale if the fetch failed, and the fetch error.
+func (r *RemoteJWKS) refresh(ctx context.Context) (*JWKS, error) {
+	r.mu.Lock()
+	fetching := r.fetching
+	if fetching == nil {
+		fetching = &jwksFetch{done: make(chan struct{})}
+		r.fetching = fetching
+		go func() {
+			fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jwksFetchTimeout)
+			defer cancel()
+			keySet, err := r.fetch(fetchCtx)
+
+			r.mu.Lock()
+			r.attemptedAt = time.Now()
+			r.fetchErr = err
+			if err == nil {
+				r.keySet = keySet
+				r.fetchedAt = r.attemptedAt
+			}
+			r.fetching = nil
+			r.mu.Unlock()
+			close(fetching.done)
+		}()
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-fetching.done:
+	case <-ctx.Done():
+		return nil, errorutil.New(ctx.Err())
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.keySet, r.fetchErr
+}
+
+func (r *RemoteJWKS) fetch(ctx context.Context) (*JWKS, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.URL, nil)
+	if err != nil {
+		return nil, errorutil.New(err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errorutil.New(err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorutil.Errorf("could not fetch JWKS from %q: unexpected status %d", r.URL, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, jwksMaxSize))
+	if err != nil {
+		return nil, errorutil.New(err)
+	}
+	return ParseJWKS(data)
+}
+
+// lookupPublicKey returns the public key identified by the given key ID in the given key set if it
+// can be used to verify a signature created with the given signing method. Returns nil if the key
+// cannot be found.
+func lookupPublicKey(ctx context.Context, keySet KeySet, kid string, signingMethod jwt.SigningMethod) (any, error) {
+	jwk, err := keySet.LookupKey(ctx, kid)
+	if err != nil {
+		return nil, err
+	}
+	if jwk == nil || !jwk.verifies(signingMethod) {
+		return nil, nil
+	}
+	return jwk.PublicKey()
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"net/http"
+
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/util/fsutil/osfs"
+)
+
+// JWKSController controller publishing the public keys of the `JWTService` as a JSON Web Key Set
+// so other services can verify the tokens issued by this application. The key set contains
+// the current and previous RSA and ECDSA public keys (see `JWTService.PublicKeySet()`).
+type JWKSController struct {
+	goyave.Component
+
+	jwtService *JWTService
+}
+
+// NewJWKSController create a new `JWKSController` registering the "/.well-known/jwks.json" route.
+func NewJWKSController() *JWKSController {
+	return &JWKSController{}
+}
+
+// Init the controller. Automatically registers the `JWTService` if not already registered,
+// using `osfs.FS` as file system for the keys.
+func (c *JWKSController) Init(server *goyave.Server) {
+	c.Component.Init(server)
+
+	service, ok := server.LookupService(JWTServiceName)
+	if !ok {
+		service = NewJWTServiceFromServer(server, &osfs.FS{})
+		server.RegisterService(service)
+	}
+	c.jwtService = service.(*JWTService)
+}
+
+// RegisterRoutes register the "/.well-known/jwks.json" route on the given router.
+func (c *JWKSController) RegisterRoutes(router *goyave.Router) {
+	router.Get("/.well-known/jwks.json", c.JWKS)
+}
+
+// JWKS GET handler returning the public key set.
+func (c *JWKSController) JWKS(response *goyave.Response, _ *goyave.Request) {
+	keySet, err := c.jwtService.PublicKeySet()
+	if err != nil {
+		response.Error(err)
+		return
+	}
+	response.Header().Set("Cache-Control", "public, max-age=3600")
+	response.JSON(http.StatusOK, keySet)
+}
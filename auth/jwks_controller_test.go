@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/util/testutil"
+)
+
+func TestJWKSController(t *testing.T) {
+	t.Run("JWKS", func(t *testing.T) {
+		rootDir := testutil.FindRootDirectory()
+		server, _ := prepareAuthenticatorTest(t)
+		server.Config().Set("auth.jwt.secret", "secret")
+		server.Config().Set("auth.jwt.rsa.public", path.Join(rootDir, "resources/rsa/public.pem"))
+		server.Config().Set("auth.jwt.ecdsa.public", path.Join(rootDir, "resources/ecdsa/public.pem"))
+
+		server.RegisterRoutes(func(_ *goyave.Server, router *goyave.Router) {
+			router.Controller(NewJWKSController())
+		})
+
+		request := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+		resp := server.TestRequest(request)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "public, max-age=3600", resp.Header.Get("Cache-Control"))
+		keySet, err := testutil.ReadJSONBody[*JWKS](resp.Body)
+		assert.NoError(t, resp.Body.Close())
+		require.NoError(t, err)
+
+		service := server.Service(JWTServiceName).(*JWTService)
+		expected, err := service.PublicKeySet()
+		require.NoError(t, err)
+		assert.Equal(t, expected, keySet)
+		require.Len(t, keySet.Keys, 2)
+		assert.Equal(t, "RSA", keySet.Keys[0].KeyType)
+		assert.Equal(t, "EC", keySet.Keys[1].KeyType)
+	})
+
+	t.Run("JWKS_error", func(t *testing.T) {
+		server, _ := prepareAuthenticatorTest(t)
+		server.Config().Set("auth.jwt.rsa.public", "notafile.pem")
+
+		server.RegisterRoutes(func(_ *goyave.Server, router *goyave.Router) {
+			router.Controller(NewJWKSController())
+		})
+
+		request := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+		resp := server.TestRequest(request)
+		assert.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
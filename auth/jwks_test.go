@@ -0,0 +1,654 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/slog"
+	"goyave.dev/goyave/v5/util/fsutil/osfs"
+	"goyave.dev/goyave/v5/util/testutil"
+)
+
+// writeTestKeyPair generates a new key pair and writes it as PEM files in a temporary
+// directory. Returns the paths to the private and public keys.
+func writeTestKeyPair(t *testing.T, ec bool) (string, string) {
+	var privateDER, publicDER []byte
+	var privateType string
+	if ec {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		privateDER, err = x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+		privateType = "EC PRIVATE KEY"
+		publicDER, err = x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+	} else {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		privateDER = x509.MarshalPKCS1PrivateKey(key)
+		privateType = "RSA PRIVATE KEY"
+		publicDER, err = x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+	}
+
+	dir := t.TempDir()
+	privatePath := path.Join(dir, "private.pem")
+	publicPath := path.Join(dir, "public.pem")
+	require.NoError(t, os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: privateType, Bytes: privateDER}), 0o600))
+	require.NoError(t, os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o600))
+	return privatePath, publicPath
+}
+
+func TestJWK(t *testing.T) {
+	rootDir := testutil.FindRootDirectory()
+
+	t.Run("RSA", func(t *testing.T) {
+		_, service := prepareJWTServiceTest(t)
+		service.config().Set("auth.jwt.rsa.private", path.Join(rootDir, "resources/rsa/private.pem"))
+		service.config().Set("auth.jwt.rsa.public", path.Join(rootDir, "resources/rsa/public.pem"))
+		privateKey, err := service.GetKey("auth.jwt.rsa.private")
+		require.NoError(t, err)
+		publicKey, err := service.GetKey("auth.jwt.rsa.public")
+		require.NoError(t, err)
+
+		jwk, err := NewJWK(privateKey)
+		require.NoError(t, err)
+		assert.Equal(t, "RSA", jwk.KeyType)
+		assert.Equal(t, "sig", jwk.Use)
+		assert.Equal(t, "AQAB", jwk.E)
+		assert.Equal(t, jwk.Thumbprint(), jwk.KeyID)
+		assert.Empty(t, jwk.Curve)
+
+		publicJWK, err := NewJWK(publicKey)
+		require.NoError(t, err)
+		assert.Equal(t, jwk, publicJWK)
+
+		kid, err := KeyID(publicKey)
+		require.NoError(t, err)
+		assert.Equal(t, jwk.KeyID, kid)
+
+		key, err := jwk.PublicKey()
+		require.NoError(t, err)
+		assert.Equal(t, publicKey, key)
+	})
+
+	t.Run("ECDSA", func(t *testing.T) {
+		_, service := prepareJWTServiceTest(t)
+		service.config().Set("auth.jwt.ecdsa.private", path.Join(rootDir, "resources/ecdsa/private.pem"))
+		service.config().Set("auth.jwt.ecdsa.public", path.Join(rootDir, "resources/ecdsa/public.pem"))
+		privateKey, err := service.GetKey("auth.jwt.ecdsa.private")
+		require.NoError(t, err)
+		publicKey, err := service.GetKey("auth.jwt.ecdsa.public")
+		require.NoError(t, err)
+
+		jwk, err := NewJWK(privateKey)
+		require.NoError(t, err)
+		assert.Equal(t, "EC", jwk.KeyType)
+		assert.Equal(t, "P-256", jwk.Curve)
+		assert.Len(t, jwk.X, 43)
+		assert.Len(t, jwk.Y, 43)
+		assert.Equal(t, jwk.Thumbprint(), jwk.KeyID)
+		assert.Empty(t, jwk.N)
+
+		key, err := jwk.PublicKey()
+		require.NoError(t, err)
+		assert.True(t, publicKey.(*ecdsa.PublicKey).Equal(key))
+	})
+
+	t.Run("unsupported_key", func(t *testing.T) {
+		_, err := NewJWK([]byte("secret"))
+		require.Error(t, err)
+		_, err = KeyID("secret")
+		require.Error(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := []*JWK{
+			{KeyType: "oct"},
+			{KeyType: "RSA", N: "!", E: "AQAB"},
+			{KeyType: "RSA", N: "AQAB", E: "!"},
+			{KeyType: "RSA", N: "", E: "AQAB"},
+			{KeyType: "RSA", N: "AQAB", E: "AQ"},
+			{KeyType: "EC", Curve: "P-192", X: "AQAB", Y: "AQAB"},
+			{KeyType: "EC", Curve: "P-256", X: "!", Y: "AQAB"},
+			{KeyType: "EC", Curve: "P-256", X: "AQAB", Y: "!"},
+			{KeyType: "EC", Curve: "P-256", X: "AQAB", Y: "AQAB"}, // Not on curve
+		}
+		for _, c := range cases {
+			_, err := c.PublicKey()
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("verifies", func(t *testing.T) {
+		rsaKey := &JWK{KeyType: "RSA"}
+		assert.True(t, rsaKey.verifies(jwt.SigningMethodRS256))
+		assert.True(t, rsaKey.verifies(jwt.SigningMethodRS512))
+		assert.False(t, rsaKey.verifies(jwt.SigningMethodES256))
+		assert.False(t, rsaKey.verifies(jwt.SigningMethodHS256))
+		assert.False(t, rsaKey.verifies(jwt.SigningMethodPS256))
+
+		ecKey := &JWK{KeyType: "EC", Use: "sig", Algorithm: "ES256"}
+		assert.True(t, ecKey.verifies(jwt.SigningMethodES256))
+		assert.False(t, ecKey.verifies(jwt.SigningMethodES384))
+		assert.False(t, ecKey.verifies(jwt.SigningMethodRS256))
+
+		encKey := &JWK{KeyType: "RSA", Use: "enc"}
+		assert.False(t, encKey.verifies(jwt.SigningMethodRS256))
+	})
+}
+
+func TestJWKS(t *testing.T) {
+	t.Run("ParseJWKS", func(t *testing.T) {
+		keySet, err := ParseJWKS([]byte(`{"keys":[{"kty":"RSA","kid":"a","n":"AQAB","e":"AQAB"},{"kty":"EC","kid":"b","crv":"P-256","x":"AQAB","y":"AQAB","alg":"ES256"}]}`))
+		require.NoError(t, err)
+		assert.Equal(t, &JWKS{Keys: []*JWK{
+			{KeyType: "RSA", KeyID: "a", N: "AQAB", E: "AQAB"},
+			{KeyType: "EC", KeyID: "b", Curve: "P-256", X: "AQAB", Y: "AQAB", Algorithm: "ES256"},
+		}}, keySet)
+
+		_, err = ParseJWKS([]byte(`{"keys":`))
+		require.Error(t, err)
+	})
+
+	t.Run("LoadJWKS", func(t *testing.T) {
+		file := path.Join(t.TempDir(), "jwks.json")
+		require.NoError(t, os.WriteFile(file, []byte(`{"keys":[{"kty":"RSA","kid":"a","n":"AQAB","e":"AQAB"}]}`), 0o600))
+		keySet, err := LoadJWKS(&osfs.FS{}, file)
+		require.NoError(t, err)
+		assert.Len(t, keySet.Keys, 1)
+
+		_, err = LoadJWKS(&osfs.FS{}, path.Join(t.TempDir(), "notafile.json"))
+		require.Error(t, err)
+	})
+
+	t.Run("Key", func(t *testing.T) {
+		a := &JWK{KeyType: "RSA", KeyID: "a"}
+		b := &JWK{KeyType: "EC", KeyID: "b"}
+		keySet := &JWKS{Keys: []*JWK{a, b}}
+		assert.Equal(t, a, keySet.Key("a"))
+		assert.Equal(t, b, keySet.Key("b"))
+		assert.Nil(t, keySet.Key("c"))
+		assert.Nil(t, keySet.Key(""))
+
+		key, err := keySet.LookupKey(context.Background(), "b")
+		require.NoError(t, err)
+		assert.Equal(t, b, key)
+
+		keySet = &JWKS{Keys: []*JWK{a}}
+		assert.Equal(t, a, keySet.Key(""))
+		assert.Nil(t, (&JWKS{}).Key(""))
+	})
+}
+
+func TestRemoteJWKS(t *testing.T) {
+	var requests atomic.Int32
+	var keySet atomic.Pointer[JWKS]
+	keySet.Store(&JWKS{Keys: []*JWK{{KeyType: "RSA", KeyID: "a"}}})
+	issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/jwks.json":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(keySet.Load())
+		case "/invalid.json":
+			_, _ = w.Write([]byte("{"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(issuer.Close)
+	ctx := context.Background()
+
+	t.Run("cache", func(t *testing.T) {
+		requests.Store(0)
+		remote := NewRemoteJWKS(issuer.URL + "/jwks.json")
+
+		key, err := remote.LookupKey(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, "a", key.KeyID)
+		key, err = remote.LookupKey(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, "a", key.KeyID)
+		assert.Equal(t, int32(1), requests.Load())
+
+		// Cache expired
+		remote.fetchedAt = time.Now().Add(-2 * time.Hour)
+		remote.attemptedAt = remote.fetchedAt
+		_, err = remote.LookupKey(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("rotation", func(t *testing.T) {
+		requests.Store(0)
+		remote := NewRemoteJWKS(issuer.URL + "/jwks.json")
+		_, err := remote.LookupKey(ctx, "a")
+		require.NoError(t, err)
+
+		keySet.Store(&JWKS{Keys: []*JWK{{KeyType: "RSA", KeyID: "a"}, {KeyType: "RSA", KeyID: "b"}}})
+		t.Cleanup(func() { keySet.Store(&JWKS{Keys: []*JWK{{KeyType: "RSA", KeyID: "a"}}}) })
+
+		// Fetched too recently
+		key, err := remote.LookupKey(ctx, "b")
+		require.NoError(t, err)
+		assert.Nil(t, key)
+		assert.Equal(t, int32(1), requests.Load())
+
+		remote.attemptedAt = time.Now().Add(-2 * time.Minute)
+		key, err = remote.LookupKey(ctx, "b")
+		require.NoError(t, err)
+		assert.Equal(t, "b", key.KeyID)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := NewRemoteJWKS(issuer.URL+"/notfound").LookupKey(ctx, "a")
+		require.Error(t, err)
+		_, err = NewRemoteJWKS(issuer.URL+"/invalid.json").LookupKey(ctx, "a")
+		require.Error(t, err)
+		_, err = NewRemoteJWKS("http://invalid host").LookupKey(ctx, "a")
+		require.Error(t, err)
+
+		remote := &RemoteJWKS{URL: issuer.URL + "/jwks.json", Client: issuer.Client()}
+		_, err = remote.LookupKey(ctx, "a")
+		require.NoError(t, err)
+		remote.URL = issuer.URL + "/notfound"
+		remote.attemptedAt = time.Now().Add(-2 * time.Minute)
+		_, err = remote.LookupKey(ctx, "b")
+		require.Error(t, err)
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		requests.Store(0)
+		remote := NewRemoteJWKS(issuer.URL + "/jwks.json")
+		_, err := remote.LookupKey(ctx, "a")
+		require.NoError(t, err)
+
+		// The issuer is down: the last key set is still used
+		remote.URL = issuer.URL + "/notfound"
+		remote.fetchedAt = time.Now().Add(-2 * time.Hour)
+		remote.attemptedAt = remote.fetchedAt
+		key, err := remote.LookupKey(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, "a", key.KeyID)
+		assert.Equal(t, int32(2), requests.Load())
+
+		// Not fetched again right after a failed attempt
+		key, err = remote.LookupKey(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, "a", key.KeyID)
+		key, err = remote.LookupKey(ctx, "b")
+		require.NoError(t, err)
+		assert.Nil(t, key)
+		assert.Equal(t, int32(2), requests.Load())
+
+		// First fetch failed: the error is returned without fetching again
+		remote = NewRemoteJWKS(issuer.URL + "/notfound")
+		_, err = remote.LookupKey(ctx, "a")
+		require.Error(t, err)
+		_, err = remote.LookupKey(ctx, "a")
+		require.Error(t, err)
+		assert.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		requests.Store(0)
+		release := make(chan struct{})
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+			<-release
+			_ = json.NewEncoder(w).Encode(keySet.Load())
+		}))
+		t.Cleanup(slow.Close)
+		remote := NewRemoteJWKS(slow.URL)
+
+		// A cancelled lookup doesn't cancel the fetch shared with other lookups
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := remote.LookupKey(cancelledCtx, "a")
+		require.ErrorIs(t, err, context.Canceled)
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				key, err := remote.LookupKey(ctx, "a")
+				assert.NoError(t, err)
+				if assert.NotNil(t, key) {
+					assert.Equal(t, "a", key.KeyID)
+				}
+			}()
+		}
+		close(release)
+		wg.Wait()
+		assert.Equal(t, int32(1), requests.Load())
+	})
+}
+
+func TestJWTServiceKeyRotation(t *testing.T) {
+	rootDir := testutil.FindRootDirectory()
+
+	t.Run("PublicKeySet", func(t *testing.T) {
+		server, service := prepareJWTServiceTest(t)
+		keySet, err := service.PublicKeySet()
+		require.NoError(t, err)
+		assert.Empty(t, keySet.Keys)
+
+		server, service = prepareJWTServiceTest(t)
+		_, previousRSA := writeTestKeyPair(t, false)
+		_, previousEC := writeTestKeyPair(t, true)
+		server.Config().Set("auth.jwt.rsa.public", path.Join(rootDir, "resources/rsa/public.pem"))
+		server.Config().Set("auth.jwt.rsa.previous", []string{previousRSA})
+		server.Config().Set("auth.jwt.ecdsa.public", path.Join(rootDir, "resources/ecdsa/public.pem"))
+		server.Config().Set("auth.jwt.ecdsa.previous", []string{previousEC})
+
+		keySet, err = service.PublicKeySet()
+		require.NoError(t, err)
+		require.Len(t, keySet.Keys, 4)
+		assert.Equal(t, []string{"RSA", "RSA", "EC", "EC"}, []string{keySet.Keys[0].KeyType, keySet.Keys[1].KeyType, keySet.Keys[2].KeyType, keySet.Keys[3].KeyType})
+
+		currentKey, err := service.GetKey("auth.jwt.rsa.public")
+		require.NoError(t, err)
+		kid, err := KeyID(currentKey)
+		require.NoError(t, err)
+		assert.Equal(t, kid, keySet.Keys[0].KeyID)
+
+		cached, err := service.PublicKeySet()
+		require.NoError(t, err)
+		assert.Same(t, keySet, cached)
+	})
+
+	t.Run("cache_follows_config", func(t *testing.T) {
+		server, service := prepareJWTServiceTest(t)
+		_, newPublic := writeTestKeyPair(t, false)
+		server.Config().Set("auth.jwt.rsa.public", path.Join(rootDir, "resources/rsa/public.pem"))
+
+		oldKey, err := service.GetKey("auth.jwt.rsa.public")
+		require.NoError(t, err)
+		oldKeySet, err := service.PublicKeySet()
+		require.NoError(t, err)
+		require.Len(t, oldKeySet.Keys, 1)
+
+		// Rotation: the current key becomes the previous key
+		server.Config().Set("auth.jwt.rsa.public", newPublic)
+		server.Config().Set("auth.jwt.rsa.previous", []string{path.Join(rootDir, "resources/rsa/public.pem")})
+
+		newKey, err := service.GetKey("auth.jwt.rsa.public")
+		require.NoError(t, err)
+		assert.NotEqual(t, oldKey, newKey)
+		keySet, err := service.PublicKeySet()
+		require.NoError(t, err)
+		require.Len(t, keySet.Keys, 2)
+		assert.NotEqual(t, oldKeySet.Keys[0].KeyID, keySet.Keys[0].KeyID)
+		assert.Equal(t, oldKeySet.Keys[0].KeyID, keySet.Keys[1].KeyID)
+
+		cached, err := service.GetKey("auth.jwt.rsa.public")
+		require.NoError(t, err)
+		assert.Same(t, newKey, cached)
+	})
+
+	t.Run("PublicKeySet_errors", func(t *testing.T) {
+		server, service := prepareJWTServiceTest(t)
+		server.Config().Set("auth.jwt.rsa.public", path.Join(rootDir, "resources/notafile.pem"))
+		_, err := service.PublicKeySet()
+		require.Error(t, err)
+
+		server, service = prepareJWTServiceTest(t)
+		server.Config().Set("auth.jwt.rsa.previous", []string{path.Join(rootDir, "resources/notafile.pem")})
+		_, err = service.PublicKeySet()
+		require.Error(t, err)
+
+		server, service = prepareJWTServiceTest(t)
+		server.Config().Set("auth.jwt.ecdsa.previous", []string{path.Join(rootDir, "resources/rsa/public.pem")})
+		_, err = service.PublicKeySet()
+		require.Error(t, err)
+	})
+
+	t.Run("GenerateTokenWithClaims_kid", func(t *testing.T) {
+		server, service := prepareJWTServiceTest(t)
+		server.Config().Set("auth.jwt.rsa.private", path.Join(rootDir, "resources/rsa/private.pem"))
+		server.Config().Set("auth.jwt.rsa.public", path.Join(rootDir, "resources/rsa/public.pem"))
+		server.Config().Set("auth.jwt.secret", "secret")
+
+		tokenString, err := service.GenerateTokenWithClaims(jwt.MapClaims{"sub": "johndoe"}, jwt.SigningMethodRS256)
+		require.NoError(t, err)
+		token, _, err := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
+		require.NoError(t, err)
+		keySet, err := service.PublicKeySet()
+		require.NoError(t, err)
+		assert.Equal(t, keySet.Keys[0].KeyID, token.Header["kid"])
+
+		tokenString, err = service.GenerateToken("johndoe")
+		require.NoError(t, err)
+		token, _, err = new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
+		require.NoError(t, err)
+		assert.NotContains(t, token.Header, "kid")
+	})
+
+	t.Run("GetPublicKey", func(t *testing.T) {
+		server, service := prepareJWTServiceTest(t)
+		_, previous := writeTestKeyPair(t, false)
+		server.Config().Set("auth.jwt.rsa.public", path.Join(rootDir, "resources/rsa/public.pem"))
+		server.Config().Set("auth.jwt.rsa.previous", []string{previous})
+		server.Config().Set("auth.jwt.ecdsa.public", path.Join(rootDir, "resources/ecdsa/public.pem"))
+
+		current, err := service.GetKey("auth.jwt.rsa.public")
+		require.NoError(t, err)
+		key, err := service.GetPublicKey(jwt.SigningMethodRS256, "")
+		require.NoError(t, err)
+		assert.Equal(t, current, key)
+
+		currentEC, err := service.GetKey("auth.jwt.ecdsa.public")
+		require.NoError(t, err)
+		key, err = service.GetPublicKey(jwt.SigningMethodES256, "")
+		require.NoError(t, err)
+		assert.Equal(t, currentEC, key)
+
+		keySet, err := service.PublicKeySet()
+		require.NoError(t, err)
+		key, err = service.GetPublicKey(jwt.SigningMethodRS256, keySet.Keys[1].KeyID)
+		require.NoError(t, err)
+		previousKey, err := keySet.Keys[1].PublicKey()
+		require.NoError(t, err)
+		assert.Equal(t, previousKey, key)
+
+		// Key type doesn't match signing method: fall back to the current key
+		key, err = service.GetPublicKey(jwt.SigningMethodES256, keySet.Keys[1].KeyID)
+		require.NoError(t, err)
+		assert.Equal(t, currentEC, key)
+
+		// Unknown key ID: fall back to the current key
+		key, err = service.GetPublicKey(jwt.SigningMethodRS256, "key-1")
+		require.NoError(t, err)
+		assert.Equal(t, current, key)
+
+		server.Config().Set("auth.jwt.ecdsa.public", nil)
+		key, err = service.GetPublicKey(jwt.SigningMethodES256, "key-1")
+		require.NoError(t, err)
+		assert.Nil(t, key)
+
+		_, err = service.GetPublicKey(jwt.SigningMethodHS256, "")
+		require.Error(t, err)
+	})
+}
+
+func TestJWTAuthenticatorKeySet(t *testing.T) {
+	rootDir := testutil.FindRootDirectory()
+
+	authenticate := func(t *testing.T, server *testutil.TestServer, authenticator goyave.Middleware, token string) (int, map[string]string) {
+		request := server.NewTestRequest(http.MethodGet, "/protected", nil)
+		request.Request().Header.Set("Authorization", "Bearer "+token)
+		request.Route = &goyave.Route{Meta: map[string]any{MetaAuth: true}}
+		resp := server.TestMiddleware(authenticator, request, func(response *goyave.Response, _ *goyave.Request) {
+			response.Status(http.StatusOK)
+		})
+		if resp.StatusCode == http.StatusOK {
+			assert.NoError(t, resp.Body.Close())
+			return resp.StatusCode, nil
+		}
+		body, err := testutil.ReadJSONBody[map[string]string](resp.Body)
+		assert.NoError(t, resp.Body.Close())
+		require.NoError(t, err)
+		return resp.StatusCode, body
+	}
+
+	t.Run("rotation", func(t *testing.T) {
+		server, user := prepareAuthenticatorTest(t)
+		previousPrivate, previousPublic := writeTestKeyPair(t, false)
+		server.Config().Set("auth.jwt.rsa.private", previousPrivate)
+		server.Config().Set("auth.jwt.rsa.public", previousPublic)
+
+		// Token signed before the rotation
+		oldToken, err := NewJWTService(server.Config(), &osfs.FS{}).GenerateTokenWithClaims(jwt.MapClaims{"sub": user.Email}, jwt.SigningMethodRS256)
+		require.NoError(t, err)
+
+		server.Config().Set("auth.jwt.rsa.private", path.Join(rootDir, "resources/rsa/private.pem"))
+		server.Config().Set("auth.jwt.rsa.public", path.Join(rootDir, "resources/rsa/public.pem"))
+		server.Config().Set("auth.jwt.rsa.previous", []string{previousPublic})
+		service := NewJWTService(server.Config(), &osfs.FS{})
+		server.RegisterService(service)
+		newToken, err := service.GenerateTokenWithClaims(jwt.MapClaims{"sub": user.Email}, jwt.SigningMethodRS256)
+		require.NoError(t, err)
+
+		a := NewJWTAuthenticator(&MockUserService[TestUser]{user: user})
+		a.SigningMethod = jwt.SigningMethodRS256
+		authenticator := Middleware(a)
+
+		status, _ := authenticate(t, server, authenticator, oldToken)
+		assert.Equal(t, http.StatusOK, status)
+		status, _ = authenticate(t, server, authenticator, newToken)
+		assert.Equal(t, http.StatusOK, status)
+
+		// Previous key removed
+		server.Config().Set("auth.jwt.rsa.previous", []string{})
+		status, body := authenticate(t, server, authenticator, oldToken)
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, map[string]string{"error": server.Lang.GetDefault().Get("auth.jwt-invalid")}, body)
+	})
+
+	t.Run("remote", func(t *testing.T) {
+		server, user := prepareAuthenticatorTest(t)
+		issuerPrivate, issuerPublic := writeTestKeyPair(t, true)
+		server.Config().Set("auth.jwt.ecdsa.private", issuerPrivate)
+		server.Config().Set("auth.jwt.ecdsa.public", issuerPublic)
+		issuerService := NewJWTService(server.Config(), &osfs.FS{})
+		keySet, err := issuerService.PublicKeySet()
+		require.NoError(t, err)
+		token, err := issuerService.GenerateTokenWithClaims(jwt.MapClaims{"sub": user.Email}, jwt.SigningMethodES256)
+		require.NoError(t, err)
+
+		issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_ = json.NewEncoder(w).Encode(keySet)
+		}))
+		t.Cleanup(issuer.Close)
+
+		// The local keys are not used
+		server.Config().Set("auth.jwt.ecdsa.public", path.Join(rootDir, "resources/ecdsa/public.pem"))
+		a := NewJWTAuthenticator(&MockUserService[TestUser]{user: user})
+		a.SigningMethod = jwt.SigningMethodES256
+		a.KeySet = NewRemoteJWKS(issuer.URL)
+		authenticator := Middleware(a)
+
+		status, _ := authenticate(t, server, authenticator, token)
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("unknown_key", func(t *testing.T) {
+		server, user := prepareAuthenticatorTest(t)
+		server.Config().Set("auth.jwt.rsa.private", path.Join(rootDir, "resources/rsa/private.pem"))
+		token, err := NewJWTService(server.Config(), &osfs.FS{}).GenerateTokenWithClaims(jwt.MapClaims{"sub": user.Email}, jwt.SigningMethodRS256)
+		require.NoError(t, err)
+
+		a := NewJWTAuthenticator(&MockUserService[TestUser]{user: user})
+		a.SigningMethod = jwt.SigningMethodRS256
+		a.KeySet = &JWKS{Keys: []*JWK{}}
+		authenticator := Middleware(a)
+
+		status, body := authenticate(t, server, authenticator, token)
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, map[string]string{"error": server.Lang.GetDefault().Get("auth.jwt-invalid")}, body)
+	})
+
+	t.Run("external_kid", func(t *testing.T) {
+		server, user := prepareAuthenticatorTest(t)
+		server.Config().Set("auth.jwt.rsa.public", path.Join(rootDir, "resources/rsa/public.pem"))
+
+		// Token signed by an external signer sharing the configured key but using its own key ID
+		data, err := os.ReadFile(path.Join(rootDir, "resources/rsa/private.pem"))
+		require.NoError(t, err)
+		signingKey, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+		require.NoError(t, err)
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": user.Email, "exp": time.Now().Add(time.Minute).Unix()})
+		token.Header["kid"] = "key-1"
+		tokenString, err := token.SignedString(signingKey)
+		require.NoError(t, err)
+
+		a := NewJWTAuthenticator(&MockUserService[TestUser]{user: user})
+		a.SigningMethod = jwt.SigningMethodRS256
+		authenticator := Middleware(a)
+
+		status, _ := authenticate(t, server, authenticator, tokenString)
+		assert.Equal(t, http.StatusOK, status)
+
+		// Signed with another key
+		otherPrivate, _ := writeTestKeyPair(t, false)
+		data, err = os.ReadFile(otherPrivate)
+		require.NoError(t, err)
+		otherKey, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+		require.NoError(t, err)
+		tokenString, err = token.SignedString(otherKey)
+		require.NoError(t, err)
+		status, body := authenticate(t, server, authenticator, tokenString)
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, map[string]string{"error": server.Lang.GetDefault().Get("auth.jwt-invalid")}, body)
+	})
+
+	t.Run("key_set_error", func(t *testing.T) {
+		server, user := prepareAuthenticatorTest(t)
+		buf := &bytes.Buffer{}
+		server.Logger = slog.New(slog.NewHandler(false, buf))
+		server.Config().Set("auth.jwt.rsa.private", path.Join(rootDir, "resources/rsa/private.pem"))
+		token, err := NewJWTService(server.Config(), &osfs.FS{}).GenerateTokenWithClaims(jwt.MapClaims{"sub": user.Email}, jwt.SigningMethodRS256)
+		require.NoError(t, err)
+		parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+		require.NoError(t, err)
+
+		keySets := []KeySet{
+			NewRemoteJWKS("http://invalid host"),
+			&JWKS{Keys: []*JWK{{KeyType: "RSA", KeyID: parsed.Header["kid"].(string), N: "!", E: "AQAB"}}},
+		}
+		for _, keySet := range keySets {
+			buf.Reset()
+			a := NewJWTAuthenticator(&MockUserService[TestUser]{user: user})
+			a.SigningMethod = jwt.SigningMethodRS256
+			a.KeySet = keySet
+			authenticator := Middleware(a)
+
+			status, body := authenticate(t, server, authenticator, token)
+			assert.Equal(t, http.StatusUnauthorized, status)
+			assert.Equal(t, map[string]string{"error": server.Lang.GetDefault().Get("auth.jwt-invalid")}, body)
+			assert.NotEmpty(t, buf.String())
+		}
+	})
+}
@@ -1,14 +1,17 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"goyave.dev/goyave/v5"
 	"goyave.dev/goyave/v5/config"
@@ -34,11 +37,25 @@ func init() {
 		IsSlice:          false,
 		AuthorizedValues: []any{},
 	})
-	registerKeyConfigEntry("auth.jwt.secret")
+	config.Register("auth.jwt.refreshExpiry", config.Entry{
+		Value:            604800,
+		Type:             reflect.Int,
+		IsSlice:          false,
+		AuthorizedValues: []any{},
+	})
+	config.Register("auth.jwt.secret", config.Entry{
+		Value:            nil,
+		Type:             reflect.String,
+		IsSlice:          false,
+		AuthorizedValues: []any{},
+	})
+	config.RegisterSecret("auth.jwt.secret")
 	registerKeyConfigEntry("auth.jwt.rsa.public")
 	registerKeyConfigEntry("auth.jwt.rsa.private")
 	registerKeyConfigEntry("auth.jwt.ecdsa.public")
 	registerKeyConfigEntry("auth.jwt.ecdsa.private")
+	registerPreviousKeysConfigEntry("auth.jwt.rsa.previous")
+	registerPreviousKeysConfigEntry("auth.jwt.ecdsa.previous")
 }
 
 func registerKeyConfigEntry(name string) {
@@ -50,20 +67,54 @@ func registerKeyConfigEntry(name string) {
 	})
 }
 
+func registerPreviousKeysConfigEntry(name string) {
+	config.Register(name, config.Entry{
+		Value:            []string{},
+		Type:             reflect.String,
+		IsSlice:          true,
+		AuthorizedValues: []any{},
+	})
+}
+
+// cachedKey a key or key set cached by the `JWTService`, along with the path of
+// the file(s) it was loaded from.
+type cachedKey struct {
+	key  any
+	path string
+}
+
 // JWTService providing signature keys cache and JWT generation.
 //
+// Tokens signed with RSA or ECDSA have a "kid" header identifying the key used
+// to sign them (see `KeyID()`). To rotate keys without invalidating outstanding tokens,
+// add the path to the previous public key to the `auth.jwt.rsa.previous` or
+// `auth.jwt.ecdsa.previous` config entries: tokens signed with these keys are still
+// accepted by the `JWTAuthenticator` and the keys are published by the `JWKSController`.
+//
 // This service is identified by `auth.JWTServiceName`.
 type JWTService struct {
 	fs     fs.FS
-	config *config.Config
+	config func() *config.Config
 	cache  sync.Map
 }
 
 // NewJWTService create a new `JWTService` with the given config and file system.
 // The file system is used to get the signing keys.
-func NewJWTService(config *config.Config, fs fs.FS) *JWTService {
+func NewJWTService(cfg *config.Config, fs fs.FS) *JWTService {
 	return &JWTService{
-		config: config,
+		config: func() *config.Config { return cfg },
+		fs:     fs,
+	}
+}
+
+// NewJWTServiceFromServer create a new `JWTService` using the configuration of the given
+// server and the given file system. Unlike `NewJWTService()`, the configuration is read
+// each time the service is used, so the changes made by a config reload (see
+// `goyave.Options.ReloadableConfig`), such as a new `auth.jwt.expiry` or rotated keys,
+// take effect without restarting the server.
+func NewJWTServiceFromServer(server *goyave.Server, fs fs.FS) *JWTService {
+	return &JWTService{
+		config: server.Config,
 		fs:     fs,
 	}
 }
@@ -83,6 +134,7 @@ func (s *JWTService) Name() string {
 //   - `sub`: has the value of the `id` parameter
 //   - `nbf`: "Not before", the current timestamp is used
 //   - `exp`: "Expiry", the current timestamp plus the `auth.jwt.expiry` config entry.
+//   - `jti`: "JWT ID", a random UUID used to revoke the token (see `TokenDenylist`)
 func (s *JWTService) GenerateToken(username any) (string, error) {
 	return s.GenerateTokenWithClaims(jwt.MapClaims{"sub": username}, jwt.SigningMethodHS256)
 }
@@ -99,14 +151,18 @@ func (s *JWTService) GenerateToken(username any) (string, error) {
 // The generated token will also contain the following claims:
 //   - `nbf`: "Not before", the current timestamp is used
 //   - `exp`: "Expiry", the current timestamp plus the `auth.jwt.expiry` config entry.
+//   - `jti`: "JWT ID", a random UUID used to revoke the token (see `TokenDenylist`)
+//
+// Tokens signed with RSA or ECDSA also have a "kid" header identifying the signing key.
 //
-// `nbf` and `exp` can be overridden if they are set in the `claims` parameter.
+// `nbf`, `exp` and `jti` can be overridden if they are set in the `claims` parameter.
 func (s *JWTService) GenerateTokenWithClaims(claims jwt.MapClaims, signingMethod jwt.SigningMethod) (string, error) {
-	exp := time.Duration(s.config.GetInt("auth.jwt.expiry")) * time.Second
+	exp := time.Duration(s.config().GetInt("auth.jwt.expiry")) * time.Second
 	now := time.Now()
 	customClaims := jwt.MapClaims{
 		"nbf": now.Unix(),          // Not Before
 		"exp": now.Add(exp).Unix(), // Expiry
+		"jti": uuid.NewString(),    // JWT ID
 	}
 	for k, c := range claims {
 		customClaims[k] = c
@@ -117,6 +173,14 @@ func (s *JWTService) GenerateTokenWithClaims(claims jwt.MapClaims, signingMethod
 	if err != nil {
 		return "", err
 	}
+	switch signingMethod.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		kid, err := KeyID(key)
+		if err != nil {
+			return "", err
+		}
+		token.Header["kid"] = kid
+	}
 	result, err := token.SignedString(key)
 	return result, errorutil.New(err)
 }
@@ -131,13 +195,15 @@ func (s *JWTService) GenerateTokenWithClaims(claims jwt.MapClaims, signingMethod
 //   - `auth.jwt.secret`
 //
 // To optimize subsequent requests and avoid IO for keys that are stored on the
-// disk, the keys are cached.
+// disk, the keys are cached. The cache is bound to the path of the key file, so
+// pointing the config entry to another file takes effect without a restart.
 func (s *JWTService) GetKey(entry string) (any, error) {
-	if k, ok := s.cache.Load(entry); ok {
-		return k, nil
+	path := s.config().GetString(entry)
+	if k, ok := s.cache.Load(entry); ok && k.(cachedKey).path == path {
+		return k.(cachedKey).key, nil
 	}
 
-	data, err := fs.ReadFile(s.fs, s.config.GetString(entry))
+	data, err := fs.ReadFile(s.fs, path)
 	if err != nil {
 		return nil, errorutil.New(err)
 	}
@@ -155,7 +221,7 @@ func (s *JWTService) GetKey(entry string) (any, error) {
 	}
 
 	if err == nil {
-		s.cache.Store(entry, key)
+		s.cache.Store(entry, cachedKey{key: key, path: path})
 	}
 	return key, errorutil.New(err)
 }
@@ -168,12 +234,117 @@ func (s *JWTService) GetPrivateKey(signingMethod jwt.SigningMethod) (any, error)
 	case *jwt.SigningMethodECDSA:
 		return s.GetKey("auth.jwt.ecdsa.private")
 	case *jwt.SigningMethodHMAC:
-		return []byte(s.config.GetString("auth.jwt.secret")), nil
+		return []byte(s.config().GetString("auth.jwt.secret")), nil
 	default:
 		return nil, errorutil.New("unsupported JWT signing method: " + signingMethod.Alg())
 	}
 }
 
+// GetPublicKey loads the public key that corresponds to the given `signingMethod` and
+// key ID ("kid" header). If the key ID is empty, the current public key defined in the
+// config is returned. Otherwise, the key is searched in the `PublicKeySet()`. If no key
+// of the set matches, the current public key is returned if it is defined, so tokens
+// issued by an external signer using its own key IDs are still verified with it.
+// Returns nil if no key matches.
+func (s *JWTService) GetPublicKey(signingMethod jwt.SigningMethod, kid string) (any, error) {
+	var entry string
+	switch signingMethod.(type) {
+	case *jwt.SigningMethodRSA:
+		entry = "auth.jwt.rsa.public"
+	case *jwt.SigningMethodECDSA:
+		entry = "auth.jwt.ecdsa.public"
+	default:
+		return nil, errorutil.New("unsupported JWT signing method: " + signingMethod.Alg())
+	}
+	if kid == "" {
+		return s.GetKey(entry)
+	}
+
+	keySet, err := s.PublicKeySet()
+	if err != nil {
+		return nil, err
+	}
+	key, err := lookupPublicKey(context.Background(), keySet, kid, signingMethod)
+	if err != nil || key != nil {
+		return key, err
+	}
+	if cfg := s.config(); !cfg.Has(entry) || cfg.GetString(entry) == "" {
+		return nil, nil
+	}
+	return s.GetKey(entry)
+}
+
+// PublicKeySet returns the JSON Web Key Set containing the public keys defined by the
+// `auth.jwt.rsa.public`, `auth.jwt.rsa.previous`, `auth.jwt.ecdsa.public` and
+// `auth.jwt.ecdsa.previous` config entries. Entries that are not set are ignored.
+// HMAC secrets are never part of the set.
+//
+// The key set is cached. Like for `GetKey()`, the cache is bound to the paths of the
+// key files.
+func (s *JWTService) PublicKeySet() (*JWKS, error) {
+	cfg := s.config()
+	paths := keySetPaths(cfg)
+	if k, ok := s.cache.Load("jwks"); ok && k.(cachedKey).path == paths {
+		return k.(cachedKey).key.(*JWKS), nil
+	}
+
+	keySet := &JWKS{Keys: []*JWK{}}
+	families := []struct {
+		current  string
+		previous string
+		parse    func([]byte) (any, error)
+	}{
+		{"auth.jwt.rsa.public", "auth.jwt.rsa.previous", func(data []byte) (any, error) { return jwt.ParseRSAPublicKeyFromPEM(data) }},
+		{"auth.jwt.ecdsa.public", "auth.jwt.ecdsa.previous", func(data []byte) (any, error) { return jwt.ParseECPublicKeyFromPEM(data) }},
+	}
+	for _, family := range families {
+		keys := []any{}
+		if cfg.Has(family.current) && cfg.GetString(family.current) != "" {
+			key, err := s.GetKey(family.current)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+		for _, path := range cfg.GetStringSlice(family.previous) {
+			data, err := fs.ReadFile(s.fs, path)
+			if err != nil {
+				return nil, errorutil.New(err)
+			}
+			key, err := family.parse(data)
+			if err != nil {
+				return nil, errorutil.New(err)
+			}
+			keys = append(keys, key)
+		}
+		for _, key := range keys {
+			jwk, err := NewJWK(key)
+			if err != nil {
+				return nil, err
+			}
+			keySet.Keys = append(keySet.Keys, jwk)
+		}
+	}
+
+	s.cache.Store("jwks", cachedKey{key: keySet, path: paths})
+	return keySet, nil
+}
+
+// keySetPaths returns the paths of all the key files composing the `PublicKeySet()`,
+// used to identify the cached key set.
+func keySetPaths(cfg *config.Config) string {
+	paths := []string{}
+	for _, entry := range []string{"auth.jwt.rsa.public", "auth.jwt.ecdsa.public"} {
+		if cfg.Has(entry) {
+			paths = append(paths, entry+"="+cfg.GetString(entry))
+		}
+	}
+	for _, entry := range []string{"auth.jwt.rsa.previous", "auth.jwt.ecdsa.previous"} {
+		paths = append(paths, entry+"="+strings.Join(cfg.GetStringSlice(entry), ","))
+	}
+	return strings.Join(paths, "\n")
+}
+
 // JWTAuthenticator implementation of Authenticator using a JSON Web Token.
 //
 // The T parameter represents the user DTO and should not be a pointer.
@@ -192,6 +363,17 @@ type JWTAuthenticator[T any] struct {
 	// Defaults to "sub".
 	ClaimName string
 
+	// KeySet optionally defines the public keys used to verify tokens signed with
+	// RSA or ECDSA, for example a `*JWKS` loaded with `LoadJWKS()` or a `*RemoteJWKS`
+	// fetching the key set of an issuer. The key is selected using the "kid" header
+	// of the token. If nil, the keys defined in the config are used (see `JWTService.GetPublicKey()`).
+	// Errors returned by the key set are logged and the token is rejected.
+	KeySet KeySet
+
+	// Denylist optionally defines the revoked tokens. Tokens having a "jti"
+	// claim present in the denylist are rejected.
+	Denylist TokenDenylist
+
 	// Optional defines if the authenticator allows requests that
 	// don't provide credentials. Handlers should therefore check
 	// if `request.User` is not `nil` before accessing it.
@@ -214,7 +396,7 @@ func (a *JWTAuthenticator[T]) Init(server *goyave.Server) {
 
 	service, ok := server.LookupService(JWTServiceName)
 	if !ok {
-		service = NewJWTService(server.Config(), &osfs.FS{})
+		service = NewJWTServiceFromServer(server, &osfs.FS{})
 		server.RegisterService(service)
 	}
 	a.service = service.(*JWTService)
@@ -225,6 +407,8 @@ func (a *JWTAuthenticator[T]) Init(server *goyave.Server) {
 // If no user can be authenticated, returns an error.
 //
 // If the token is valid and has claims, those claims will be added to `request.Extra` with the key "jwt_claims".
+//
+// If the authenticator has a `Denylist`, tokens whose "jti" claim is denied are rejected.
 func (a *JWTAuthenticator[T]) Authenticate(request *goyave.Request) (*T, error) {
 	tokenString, ok := request.BearerToken()
 	if tokenString == "" || !ok {
@@ -234,12 +418,26 @@ func (a *JWTAuthenticator[T]) Authenticate(request *goyave.Request) (*T, error)
 		return nil, fmt.Errorf(request.Lang.Get("auth.no-credentials-provided"))
 	}
 
-	token, err := jwt.Parse(tokenString, a.keyFunc)
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		return a.keyFunc(request.Context(), token)
+	})
 
 	if err == nil && token.Valid {
 		if claims, ok := token.Claims.(jwt.MapClaims); ok {
 			request.Extra[ExtraJWTClaims{}] = claims
 
+			if a.Denylist != nil {
+				if jti, ok := claims["jti"].(string); ok && jti != "" {
+					denied, err := a.Denylist.IsDenied(request.Context(), jti)
+					if err != nil {
+						panic(errorutil.New(err))
+					}
+					if denied {
+						return nil, fmt.Errorf(request.Lang.Get("auth.jwt-revoked"))
+					}
+				}
+			}
+
 			claimName := a.ClaimName
 			if claimName == "" {
 				claimName = "sub"
@@ -256,29 +454,21 @@ func (a *JWTAuthenticator[T]) Authenticate(request *goyave.Request) (*T, error)
 		}
 	}
 
-	return nil, a.makeError(request.Lang, err.(*jwt.ValidationError).Errors)
+	return nil, makeJWTError(request.Lang, err.(*jwt.ValidationError).Errors)
 }
 
-func (a *JWTAuthenticator[T]) keyFunc(token *jwt.Token) (any, error) {
+func (a *JWTAuthenticator[T]) keyFunc(ctx context.Context, token *jwt.Token) (any, error) {
 	switch a.SigningMethod.(type) {
 	case *jwt.SigningMethodRSA:
 		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
 			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
 		}
-		key, err := a.service.GetKey("auth.jwt.rsa.public")
-		if err != nil {
-			panic(err)
-		}
-		return key, nil
+		return a.publicKey(ctx, token)
 	case *jwt.SigningMethodECDSA:
 		if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
 			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
 		}
-		key, err := a.service.GetKey("auth.jwt.ecdsa.public")
-		if err != nil {
-			panic(err)
-		}
-		return key, nil
+		return a.publicKey(ctx, token)
 	case *jwt.SigningMethodHMAC, nil:
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
@@ -289,7 +479,33 @@ func (a *JWTAuthenticator[T]) keyFunc(token *jwt.Token) (any, error) {
 	}
 }
 
-func (a *JWTAuthenticator[T]) makeError(language *lang.Language, bitfield uint32) error {
+func (a *JWTAuthenticator[T]) publicKey(ctx context.Context, token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	if a.KeySet == nil {
+		key, err := a.service.GetPublicKey(token.Method, kid)
+		if err != nil {
+			panic(err)
+		}
+		if key == nil {
+			return nil, fmt.Errorf("Unknown key: %q", kid)
+		}
+		return key, nil
+	}
+
+	// The key set may be remote or contain malformed keys: the error is logged
+	// and the token rejected instead of failing the request.
+	key, err := lookupPublicKey(ctx, a.KeySet, kid, token.Method)
+	if err != nil {
+		a.Logger().Error(err)
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("Unknown key: %q", kid)
+	}
+	return key, nil
+}
+
+func makeJWTError(language *lang.Language, bitfield uint32) error {
 	if bitfield&jwt.ValidationErrorNotValidYet != 0 {
 		return fmt.Errorf(language.Get("auth.jwt-not-valid-yet"))
 	} else if bitfield&jwt.ValidationErrorExpired != 0 {
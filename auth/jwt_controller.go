@@ -1,9 +1,11 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"reflect"
+	"time"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/samber/lo"
@@ -22,9 +24,13 @@ type TokenFunc[T any] func(request *goyave.Request, user *T) (string, error)
 
 // JWTController controller adding a login route returning a JWT for quick prototyping.
 //
+// If a `RefreshTokenStore` is defined, the login route also returns a refresh token, and
+// the "/refresh" and "/logout" routes are registered. Refresh tokens are rotated: each
+// refresh token can only be used once and a new one is returned by the "/refresh" route.
+//
 // The T parameter represents the user DTO and should not be a pointer. The DTO used should be
 // different from the DTO returned to clients as a response because it needs to contain the user's password.
-type JWTController[T any] struct { // TODO refresh token
+type JWTController[T any] struct {
 	goyave.Component
 
 	jwtService *JWTService
@@ -51,6 +57,20 @@ type JWTController[T any] struct { // TODO refresh token
 	// PasswordField the name of T's struct field that holds the user's hashed password.
 	// It will be used to compare the password hash with the user input.
 	PasswordField string
+
+	// RefreshTokenStore optionally enables refresh tokens. Refresh tokens expire
+	// in the amount of seconds defined by the `auth.jwt.refreshExpiry` config entry.
+	RefreshTokenStore RefreshTokenStore
+
+	// Denylist optionally defines where the revoked JWTs are stored. When a refresh token
+	// is revoked, the JWT issued along with it is added to the denylist. This denylist
+	// should also be used by the `JWTAuthenticator`.
+	Denylist TokenDenylist
+
+	// RefreshTokenRequestField the name of the request's body field
+	// containing the refresh token in the "/refresh" and "/logout" routes.
+	// Defaults to "refresh_token"
+	RefreshTokenRequestField string
 }
 
 // NewJWTController create a new JWTController that registers a login route returning a JWT for quick prototyping.
@@ -71,15 +91,20 @@ func (c *JWTController[T]) Init(server *goyave.Server) {
 
 	service, ok := server.LookupService(JWTServiceName)
 	if !ok {
-		service = NewJWTService(server.Config(), &osfs.FS{})
+		service = NewJWTServiceFromServer(server, &osfs.FS{})
 		server.RegisterService(service)
 	}
 	c.jwtService = service.(*JWTService)
 }
 
 // RegisterRoutes register the "/login" route (with validation) on the given router.
+// If the controller has a `RefreshTokenStore`, the "/refresh" and "/logout" routes are registered as well.
 func (c *JWTController[T]) RegisterRoutes(router *goyave.Router) {
 	router.Post("/login", c.Login).Middleware(&parse.Middleware{}).ValidateBody(c.validationRules)
+	if c.RefreshTokenStore != nil {
+		router.Post("/refresh", c.Refresh).Middleware(&parse.Middleware{}).ValidateBody(c.refreshValidationRules)
+		router.Post("/logout", c.Logout).Middleware(&parse.Middleware{}).ValidateBody(c.refreshValidationRules)
+	}
 }
 
 func (c *JWTController[T]) validationRules(_ *goyave.Request) validation.RuleSet {
@@ -99,10 +124,29 @@ func (c *JWTController[T]) validationRules(_ *goyave.Request) validation.RuleSet
 	}
 }
 
+func (c *JWTController[T]) refreshValidationRules(_ *goyave.Request) validation.RuleSet {
+	return validation.RuleSet{
+		{Path: validation.CurrentElement, Rules: validation.List{
+			validation.Required(),
+			validation.Object(),
+		}},
+		{Path: c.refreshTokenRequestField(), Rules: validation.List{
+			validation.Required(),
+			validation.String(),
+		}},
+	}
+}
+
+func (c *JWTController[T]) refreshTokenRequestField() string {
+	return lo.Ternary(c.RefreshTokenRequestField == "", "refresh_token", c.RefreshTokenRequestField)
+}
+
 // Login POST handler for token-based authentication.
 // Creates a new token for the user authenticated with the body fields
 // defined in the controller and returns it as a response.
 // The password is checked using bcrypt.
+//
+// If the controller has a `RefreshTokenStore`, a refresh token is also returned.
 func (c *JWTController[T]) Login(response *goyave.Response, request *goyave.Request) {
 	body := request.Data.(map[string]any)
 	username := body[lo.Ternary(c.UsernameRequestField == "", "username", c.UsernameRequestField)].(string)
@@ -127,17 +171,157 @@ func (c *JWTController[T]) Login(response *goyave.Response, request *goyave.Requ
 	}
 
 	if !notFound && bcrypt.CompareHashAndPassword([]byte(pass.String()), []byte(password)) == nil {
-		tokenFunc := lo.Ternary(c.TokenFunc == nil, c.defaultTokenFunc, c.TokenFunc)
-		token, err := tokenFunc(request, user)
-		if err != nil {
-			response.Error(errorutil.New(err))
+		c.respondTokens(response, request, username, user)
+		return
+	}
+
+	response.JSON(http.StatusUnauthorized, map[string]string{"error": request.Lang.Get("auth.invalid-credentials")})
+}
+
+// Refresh POST handler exchanging a refresh token for a new JWT and a new refresh token.
+// The given refresh token is consumed and cannot be used again. If the controller has a `Denylist`,
+// the JWT issued along with the consumed refresh token is revoked so only the latest JWT of a session
+// is valid and can be revoked by `RevokeSessions()`.
+func (c *JWTController[T]) Refresh(response *goyave.Response, request *goyave.Request) {
+	refreshToken, ok := c.consumeRefreshToken(response, request)
+	if !ok {
+		return
+	}
+	if refreshToken != nil {
+		if err := c.denyAccessTokens(request.Context(), refreshToken); err != nil {
+			response.Error(err)
+			return
+		}
+	}
+	if refreshToken == nil || refreshToken.ExpiresAt.Before(time.Now()) {
+		response.JSON(http.StatusUnauthorized, map[string]string{"error": request.Lang.Get("auth.refresh-token-invalid")})
+		return
+	}
+
+	user, err := c.UserService.FindByUsername(request.Context(), refreshToken.Subject)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.JSON(http.StatusUnauthorized, map[string]string{"error": request.Lang.Get("auth.invalid-credentials")})
 			return
 		}
+		response.Error(errorutil.New(err))
+		return
+	}
+
+	request.Extra[ExtraRefreshToken{}] = refreshToken
+	c.respondTokens(response, request, refreshToken.Subject, user)
+}
+
+// Logout POST handler revoking the given refresh token and the JWT issued along with it
+// if the controller has a `Denylist`. Responds with "204 No Content", even if the refresh
+// token doesn't exist.
+func (c *JWTController[T]) Logout(response *goyave.Response, request *goyave.Request) {
+	refreshToken, ok := c.consumeRefreshToken(response, request)
+	if !ok {
+		return
+	}
+	if refreshToken != nil {
+		if err := c.denyAccessTokens(request.Context(), refreshToken); err != nil {
+			response.Error(err)
+			return
+		}
+	}
+	response.Status(http.StatusNoContent)
+}
+
+// RevokeSessions revokes all the refresh tokens of the user identified by the given username
+// (the value used as subject when the refresh tokens were issued), as well as the JWTs issued
+// along with them if the controller has a `Denylist`. This can be used to kill the sessions
+// of a compromised account.
+func (c *JWTController[T]) RevokeSessions(ctx context.Context, username string) error {
+	if c.RefreshTokenStore == nil {
+		return errorutil.New("cannot revoke sessions: the controller doesn't have a refresh token store")
+	}
+	refreshTokens, err := c.RefreshTokenStore.RevokeSubject(ctx, username)
+	if err != nil {
+		return errorutil.New(err)
+	}
+	return c.denyAccessTokens(ctx, refreshTokens...)
+}
+
+// consumeRefreshToken consumes the refresh token given in the request body. Returns nil
+// if the refresh token doesn't exist. Returns false if an unexpected error occurred, in which
+// case the response has already been written.
+func (c *JWTController[T]) consumeRefreshToken(response *goyave.Response, request *goyave.Request) (*RefreshToken, bool) {
+	body := request.Data.(map[string]any)
+	token := body[c.refreshTokenRequestField()].(string)
+
+	refreshToken, err := c.RefreshTokenStore.Consume(request.Context(), HashRefreshToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, true
+		}
+		response.Error(errorutil.New(err))
+		return nil, false
+	}
+	return refreshToken, true
+}
+
+func (c *JWTController[T]) denyAccessTokens(ctx context.Context, refreshTokens ...*RefreshToken) error {
+	if c.Denylist == nil {
+		return nil
+	}
+	for _, refreshToken := range refreshTokens {
+		if refreshToken.AccessTokenID == "" || refreshToken.AccessTokenExpiresAt.Before(time.Now()) {
+			continue
+		}
+		if err := c.Denylist.Deny(ctx, refreshToken.AccessTokenID, refreshToken.AccessTokenExpiresAt); err != nil {
+			return errorutil.New(err)
+		}
+	}
+	return nil
+}
+
+// respondTokens generates a new JWT for the given user and a new refresh token if the controller
+// has a `RefreshTokenStore`, and writes them in the response.
+func (c *JWTController[T]) respondTokens(response *goyave.Response, request *goyave.Request, username string, user *T) {
+	tokenFunc := lo.Ternary(c.TokenFunc == nil, c.defaultTokenFunc, c.TokenFunc)
+	token, err := tokenFunc(request, user)
+	if err != nil {
+		response.Error(errorutil.New(err))
+		return
+	}
+	if c.RefreshTokenStore == nil {
 		response.JSON(http.StatusOK, map[string]string{"token": token})
 		return
 	}
 
-	response.JSON(http.StatusUnauthorized, map[string]string{"error": request.Lang.Get("auth.invalid-credentials")})
+	refreshToken, err := c.issueRefreshToken(request.Context(), username, token)
+	if err != nil {
+		response.Error(err)
+		return
+	}
+	response.JSON(http.StatusOK, map[string]string{"token": token, "refresh_token": refreshToken})
+}
+
+func (c *JWTController[T]) issueRefreshToken(ctx context.Context, username, accessToken string) (string, error) {
+	expiry := time.Duration(c.Config().GetInt("auth.jwt.refreshExpiry")) * time.Second
+	token, refreshToken, err := NewRefreshToken(username, expiry)
+	if err != nil {
+		return "", err
+	}
+
+	// The access token was just generated, there is no need to verify it.
+	parsed, _, err := new(jwt.Parser).ParseUnverified(accessToken, jwt.MapClaims{})
+	if err == nil {
+		claims := parsed.Claims.(jwt.MapClaims)
+		if jti, ok := claims["jti"].(string); ok {
+			refreshToken.AccessTokenID = jti
+		}
+		if exp, ok := claims["exp"].(float64); ok {
+			refreshToken.AccessTokenExpiresAt = time.Unix(int64(exp), 0)
+		}
+	}
+
+	if err := c.RefreshTokenStore.Save(ctx, refreshToken); err != nil {
+		return "", errorutil.New(err)
+	}
+	return token, nil
 }
 
 func (c *JWTController[T]) defaultTokenFunc(r *goyave.Request, _ *T) (string, error) {
@@ -145,6 +329,9 @@ func (c *JWTController[T]) defaultTokenFunc(r *goyave.Request, _ *T) (string, er
 	if signingMethod == nil {
 		signingMethod = jwt.SigningMethodHS256
 	}
+	if refreshToken, ok := r.Extra[ExtraRefreshToken{}].(*RefreshToken); ok {
+		return c.jwtService.GenerateTokenWithClaims(jwt.MapClaims{"sub": refreshToken.Subject}, signingMethod)
+	}
 	body := r.Data.(map[string]any)
 	usernameField := lo.Ternary(c.UsernameRequestField == "", "username", c.UsernameRequestField)
 	return c.jwtService.GenerateTokenWithClaims(jwt.MapClaims{"sub": body[usernameField]}, signingMethod)
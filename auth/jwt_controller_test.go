@@ -2,14 +2,18 @@ package auth
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 	"goyave.dev/goyave/v5"
 	"goyave.dev/goyave/v5/slog"
 	"goyave.dev/goyave/v5/util/testutil"
@@ -229,3 +233,190 @@ func TestJWTController(t *testing.T) {
 		}
 	})
 }
+
+func TestJWTControllerRefreshTokens(t *testing.T) {
+	prepareRefreshTest := func(t *testing.T) (*testutil.TestServer, *JWTController[TestUser], *MockTokenDenylist) {
+		server, user := prepareAuthenticatorTest(t)
+		t.Cleanup(func() { server.CloseDB() })
+		server.Config().Set("auth.jwt.secret", "secret")
+		require.NoError(t, server.DB().AutoMigrate(&RefreshToken{}))
+
+		denylist := &MockTokenDenylist{denied: map[string]time.Time{}}
+		controller := NewJWTController(&MockUserService[TestUser]{user: user}, "Password")
+		controller.RefreshTokenStore = NewGormRefreshTokenStore(server.DB())
+		controller.Denylist = denylist
+		server.RegisterRoutes(func(_ *goyave.Server, router *goyave.Router) {
+			router.Controller(controller)
+		})
+		return server, controller, denylist
+	}
+
+	post := func(t *testing.T, server *testutil.TestServer, uri string, data map[string]any) (*http.Response, map[string]string) {
+		body, err := json.Marshal(data)
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, uri, bytes.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		resp := server.TestRequest(request)
+		if resp.StatusCode == http.StatusNoContent {
+			assert.NoError(t, resp.Body.Close())
+			return resp, nil
+		}
+		respBody, err := testutil.ReadJSONBody[map[string]string](resp.Body)
+		assert.NoError(t, resp.Body.Close())
+		require.NoError(t, err)
+		return resp, respBody
+	}
+
+	login := func(t *testing.T, server *testutil.TestServer) map[string]string {
+		resp, respBody := post(t, server, "/login", map[string]any{"username": "johndoe@example.org", "password": "secret"})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NotEmpty(t, respBody["token"])
+		require.NotEmpty(t, respBody["refresh_token"])
+		return respBody
+	}
+
+	jti := func(t *testing.T, token string) string {
+		parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+		require.NoError(t, err)
+		return parsed.Claims.(jwt.MapClaims)["jti"].(string)
+	}
+
+	t.Run("Refresh", func(t *testing.T) {
+		server, _, _ := prepareRefreshTest(t)
+		tokens := login(t, server)
+
+		resp, respBody := post(t, server, "/refresh", map[string]any{"refresh_token": tokens["refresh_token"]})
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NotEmpty(t, respBody["token"])
+		assert.NotEqual(t, tokens["token"], respBody["token"])
+		assert.NotEmpty(t, respBody["refresh_token"])
+		assert.NotEqual(t, tokens["refresh_token"], respBody["refresh_token"])
+
+		parsed, _, err := new(jwt.Parser).ParseUnverified(respBody["token"], jwt.MapClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, "johndoe@example.org", parsed.Claims.(jwt.MapClaims)["sub"])
+
+		// The refresh token was rotated and cannot be used again
+		resp, respBody = post(t, server, "/refresh", map[string]any{"refresh_token": tokens["refresh_token"]})
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, map[string]string{"error": server.Lang.GetDefault().Get("auth.refresh-token-invalid")}, respBody)
+	})
+
+	t.Run("Refresh_expired", func(t *testing.T) {
+		server, _, _ := prepareRefreshTest(t)
+		server.Config().Set("auth.jwt.refreshExpiry", -1)
+		tokens := login(t, server)
+
+		resp, respBody := post(t, server, "/refresh", map[string]any{"refresh_token": tokens["refresh_token"]})
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, map[string]string{"error": server.Lang.GetDefault().Get("auth.refresh-token-invalid")}, respBody)
+	})
+
+	t.Run("Refresh_user_not_found", func(t *testing.T) {
+		server, controller, _ := prepareRefreshTest(t)
+		tokens := login(t, server)
+		controller.UserService = &MockUserService[TestUser]{err: gorm.ErrRecordNotFound}
+
+		resp, respBody := post(t, server, "/refresh", map[string]any{"refresh_token": tokens["refresh_token"]})
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, map[string]string{"error": server.Lang.GetDefault().Get("auth.invalid-credentials")}, respBody)
+	})
+
+	t.Run("Refresh_validation", func(t *testing.T) {
+		server, _, _ := prepareRefreshTest(t)
+		body, err := json.Marshal(map[string]any{})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		resp := server.TestRequest(request)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		respBody, err := testutil.ReadJSONBody[map[string]*validation.ErrorResponse](resp.Body)
+		assert.NoError(t, resp.Body.Close())
+		require.NoError(t, err)
+		if assert.Contains(t, respBody, "error") && assert.NotNil(t, respBody["error"]) {
+			assert.Contains(t, respBody["error"].Body.Fields, "refresh_token")
+		}
+	})
+
+	t.Run("Logout", func(t *testing.T) {
+		server, _, denylist := prepareRefreshTest(t)
+		tokens := login(t, server)
+
+		resp, _ := post(t, server, "/logout", map[string]any{"refresh_token": tokens["refresh_token"]})
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Contains(t, denylist.denied, jti(t, tokens["token"]))
+
+		resp, _ = post(t, server, "/refresh", map[string]any{"refresh_token": tokens["refresh_token"]})
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		// Unknown refresh token
+		resp, _ = post(t, server, "/logout", map[string]any{"refresh_token": tokens["refresh_token"]})
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("RevokeSessions", func(t *testing.T) {
+		server, controller, denylist := prepareRefreshTest(t)
+		tokens1 := login(t, server)
+		tokens2 := login(t, server)
+
+		require.NoError(t, controller.RevokeSessions(context.Background(), "johndoe@example.org"))
+		assert.Contains(t, denylist.denied, jti(t, tokens1["token"]))
+		assert.Contains(t, denylist.denied, jti(t, tokens2["token"]))
+
+		resp, _ := post(t, server, "/refresh", map[string]any{"refresh_token": tokens1["refresh_token"]})
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		resp, _ = post(t, server, "/refresh", map[string]any{"refresh_token": tokens2["refresh_token"]})
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		denylist.err = fmt.Errorf("test error")
+		login(t, server)
+		require.Error(t, controller.RevokeSessions(context.Background(), "johndoe@example.org"))
+
+		require.Error(t, (&JWTController[TestUser]{}).RevokeSessions(context.Background(), "johndoe@example.org"))
+	})
+
+	t.Run("RevokeSessions_after_refresh", func(t *testing.T) {
+		server, controller, denylist := prepareRefreshTest(t)
+		tokens := login(t, server)
+
+		resp, refreshed := post(t, server, "/refresh", map[string]any{"refresh_token": tokens["refresh_token"]})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, denylist.denied, jti(t, tokens["token"]))
+
+		require.NoError(t, controller.RevokeSessions(context.Background(), "johndoe@example.org"))
+		assert.Contains(t, denylist.denied, jti(t, refreshed["token"]))
+
+		jwtAuthenticator := NewJWTAuthenticator(controller.UserService)
+		jwtAuthenticator.Denylist = denylist
+		authenticator := Middleware(jwtAuthenticator)
+		for _, token := range []string{tokens["token"], refreshed["token"]} {
+			request := server.NewTestRequest(http.MethodGet, "/protected", nil)
+			request.Request().Header.Set("Authorization", "Bearer "+token)
+			request.Route = &goyave.Route{Meta: map[string]any{MetaAuth: true}}
+			resp := server.TestMiddleware(authenticator, request, func(response *goyave.Response, _ *goyave.Request) {
+				assert.Fail(t, "middleware passed despite revoked token")
+				response.Status(http.StatusOK)
+			})
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+			assert.NoError(t, resp.Body.Close())
+		}
+	})
+
+	t.Run("no_store", func(t *testing.T) {
+		server, user := prepareAuthenticatorTest(t)
+		server.Config().Set("auth.jwt.secret", "secret")
+		controller := NewJWTController(&MockUserService[TestUser]{user: user}, "Password")
+		server.RegisterRoutes(func(_ *goyave.Server, router *goyave.Router) {
+			router.Controller(controller)
+		})
+
+		resp, respBody := post(t, server, "/login", map[string]any{"username": user.Email, "password": "secret"})
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NotContains(t, respBody, "refresh_token")
+
+		request := httptest.NewRequest(http.MethodPost, "/refresh", nil)
+		resp = server.TestRequest(request)
+		assert.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
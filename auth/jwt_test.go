@@ -2,10 +2,12 @@ package auth
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/http"
 	"path"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/golang-jwt/jwt"
@@ -27,6 +29,33 @@ func prepareJWTServiceTest(t *testing.T) (*testutil.TestServer, *JWTService) {
 }
 
 func TestJWTService(t *testing.T) {
+	t.Run("NewJWTServiceFromServer", func(t *testing.T) {
+		filesystem := fstest.MapFS{
+			"config.json": {Data: []byte(`{"auth": {"jwt": {"secret": "secret", "expiry": 20}}}`), ModTime: time.Now()},
+		}
+		reloadable, err := config.NewReloadable(&config.File{FS: filesystem, Path: "config.json"})
+		require.NoError(t, err)
+		server := testutil.NewTestServerWithOptions(t, goyave.Options{Config: reloadable.Config(), ReloadableConfig: reloadable})
+		service := NewJWTServiceFromServer(server.Server, &osfs.FS{})
+
+		expiry := func() time.Duration {
+			tokenString, err := service.GenerateToken("johndoe")
+			require.NoError(t, err)
+			token, err := jwt.Parse(tokenString, func(_ *jwt.Token) (any, error) {
+				return []byte(server.Config().GetString("auth.jwt.secret")), nil
+			})
+			require.NoError(t, err)
+			claims := token.Claims.(jwt.MapClaims)
+			return time.Duration(int64(claims["exp"].(float64))-int64(claims["nbf"].(float64))) * time.Second
+		}
+		assert.Equal(t, 20*time.Second, expiry())
+
+		filesystem["config.json"] = &fstest.MapFile{Data: []byte(`{"auth": {"jwt": {"secret": "new secret", "expiry": 60}}}`), ModTime: time.Now()}
+		_, err = reloadable.Reload()
+		require.NoError(t, err)
+		assert.Equal(t, 60*time.Second, expiry())
+	})
+
 	t.Run("GenerateToken", func(t *testing.T) {
 		server, service := prepareJWTServiceTest(t)
 		server.Config().Set("auth.jwt.secret", "secret")
@@ -49,6 +78,7 @@ func TestJWTService(t *testing.T) {
 		claims, ok := parsedToken.Claims.(jwt.MapClaims)
 		if assert.True(t, ok) {
 			assert.Equal(t, "johndoe", claims["sub"])
+			assert.NotEmpty(t, claims["jti"])
 			assert.GreaterOrEqual(t, float64(now.Unix()), claims["nbf"])
 			assert.True(t, time.Unix(int64(claims["exp"].(float64)), 0).After(now))
 			assert.Equal(t, int64(expiry.Seconds()), int64(claims["exp"].(float64)-claims["nbf"].(float64)))
@@ -388,6 +418,69 @@ func TestJWTAuthenticator(t *testing.T) {
 		assert.NoError(t, resp.Body.Close())
 	})
 
+	t.Run("revoked", func(t *testing.T) {
+		server, user := prepareAuthenticatorTest(t)
+		server.Config().Set("auth.jwt.secret", "secret")
+		mockUserService := &MockUserService[TestUser]{user: user}
+		denylist := &MockTokenDenylist{denied: map[string]time.Time{}}
+		jwtAuthenticator := NewJWTAuthenticator(mockUserService)
+		jwtAuthenticator.Denylist = denylist
+		authenticator := Middleware(jwtAuthenticator)
+
+		service := NewJWTService(server.Config(), &osfs.FS{})
+		token, err := service.GenerateTokenWithClaims(jwt.MapClaims{"sub": user.Email, "jti": "revoked-id"}, jwt.SigningMethodHS256)
+		require.NoError(t, err)
+
+		request := server.NewTestRequest(http.MethodGet, "/protected", nil)
+		request.Request().Header.Set("Authorization", "Bearer "+token)
+		request.Route = &goyave.Route{Meta: map[string]any{MetaAuth: true}}
+		resp := server.TestMiddleware(authenticator, request, func(response *goyave.Response, _ *goyave.Request) {
+			response.Status(http.StatusOK)
+		})
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NoError(t, resp.Body.Close())
+
+		require.NoError(t, denylist.Deny(context.Background(), "revoked-id", time.Now().Add(time.Hour)))
+		request = server.NewTestRequest(http.MethodGet, "/protected", nil)
+		request.Request().Header.Set("Authorization", "Bearer "+token)
+		request.Route = &goyave.Route{Meta: map[string]any{MetaAuth: true}}
+		resp = server.TestMiddleware(authenticator, request, func(response *goyave.Response, _ *goyave.Request) {
+			assert.Fail(t, "middleware passed despite failed authentication")
+			response.Status(http.StatusOK)
+		})
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		body, err := testutil.ReadJSONBody[map[string]string](resp.Body)
+		assert.NoError(t, resp.Body.Close())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"error": server.Lang.GetDefault().Get("auth.jwt-revoked")}, body)
+	})
+
+	t.Run("denylist_error", func(t *testing.T) {
+		server, user := prepareAuthenticatorTest(t)
+		buf := &bytes.Buffer{}
+		server.Logger = slog.New(slog.NewHandler(false, buf))
+		server.Config().Set("auth.jwt.secret", "secret")
+		mockUserService := &MockUserService[TestUser]{user: user}
+		jwtAuthenticator := NewJWTAuthenticator(mockUserService)
+		jwtAuthenticator.Denylist = &MockTokenDenylist{err: fmt.Errorf("denylist error")}
+		authenticator := Middleware(jwtAuthenticator)
+
+		service := NewJWTService(server.Config(), &osfs.FS{})
+		token, err := service.GenerateToken(user.Email)
+		require.NoError(t, err)
+
+		request := server.NewTestRequest(http.MethodGet, "/protected", nil)
+		request.Request().Header.Set("Authorization", "Bearer "+token)
+		request.Route = &goyave.Route{Meta: map[string]any{MetaAuth: true}}
+		resp := server.TestMiddleware(authenticator, request, func(response *goyave.Response, _ *goyave.Request) {
+			assert.Fail(t, "middleware passed despite failed authentication")
+			response.Status(http.StatusOK)
+		})
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.NoError(t, resp.Body.Close())
+		assert.Contains(t, buf.String(), "denylist error")
+	})
+
 	t.Run("unexpected_method_hmac", func(t *testing.T) {
 		rootDir := testutil.FindRootDirectory()
 		server, _ := prepareAuthenticatorTest(t)
@@ -0,0 +1,328 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"gorm.io/gorm"
+	"goyave.dev/goyave/v5"
+	errorutil "goyave.dev/goyave/v5/util/errors"
+)
+
+// OIDCDiscovery the relevant fields of an OpenID Connect discovery document
+// ("/.well-known/openid-configuration").
+type OIDCDiscovery struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported,omitempty"`
+}
+
+// OIDCAuthenticator implementation of Authenticator validating access tokens (JWT) issued by an
+// external OpenID Connect / OAuth2 identity provider. The application acts as a resource server.
+//
+// The issuer's discovery document ("<Issuer>/.well-known/openid-configuration") is fetched on the first
+// authentication to find the location of the issuer's JSON Web Key Set, which is then cached
+// (see `RemoteJWKS`). Only tokens signed with RSA or ECDSA are accepted.
+//
+// The token must have an "exp" claim, the "iss" claim must match the `Issuer`, the "aud" claim must
+// contain the `Audience` and the token must grant all the `Scopes`. The claims are added to `request.Extra`
+// with the key `ExtraJWTClaims{}`.
+//
+// If the discovery document or the key set cannot be fetched, the error is logged and the
+// token is rejected. A failed discovery is not attempted again for one minute.
+//
+// Implements `Unauthorizer`: tokens that don't grant all the `Scopes` are answered with
+// "403 Forbidden" and a "WWW-Authenticate" header with the "insufficient_scope" error (RFC 6750).
+// Other authentication failures are answered with "401 Unauthorized".
+//
+// The T parameter represents the user DTO and should not be a pointer.
+type OIDCAuthenticator[T any] struct {
+	goyave.Component
+
+	UserService UserService[T]
+
+	// Client the HTTP client used to fetch the discovery document and the key set.
+	// Defaults to `http.DefaultClient`.
+	Client *http.Client
+
+	// KeySet optionally overrides the key set used to verify the tokens. If set,
+	// the discovery document is not fetched.
+	KeySet KeySet
+
+	keySet KeySet
+
+	discoveryErr         error
+	discoveryAttemptedAt time.Time
+	discovering          *jwksFetch
+
+	// Issuer the URL of the identity provider, for example "https://accounts.example.org".
+	// Must be identical to the "iss" claim of the tokens.
+	Issuer string
+
+	// Audience the identifier of this application (resource server) in the identity
+	// provider. The "aud" claim of the tokens must contain this value. The audience is
+	// not checked if empty, which is discouraged.
+	Audience string
+
+	// ClaimName the name of the claim used to retrieve the user.
+	// Defaults to "sub".
+	ClaimName string
+
+	// Scopes the scopes the tokens must grant. The scopes are read from the "scope"
+	// claim (space-separated string) or the "scp" claim (string or array).
+	Scopes []string
+
+	// Algorithms optionally restricts the accepted signing algorithms (e.g. "RS256").
+	// Defaults to all RSA and ECDSA algorithms.
+	Algorithms []string
+
+	mu sync.Mutex
+
+	// Optional defines if the authenticator allows requests that
+	// don't provide credentials. Handlers should therefore check
+	// if `request.User` is not `nil` before accessing it.
+	Optional bool
+}
+
+// NewOIDCAuthenticator create a new authenticator validating the access tokens issued by
+// the given OpenID Connect issuer for the given audience.
+//
+// The T parameter represents the user DTO and should not be a pointer.
+func NewOIDCAuthenticator[T any](userService UserService[T], issuer, audience string) *OIDCAuthenticator[T] {
+	return &OIDCAuthenticator[T]{
+		UserService: userService,
+		Issuer:      issuer,
+		Audience:    audience,
+	}
+}
+
+// Authenticate fetch the user corresponding to the access token
+// found in the given request and returns it.
+// If no user can be authenticated, returns an error.
+func (a *OIDCAuthenticator[T]) Authenticate(request *goyave.Request) (*T, error) {
+	tokenString, ok := request.BearerToken()
+	if tokenString == "" || !ok {
+		if a.Optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf(request.Lang.Get("auth.no-credentials-provided"))
+	}
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		return a.keyFunc(request.Context(), token)
+	})
+	if err != nil {
+		return nil, makeJWTError(request.Lang, err.(*jwt.ValidationError).Errors)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["exp"] == nil {
+		return nil, fmt.Errorf(request.Lang.Get("auth.jwt-invalid"))
+	}
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, fmt.Errorf(request.Lang.Get("auth.jwt-expired"))
+	}
+	if !claims.VerifyIssuer(a.Issuer, true) {
+		return nil, fmt.Errorf(request.Lang.Get("auth.jwt-invalid-issuer"))
+	}
+	if a.Audience != "" && !claims.VerifyAudience(a.Audience, true) {
+		return nil, fmt.Errorf(request.Lang.Get("auth.jwt-invalid-audience"))
+	}
+	if !hasScopes(claims, a.Scopes) {
+		return nil, &insufficientScopeError{message: request.Lang.Get("auth.jwt-insufficient-scope")}
+	}
+	request.Extra[ExtraJWTClaims{}] = claims
+
+	claimName := a.ClaimName
+	if claimName == "" {
+		claimName = "sub"
+	}
+	user, err := a.UserService.FindByUsername(request.Context(), claims[claimName])
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf(request.Lang.Get("auth.invalid-credentials"))
+		}
+		panic(errorutil.New(err))
+	}
+	return user, nil
+}
+
+// OnUnauthorized responds with "403 Forbidden" if the token doesn't grant all the required
+// `Scopes`, "401 Unauthorized" otherwise.
+func (a *OIDCAuthenticator[T]) OnUnauthorized(response *goyave.Response, _ *goyave.Request, err error) {
+	status := http.StatusUnauthorized
+	var scopeErr *insufficientScopeError
+	if errors.As(err, &scopeErr) {
+		response.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope=%q`, strings.Join(a.Scopes, " ")))
+		status = http.StatusForbidden
+	}
+	response.JSON(status, map[string]string{"error": err.Error()})
+}
+
+func (a *OIDCAuthenticator[T]) keyFunc(ctx context.Context, token *jwt.Token) (any, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+	default:
+		return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+	}
+	if len(a.Algorithms) > 0 && !slices.Contains(a.Algorithms, token.Method.Alg()) {
+		return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+	}
+
+	keySet, err := a.getKeySet(ctx)
+	if err != nil {
+		a.Logger().Error(err)
+		return nil, err
+	}
+	kid, _ := token.Header["kid"].(string)
+	key, err := lookupPublicKey(ctx, keySet, kid, token.Method)
+	if err != nil {
+		a.Logger().Error(err)
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("Unknown key: %q", kid)
+	}
+	return key, nil
+}
+
+// getKeySet returns the `KeySet` if defined. Otherwise, fetches the discovery document
+// of the issuer on the first call and returns a `RemoteJWKS` using the "jwks_uri" it contains.
+// The discovery document is fetched outside the lock, with a context detached from the request
+// so a client cancelling its request doesn't fail the discovery. Concurrent calls share the
+// same discovery. If the discovery failed less than a minute ago, the same error is returned
+// without fetching the document again.
+func (a *OIDCAuthenticator[T]) getKeySet(ctx context.Context) (KeySet, error) {
+	if a.KeySet != nil {
+		return a.KeySet, nil
+	}
+
+	a.mu.Lock()
+	if a.keySet != nil {
+		a.mu.Unlock()
+		return a.keySet, nil
+	}
+	if a.discoveryErr != nil && time.Since(a.discoveryAttemptedAt) < jwksMinRefreshInterval {
+		err := a.discoveryErr
+		a.mu.Unlock()
+		return nil, err
+	}
+	discovering := a.discovering
+	if discovering == nil {
+		discovering = &jwksFetch{done: make(chan struct{})}
+		a.discovering = discovering
+		go func() {
+			discoveryCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jwksFetchTimeout)
+			defer cancel()
+			discovery, err := a.Discover(discoveryCtx)
+
+			a.mu.Lock()
+			a.discoveryAttemptedAt = time.Now()
+			a.discoveryErr = err
+			if err == nil {
+				a.keySet = &RemoteJWKS{URL: discovery.JWKSURI, Client: a.Client}
+			}
+			a.discovering = nil
+			a.mu.Unlock()
+			close(discovering.done)
+		}()
+	}
+	a.mu.Unlock()
+
+	select {
+	case <-discovering.done:
+	case <-ctx.Done():
+		return nil, errorutil.New(ctx.Err())
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.keySet != nil {
+		return a.keySet, nil
+	}
+	return nil, a.discoveryErr
+}
+
+// Discover fetch the OpenID Connect discovery document of the issuer.
+// Returns an error if the "issuer" of the document doesn't match the `Issuer`
+// of the authenticator or if it doesn't contain a "jwks_uri".
+func (a *OIDCAuthenticator[T]) Discover(ctx context.Context) (*OIDCDiscovery, error) {
+	url := strings.TrimSuffix(a.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errorutil.New(err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	client := a.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errorutil.New(err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorutil.Errorf("could not fetch OpenID configuration from %q: unexpected status %d", url, resp.StatusCode)
+	}
+
+	discovery := &OIDCDiscovery{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, jwksMaxSize)).Decode(discovery); err != nil {
+		return nil, errorutil.Errorf("invalid OpenID configuration: %w", err)
+	}
+	if discovery.Issuer != a.Issuer {
+		return nil, errorutil.Errorf("invalid OpenID configuration: issuer %q doesn't match %q", discovery.Issuer, a.Issuer)
+	}
+	if discovery.JWKSURI == "" {
+		return nil, errorutil.New("invalid OpenID configuration: missing jwks_uri")
+	}
+	return discovery, nil
+}
+
+// insufficientScopeError returned by `OIDCAuthenticator` when the token doesn't
+// grant all the required scopes.
+type insufficientScopeError struct {
+	message string
+}
+
+func (e *insufficientScopeError) Error() string {
+	return e.message
+}
+
+// hasScopes returns true if the given claims grant all the required scopes.
+func hasScopes(claims jwt.MapClaims, required []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+	granted := []string{}
+	for _, name := range []string{"scope", "scp"} {
+		switch s := claims[name].(type) {
+		case string:
+			granted = append(granted, strings.Fields(s)...)
+		case []any:
+			for _, scope := range s {
+				if str, ok := scope.(string); ok {
+					granted = append(granted, str)
+				}
+			}
+		}
+	}
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			return false
+		}
+	}
+	return true
+}
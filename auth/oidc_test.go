@@ -0,0 +1,416 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/config"
+	"goyave.dev/goyave/v5/util/fsutil/osfs"
+	"goyave.dev/goyave/v5/util/testutil"
+)
+
+type testIssuer struct {
+	*httptest.Server
+	service   *JWTService
+	requests  atomic.Int32
+	discovery func(issuer string) map[string]any
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	privateKey, publicKey := writeTestKeyPair(t, true)
+	cfg := config.LoadDefault()
+	cfg.Set("auth.jwt.ecdsa.private", privateKey)
+	cfg.Set("auth.jwt.ecdsa.public", publicKey)
+	cfg.Set("auth.jwt.secret", "secret")
+
+	issuer := &testIssuer{
+		service: NewJWTService(cfg, &osfs.FS{}),
+		discovery: func(issuer string) map[string]any {
+			return map[string]any{"issuer": issuer, "jwks_uri": issuer + "/jwks.json"}
+		},
+	}
+	issuer.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issuer.requests.Add(1)
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(issuer.discovery(issuer.URL))
+		case "/jwks.json":
+			keySet, err := issuer.service.PublicKeySet()
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(keySet)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(issuer.Close)
+	return issuer
+}
+
+func (i *testIssuer) token(t *testing.T, claims jwt.MapClaims) string {
+	base := jwt.MapClaims{"iss": i.URL, "aud": "api", "scope": "openid profile"}
+	for k, v := range claims {
+		base[k] = v
+	}
+	token, err := i.service.GenerateTokenWithClaims(base, jwt.SigningMethodES256)
+	require.NoError(t, err)
+	return token
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	authenticate := func(t *testing.T, authenticator *OIDCAuthenticator[TestUser], token string) (*goyave.Request, *TestUser, error) {
+		server, _ := prepareAuthenticatorTest(t)
+		authenticator.Init(server.Server)
+		request := server.NewTestRequest(http.MethodGet, "/protected", nil)
+		if token != "" {
+			request.Request().Header.Set("Authorization", "Bearer "+token)
+		}
+		user, err := authenticator.Authenticate(request)
+		return request, user, err
+	}
+
+	t.Run("success", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		_, user := prepareAuthenticatorTest(t)
+		a := NewOIDCAuthenticator(&MockUserService[TestUser]{user: user}, issuer.URL, "api")
+		a.Scopes = []string{"profile"}
+
+		request, u, err := authenticate(t, a, issuer.token(t, jwt.MapClaims{"sub": user.Email}))
+		require.NoError(t, err)
+		assert.Equal(t, user, u)
+		if assert.Contains(t, request.Extra, ExtraJWTClaims{}) {
+			assert.Equal(t, user.Email, request.Extra[ExtraJWTClaims{}].(jwt.MapClaims)["sub"])
+		}
+		assert.Equal(t, int32(2), issuer.requests.Load()) // Discovery + JWKS
+
+		// Discovery document and key set are cached
+		_, _, err = authenticate(t, a, issuer.token(t, jwt.MapClaims{"sub": user.Email, "aud": []string{"other", "api"}}))
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), issuer.requests.Load())
+	})
+
+	t.Run("middleware", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		server, user := prepareAuthenticatorTest(t)
+		authenticator := Middleware[TestUser](NewOIDCAuthenticator(&MockUserService[TestUser]{user: user}, issuer.URL, "api"))
+
+		request := server.NewTestRequest(http.MethodGet, "/protected", nil)
+		request.Request().Header.Set("Authorization", "Bearer "+issuer.token(t, jwt.MapClaims{"sub": user.Email, "aud": "other"}))
+		request.Route = &goyave.Route{Meta: map[string]any{MetaAuth: true}}
+		resp := server.TestMiddleware(authenticator, request, func(response *goyave.Response, _ *goyave.Request) {
+			assert.Fail(t, "middleware passed despite failed authentication")
+			response.Status(http.StatusOK)
+		})
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		body, err := testutil.ReadJSONBody[map[string]string](resp.Body)
+		assert.NoError(t, resp.Body.Close())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"error": server.Lang.GetDefault().Get("auth.jwt-invalid-audience")}, body)
+	})
+
+	t.Run("middleware_insufficient_scope", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		server, user := prepareAuthenticatorTest(t)
+		a := NewOIDCAuthenticator(&MockUserService[TestUser]{user: user}, issuer.URL, "api")
+		a.Scopes = []string{"profile", "email"}
+		authenticator := Middleware[TestUser](a)
+
+		request := server.NewTestRequest(http.MethodGet, "/protected", nil)
+		request.Request().Header.Set("Authorization", "Bearer "+issuer.token(t, jwt.MapClaims{"sub": user.Email}))
+		request.Route = &goyave.Route{Meta: map[string]any{MetaAuth: true}}
+		resp := server.TestMiddleware(authenticator, request, func(response *goyave.Response, _ *goyave.Request) {
+			assert.Fail(t, "middleware passed despite insufficient scope")
+			response.Status(http.StatusOK)
+		})
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Equal(t, `Bearer error="insufficient_scope", scope="profile email"`, resp.Header.Get("WWW-Authenticate"))
+		body, err := testutil.ReadJSONBody[map[string]string](resp.Body)
+		assert.NoError(t, resp.Body.Close())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"error": server.Lang.GetDefault().Get("auth.jwt-insufficient-scope")}, body)
+	})
+
+	t.Run("claims", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		_, user := prepareAuthenticatorTest(t)
+
+		cases := []struct {
+			claims jwt.MapClaims
+			line   string
+			scopes []string
+		}{
+			{claims: jwt.MapClaims{"iss": "https://other.example.org"}, line: "auth.jwt-invalid-issuer"},
+			{claims: jwt.MapClaims{"iss": nil}, line: "auth.jwt-invalid-issuer"},
+			{claims: jwt.MapClaims{"aud": "other"}, line: "auth.jwt-invalid-audience"},
+			{claims: jwt.MapClaims{"aud": nil}, line: "auth.jwt-invalid-audience"},
+			{claims: jwt.MapClaims{"aud": []string{"other"}}, line: "auth.jwt-invalid-audience"},
+			{claims: jwt.MapClaims{"scope": "openid"}, scopes: []string{"profile"}, line: "auth.jwt-insufficient-scope"},
+			{claims: jwt.MapClaims{"scope": nil}, scopes: []string{"profile"}, line: "auth.jwt-insufficient-scope"},
+			{claims: jwt.MapClaims{"scope": nil, "scp": []string{"openid", "profile"}}, scopes: []string{"profile", "email"}, line: "auth.jwt-insufficient-scope"},
+			{claims: jwt.MapClaims{"scope": nil, "scp": []string{"profile", "email"}}, scopes: []string{"profile", "email"}},
+			{claims: jwt.MapClaims{"scope": nil, "scp": "email profile"}, scopes: []string{"profile", "email"}},
+			{claims: jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()}, line: "auth.jwt-expired"},
+			{claims: jwt.MapClaims{"exp": nil}, line: "auth.jwt-expired"},
+			{claims: jwt.MapClaims{"nbf": time.Now().Add(time.Hour).Unix()}, line: "auth.jwt-not-valid-yet"},
+		}
+
+		for i, c := range cases {
+			t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+				a := NewOIDCAuthenticator(&MockUserService[TestUser]{user: user}, issuer.URL, "api")
+				a.Scopes = c.scopes
+				c.claims["sub"] = user.Email
+				request, u, err := authenticate(t, a, issuer.token(t, c.claims))
+				if c.line == "" {
+					require.NoError(t, err)
+					assert.Equal(t, user, u)
+					return
+				}
+				require.EqualError(t, err, request.Lang.Get(c.line))
+				assert.Nil(t, u)
+			})
+		}
+	})
+
+	t.Run("no_expiry", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		_, user := prepareAuthenticatorTest(t)
+		key, err := issuer.service.GetPrivateKey(jwt.SigningMethodES256)
+		require.NoError(t, err)
+		kid, err := KeyID(key)
+		require.NoError(t, err)
+		token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{"iss": issuer.URL, "aud": "api", "sub": user.Email})
+		token.Header["kid"] = kid
+		tokenString, err := token.SignedString(key)
+		require.NoError(t, err)
+
+		a := NewOIDCAuthenticator(&MockUserService[TestUser]{user: user}, issuer.URL, "api")
+		request, u, err := authenticate(t, a, tokenString)
+		require.EqualError(t, err, request.Lang.Get("auth.jwt-invalid"))
+		assert.Nil(t, u)
+	})
+
+	t.Run("no_audience_check", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		_, user := prepareAuthenticatorTest(t)
+		a := NewOIDCAuthenticator(&MockUserService[TestUser]{user: user}, issuer.URL, "")
+		_, u, err := authenticate(t, a, issuer.token(t, jwt.MapClaims{"sub": user.Email, "aud": nil}))
+		require.NoError(t, err)
+		assert.Equal(t, user, u)
+	})
+
+	t.Run("claim_name", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		_, user := prepareAuthenticatorTest(t)
+		a := NewOIDCAuthenticator(&MockUserService[TestUser]{user: user}, issuer.URL, "api")
+		a.ClaimName = "email"
+		request, u, err := authenticate(t, a, issuer.token(t, jwt.MapClaims{"sub": "123", "email": user.Email}))
+		require.NoError(t, err)
+		assert.Equal(t, user, u)
+		assert.Equal(t, user.Email, request.Extra[ExtraJWTClaims{}].(jwt.MapClaims)["email"])
+	})
+
+	t.Run("invalid_signature", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		other := newTestIssuer(t)
+		_, user := prepareAuthenticatorTest(t)
+
+		// Signed by another issuer's key
+		a := NewOIDCAuthenticator(&MockUserService[TestUser]{user: user}, issuer.URL, "api")
+		request, _, err := authenticate(t, a, other.token(t, jwt.MapClaims{"sub": user.Email, "iss": issuer.URL}))
+		require.EqualError(t, err, request.Lang.Get("auth.jwt-invalid"))
+
+		// HMAC
+		token, err := issuer.service.GenerateTokenWithClaims(jwt.MapClaims{"sub": user.Email, "iss": issuer.URL, "aud": "api"}, jwt.SigningMethodHS256)
+		require.NoError(t, err)
+		request, _, err = authenticate(t, a, token)
+		require.EqualError(t, err, request.Lang.Get("auth.jwt-invalid"))
+
+		// Algorithm not allowed
+		a.Algorithms = []string{"RS256"}
+		request, _, err = authenticate(t, a, issuer.token(t, jwt.MapClaims{"sub": user.Email}))
+		require.EqualError(t, err, request.Lang.Get("auth.jwt-invalid"))
+
+		request, _, err = authenticate(t, a, "not a token")
+		require.EqualError(t, err, request.Lang.Get("auth.jwt-invalid"))
+	})
+
+	t.Run("key_set", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		_, user := prepareAuthenticatorTest(t)
+		keySet, err := issuer.service.PublicKeySet()
+		require.NoError(t, err)
+
+		a := NewOIDCAuthenticator(&MockUserService[TestUser]{user: user}, "https://issuer.example.org", "api")
+		a.KeySet = keySet
+		_, u, err := authenticate(t, a, issuer.token(t, jwt.MapClaims{"sub": user.Email, "iss": "https://issuer.example.org"}))
+		require.NoError(t, err)
+		assert.Equal(t, user, u)
+		assert.Equal(t, int32(0), issuer.requests.Load())
+	})
+
+	t.Run("user_not_found", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		a := NewOIDCAuthenticator(&MockUserService[TestUser]{err: gorm.ErrRecordNotFound}, issuer.URL, "api")
+		request, u, err := authenticate(t, a, issuer.token(t, jwt.MapClaims{"sub": "johndoe"}))
+		require.EqualError(t, err, request.Lang.Get("auth.invalid-credentials"))
+		assert.Nil(t, u)
+	})
+
+	t.Run("service_error", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		a := NewOIDCAuthenticator(&MockUserService[TestUser]{err: fmt.Errorf("service error")}, issuer.URL, "api")
+		assert.Panics(t, func() {
+			_, _, _ = authenticate(t, a, issuer.token(t, jwt.MapClaims{"sub": "johndoe"}))
+		})
+	})
+
+	t.Run("no_credentials", func(t *testing.T) {
+		a := NewOIDCAuthenticator(&MockUserService[TestUser]{}, "https://issuer.example.org", "api")
+		request, u, err := authenticate(t, a, "")
+		require.EqualError(t, err, request.Lang.Get("auth.no-credentials-provided"))
+		assert.Nil(t, u)
+
+		a.Optional = true
+		_, u, err = authenticate(t, a, "")
+		require.NoError(t, err)
+		assert.Nil(t, u)
+	})
+
+	t.Run("discovery_error", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		token := issuer.token(t, jwt.MapClaims{"sub": "johndoe"})
+
+		issuer.discovery = func(_ string) map[string]any {
+			return map[string]any{"issuer": "https://other.example.org", "jwks_uri": issuer.URL + "/jwks.json"}
+		}
+		a := NewOIDCAuthenticator(&MockUserService[TestUser]{}, issuer.URL, "api")
+		request, u, err := authenticate(t, a, token)
+		require.EqualError(t, err, request.Lang.Get("auth.jwt-invalid"))
+		assert.Nil(t, u)
+		assert.Equal(t, int32(1), issuer.requests.Load())
+
+		// The failed discovery is not attempted again right away
+		issuer.discovery = func(issuer string) map[string]any {
+			return map[string]any{"issuer": issuer, "jwks_uri": issuer + "/jwks.json"}
+		}
+		request, _, err = authenticate(t, a, token)
+		require.EqualError(t, err, request.Lang.Get("auth.jwt-invalid"))
+		assert.Equal(t, int32(1), issuer.requests.Load())
+
+		a.discoveryAttemptedAt = time.Now().Add(-2 * time.Minute)
+		_, _, err = authenticate(t, a, token)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), issuer.requests.Load())
+	})
+
+	t.Run("key_set_error", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		_, user := prepareAuthenticatorTest(t)
+		a := NewOIDCAuthenticator(&MockUserService[TestUser]{user: user}, issuer.URL, "api")
+		a.KeySet = NewRemoteJWKS("http://invalid host")
+		request, u, err := authenticate(t, a, issuer.token(t, jwt.MapClaims{"sub": user.Email}))
+		require.EqualError(t, err, request.Lang.Get("auth.jwt-invalid"))
+		assert.Nil(t, u)
+	})
+
+	t.Run("cancelled_request", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		_, user := prepareAuthenticatorTest(t)
+		a := NewOIDCAuthenticator(&MockUserService[TestUser]{user: user}, issuer.URL, "api")
+
+		// The discovery isn't cancelled with the request
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, _ = a.getKeySet(ctx)
+		keySet, err := a.getKeySet(context.Background())
+		require.NoError(t, err)
+		assert.NotNil(t, keySet)
+		assert.Equal(t, int32(1), issuer.requests.Load())
+	})
+
+	t.Run("concurrent_discovery", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		release := make(chan struct{})
+		issuer.discovery = func(issuerURL string) map[string]any {
+			<-release
+			return map[string]any{"issuer": issuerURL, "jwks_uri": issuerURL + "/jwks.json"}
+		}
+		a := NewOIDCAuthenticator(&MockUserService[TestUser]{}, issuer.URL, "api")
+
+		wg := sync.WaitGroup{}
+		keySets := make([]KeySet, 10)
+		for i := range keySets {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				keySet, err := a.getKeySet(context.Background())
+				assert.NoError(t, err)
+				keySets[i] = keySet
+			}(i)
+		}
+
+		// The lock is not held during the discovery
+		assert.Eventually(t, func() bool { return issuer.requests.Load() == 1 }, time.Second, time.Millisecond)
+		require.True(t, a.mu.TryLock())
+		a.mu.Unlock()
+
+		close(release)
+		wg.Wait()
+		assert.Equal(t, int32(1), issuer.requests.Load())
+		for _, keySet := range keySets {
+			assert.Same(t, keySets[0], keySet)
+		}
+	})
+}
+
+func TestOIDCDiscover(t *testing.T) {
+	issuer := newTestIssuer(t)
+	ctx := context.Background()
+
+	a := NewOIDCAuthenticator(&MockUserService[TestUser]{}, issuer.URL, "api")
+	discovery, err := a.Discover(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &OIDCDiscovery{Issuer: issuer.URL, JWKSURI: issuer.URL + "/jwks.json"}, discovery)
+
+	a.Issuer = issuer.URL + "/"
+	_, err = a.Discover(ctx)
+	require.Error(t, err) // Issuer doesn't match
+
+	issuer.discovery = func(issuer string) map[string]any {
+		return map[string]any{"issuer": issuer}
+	}
+	a.Issuer = issuer.URL
+	_, err = a.Discover(ctx)
+	require.Error(t, err) // Missing jwks_uri
+
+	a.Issuer = issuer.URL + "/notfound"
+	_, err = a.Discover(ctx)
+	require.Error(t, err)
+
+	a.Issuer = "http://invalid host"
+	_, err = a.Discover(ctx)
+	require.Error(t, err)
+
+	invalid := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("{"))
+	}))
+	t.Cleanup(invalid.Close)
+	a.Issuer = invalid.URL
+	a.Client = invalid.Client()
+	_, err = a.Discover(ctx)
+	require.Error(t, err)
+}
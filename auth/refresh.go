@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"goyave.dev/goyave/v5/util/errors"
+	"goyave.dev/goyave/v5/util/session"
+)
+
+// ExtraRefreshToken when using `JWTController`, this key can be used to retrieve
+// the consumed `*RefreshToken` in the request's `Extra` during the refresh process.
+// This allows custom `TokenFunc` to identify the user without a request body
+// containing the username.
+type ExtraRefreshToken struct{}
+
+// RefreshToken a long-lived opaque token that can be exchanged for a new JWT. Only the hash
+// of the token is stored. Refresh tokens are rotated: they can only be used once and a new
+// refresh token is issued every time one is used.
+//
+// This structure is also the GORM model used by `GormRefreshTokenStore`.
+type RefreshToken struct {
+	// ID the SHA-256 hash of the token, hex-encoded
+	ID string `gorm:"primaryKey;size:64"`
+
+	// Subject the username of the user the token was issued to
+	Subject string `gorm:"index;size:255"`
+
+	// AccessTokenID the "jti" claim of the JWT issued along with this refresh token.
+	// This JWT is revoked when the refresh token is revoked.
+	AccessTokenID string `gorm:"size:255"`
+
+	// AccessTokenExpiresAt the expiry of the JWT issued along with this refresh token.
+	AccessTokenExpiresAt time.Time
+
+	ExpiresAt time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
+
+// NewRefreshToken generates a new random refresh token for the given subject. Returns the
+// plain token, which should be sent to the client, and the `*RefreshToken` containing its
+// hash, which should be saved in a `RefreshTokenStore`.
+func NewRefreshToken(subject string, expiry time.Duration) (string, *RefreshToken, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, errors.New(err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	now := time.Now()
+	return token, &RefreshToken{
+		ID:        HashRefreshToken(token),
+		Subject:   subject,
+		ExpiresAt: now.Add(expiry),
+		CreatedAt: now,
+	}, nil
+}
+
+// HashRefreshToken returns the hex-encoded SHA-256 hash of the given plain refresh token.
+func HashRefreshToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// RefreshTokenStore persists refresh tokens.
+//
+// If a token cannot be found, the error returned should be of type `gorm.ErrRecordNotFound`.
+type RefreshTokenStore interface {
+	// Save stores the given refresh token.
+	Save(ctx context.Context, token *RefreshToken) error
+
+	// Consume retrieves and deletes the refresh token identified by the given hash.
+	// This operation must be atomic so a refresh token cannot be used twice.
+	Consume(ctx context.Context, id string) (*RefreshToken, error)
+
+	// RevokeSubject deletes all the refresh tokens of the given subject and returns them.
+	RevokeSubject(ctx context.Context, subject string) ([]*RefreshToken, error)
+}
+
+// TokenDenylist stores the IDs ("jti" claim) of revoked JWTs. `JWTAuthenticator` rejects tokens
+// whose ID is in its denylist.
+type TokenDenylist interface {
+	// Deny adds the given token ID to the denylist. The entry is not needed anymore
+	// after the given expiry, at which point the token is rejected anyway.
+	Deny(ctx context.Context, id string, expiresAt time.Time) error
+
+	// IsDenied returns true if the given token ID is in the denylist.
+	IsDenied(ctx context.Context, id string) (bool, error)
+}
+
+// GormRefreshTokenStore implementation of `RefreshTokenStore` using GORM and the `RefreshToken` model.
+// The "refresh_tokens" table must be migrated beforehand.
+//
+// If a `session.Session` transaction is present in the context, it is used.
+type GormRefreshTokenStore struct {
+	DB *gorm.DB
+}
+
+// NewGormRefreshTokenStore create a new `RefreshTokenStore` using the given database.
+func NewGormRefreshTokenStore(db *gorm.DB) *GormRefreshTokenStore {
+	return &GormRefreshTokenStore{DB: db}
+}
+
+// Save stores the given refresh token.
+func (s *GormRefreshTokenStore) Save(ctx context.Context, token *RefreshToken) error {
+	return errors.New(session.DB(ctx, s.DB).WithContext(ctx).Create(token).Error)
+}
+
+// Consume retrieves and deletes the refresh token identified by the given hash.
+func (s *GormRefreshTokenStore) Consume(ctx context.Context, id string) (*RefreshToken, error) {
+	var token *RefreshToken
+	err := session.DB(ctx, s.DB).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		token = &RefreshToken{}
+		if err := tx.Where("id = ?", id).First(token).Error; err != nil {
+			return err
+		}
+		db := tx.Where("id = ?", id).Delete(&RefreshToken{})
+		if db.Error != nil {
+			return db.Error
+		}
+		if db.RowsAffected == 0 { // Consumed concurrently
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	return token, nil
+}
+
+// RevokeSubject deletes all the refresh tokens of the given subject and returns them.
+func (s *GormRefreshTokenStore) RevokeSubject(ctx context.Context, subject string) ([]*RefreshToken, error) {
+	tokens := []*RefreshToken{}
+	err := session.DB(ctx, s.DB).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subject = ?", subject).Find(&tokens).Error; err != nil {
+			return err
+		}
+		if len(tokens) == 0 {
+			return nil
+		}
+		ids := make([]string, 0, len(tokens))
+		for _, t := range tokens {
+			ids = append(ids, t.ID)
+		}
+		return tx.Where("id IN ?", ids).Delete(&RefreshToken{}).Error
+	})
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	return tokens, nil
+}
+
+// Prune deletes the expired refresh tokens.
+func (s *GormRefreshTokenStore) Prune(ctx context.Context) error {
+	return errors.New(session.DB(ctx, s.DB).WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&RefreshToken{}).Error)
+}
+
+// DeniedToken the GORM model used by `GormTokenDenylist`.
+type DeniedToken struct {
+	// ID the "jti" claim of the revoked JWT
+	ID        string    `gorm:"primaryKey;size:255"`
+	ExpiresAt time.Time `gorm:"index"`
+}
+
+// GormTokenDenylist implementation of `TokenDenylist` using GORM and the `DeniedToken` model.
+// The "denied_tokens" table must be migrated beforehand.
+//
+// If a `session.Session` transaction is present in the context, it is used.
+type GormTokenDenylist struct {
+	DB *gorm.DB
+}
+
+// NewGormTokenDenylist create a new `TokenDenylist` using the given database.
+func NewGormTokenDenylist(db *gorm.DB) *GormTokenDenylist {
+	return &GormTokenDenylist{DB: db}
+}
+
+// Deny adds the given token ID to the denylist. Denying a token that is already denied has no effect.
+func (d *GormTokenDenylist) Deny(ctx context.Context, id string, expiresAt time.Time) error {
+	return errors.New(session.DB(ctx, d.DB).WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&DeniedToken{ID: id, ExpiresAt: expiresAt}).Error)
+}
+
+// IsDenied returns true if the given token ID is in the denylist and its entry is not expired.
+func (d *GormTokenDenylist) IsDenied(ctx context.Context, id string) (bool, error) {
+	var count int64
+	err := session.DB(ctx, d.DB).WithContext(ctx).
+		Model(&DeniedToken{}).
+		Where("id = ? AND expires_at > ?", id, time.Now()).
+		Count(&count).Error
+	return count > 0, errors.New(err)
+}
+
+// Prune deletes the expired entries of the denylist.
+func (d *GormTokenDenylist) Prune(ctx context.Context) error {
+	return errors.New(session.DB(ctx, d.DB).WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&DeniedToken{}).Error)
+}
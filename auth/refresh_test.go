@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"goyave.dev/goyave/v5/util/session"
+)
+
+type MockTokenDenylist struct {
+	denied map[string]time.Time
+	err    error
+	mu     sync.Mutex
+}
+
+func (d *MockTokenDenylist) Deny(_ context.Context, id string, expiresAt time.Time) error {
+	if d.err != nil {
+		return d.err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.denied[id] = expiresAt
+	return nil
+}
+
+func (d *MockTokenDenylist) IsDenied(_ context.Context, id string) (bool, error) {
+	if d.err != nil {
+		return false, d.err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.denied[id]
+	return ok, nil
+}
+
+func TestRefreshToken(t *testing.T) {
+	token, refreshToken, err := NewRefreshToken("johndoe", time.Hour)
+	require.NoError(t, err)
+	assert.Len(t, token, 43)
+	assert.Equal(t, HashRefreshToken(token), refreshToken.ID)
+	assert.Len(t, refreshToken.ID, 64)
+	assert.Equal(t, "johndoe", refreshToken.Subject)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), refreshToken.ExpiresAt, time.Second)
+	assert.WithinDuration(t, time.Now(), refreshToken.CreatedAt, time.Second)
+
+	other, _, err := NewRefreshToken("johndoe", time.Hour)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestGormRefreshTokenStore(t *testing.T) {
+	server, _ := prepareAuthenticatorTest(t)
+	t.Cleanup(func() { server.CloseDB() })
+	require.NoError(t, server.DB().AutoMigrate(&RefreshToken{}))
+	store := NewGormRefreshTokenStore(server.DB())
+	ctx := context.Background()
+
+	newToken := func(subject string, expiry time.Duration) *RefreshToken {
+		_, token, err := NewRefreshToken(subject, expiry)
+		require.NoError(t, err)
+		token.AccessTokenID = "jti-" + token.ID[:8]
+		token.AccessTokenExpiresAt = time.Now().Add(time.Minute).Truncate(time.Second)
+		require.NoError(t, store.Save(ctx, token))
+		return token
+	}
+
+	t.Run("Consume", func(t *testing.T) {
+		token := newToken("johndoe", time.Hour)
+
+		consumed, err := store.Consume(ctx, token.ID)
+		require.NoError(t, err)
+		assert.Equal(t, token.ID, consumed.ID)
+		assert.Equal(t, "johndoe", consumed.Subject)
+		assert.Equal(t, token.AccessTokenID, consumed.AccessTokenID)
+		assert.True(t, token.AccessTokenExpiresAt.Equal(consumed.AccessTokenExpiresAt))
+
+		// Cannot be used twice
+		consumed, err = store.Consume(ctx, token.ID)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.Nil(t, consumed)
+	})
+
+	t.Run("Save_duplicate", func(t *testing.T) {
+		token := newToken("johndoe", time.Hour)
+		require.Error(t, store.Save(ctx, token))
+	})
+
+	t.Run("RevokeSubject", func(t *testing.T) {
+		token1 := newToken("revoked", time.Hour)
+		token2 := newToken("revoked", time.Hour)
+		other := newToken("other", time.Hour)
+
+		revoked, err := store.RevokeSubject(ctx, "revoked")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{token1.ID, token2.ID}, []string{revoked[0].ID, revoked[1].ID})
+
+		_, err = store.Consume(ctx, token1.ID)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		_, err = store.Consume(ctx, other.ID)
+		require.NoError(t, err)
+
+		revoked, err = store.RevokeSubject(ctx, "revoked")
+		require.NoError(t, err)
+		assert.Empty(t, revoked)
+	})
+
+	t.Run("Prune", func(t *testing.T) {
+		expired := newToken("johndoe", -time.Minute)
+		valid := newToken("johndoe", time.Hour)
+		require.NoError(t, store.Prune(ctx))
+
+		_, err := store.Consume(ctx, expired.ID)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		_, err = store.Consume(ctx, valid.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("session", func(t *testing.T) {
+		token := newToken("johndoe", time.Hour)
+		err := session.GORM(server.DB(), nil).Transaction(ctx, func(ctx context.Context) error {
+			_, err := store.Consume(ctx, token.ID)
+			require.NoError(t, err)
+			return assert.AnError
+		})
+		require.ErrorIs(t, err, assert.AnError)
+
+		// Rolled back
+		_, err = store.Consume(ctx, token.ID)
+		require.NoError(t, err)
+	})
+}
+
+func TestGormTokenDenylist(t *testing.T) {
+	server, _ := prepareAuthenticatorTest(t)
+	t.Cleanup(func() { server.CloseDB() })
+	require.NoError(t, server.DB().AutoMigrate(&DeniedToken{}))
+	denylist := NewGormTokenDenylist(server.DB())
+	ctx := context.Background()
+
+	denied, err := denylist.IsDenied(ctx, "jti")
+	require.NoError(t, err)
+	assert.False(t, denied)
+
+	require.NoError(t, denylist.Deny(ctx, "jti", time.Now().Add(time.Hour)))
+	require.NoError(t, denylist.Deny(ctx, "jti", time.Now().Add(time.Hour))) // Already denied
+	denied, err = denylist.IsDenied(ctx, "jti")
+	require.NoError(t, err)
+	assert.True(t, denied)
+
+	require.NoError(t, denylist.Deny(ctx, "expired", time.Now().Add(-time.Minute)))
+	denied, err = denylist.IsDenied(ctx, "expired")
+	require.NoError(t, err)
+	assert.False(t, denied)
+
+	require.NoError(t, denylist.Prune(ctx))
+	var count int64
+	require.NoError(t, server.DB().Model(&DeniedToken{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
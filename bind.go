@@ -0,0 +1,102 @@
+package goyave
+
+import (
+	"encoding/json"
+	"errors"
+
+	errorutil "goyave.dev/goyave/v5/util/errors"
+	"goyave.dev/goyave/v5/util/typeutil"
+	"goyave.dev/goyave/v5/util/walk"
+	"goyave.dev/goyave/v5/validation"
+)
+
+// binder function converting validated request data to a DTO.
+type binder func(data any) (any, error)
+
+func newBinder[T any]() binder {
+	return func(data any) (any, error) {
+		return typeutil.Convert[T](data)
+	}
+}
+
+// ValidateBodyInto adds (or replace) validation rules for the request body, just like
+// `Route.ValidateBody()`. If the validation passes, the validated body is then converted
+// to T and stored in the request's `Extra` so it can be retrieved with `Bind()` without
+// being converted again.
+//
+// If the body cannot be converted to T, the request is treated as a validation failure:
+// the response status is set to 422 Unprocessable Entity and the conversion error is added
+// to the validation errors.
+//
+// The T parameter should be a DTO and not be a pointer. Returns the given route.
+func ValidateBodyInto[T any](route *Route, validationRules RuleSetFunc) *Route {
+	route.ValidateBody(validationRules)
+	findMiddleware[*validateRequestMiddleware](route.middleware).BodyBinder = newBinder[T]()
+	return route
+}
+
+// ValidateQueryInto adds (or replace) validation rules for the request query, just like
+// `Route.ValidateQuery()`. If the validation passes, the validated query is then converted
+// to T and stored in the request's `Extra` so it can be retrieved with `BindQuery()` without
+// being converted again.
+//
+// If the query cannot be converted to T, the request is treated as a validation failure:
+// the response status is set to 422 Unprocessable Entity and the conversion error is added
+// to the query validation errors.
+//
+// The T parameter should be a DTO and not be a pointer. Returns the given route.
+func ValidateQueryInto[T any](route *Route, validationRules RuleSetFunc) *Route {
+	route.ValidateQuery(validationRules)
+	findMiddleware[*validateRequestMiddleware](route.middleware).QueryBinder = newBinder[T]()
+	return route
+}
+
+// Bind returns the request body (`request.Data`) converted to T.
+// If the body has already been converted to T (for example by a route using
+// `ValidateBodyInto`), the cached value is returned. Otherwise, the body is
+// converted and the result is cached in the request's `Extra`.
+func Bind[T any](request *Request) (T, error) {
+	return bind[T](request, ExtraBoundBody{}, request.Data)
+}
+
+// BindQuery returns the request query (`request.Query`) converted to T.
+// If the query has already been converted to T (for example by a route using
+// `ValidateQueryInto`), the cached value is returned. Otherwise, the query is
+// converted and the result is cached in the request's `Extra`.
+func BindQuery[T any](request *Request) (T, error) {
+	return bind[T](request, ExtraBoundQuery{}, request.Query)
+}
+
+func bind[T any](request *Request, key any, data any) (T, error) {
+	if cached, ok := request.Extra[key].(T); ok {
+		return cached, nil
+	}
+	result, err := typeutil.Convert[T](data)
+	if err != nil {
+		return result, errorutil.New(err)
+	}
+	request.Extra[key] = result
+	return result, nil
+}
+
+// bindingErrors converts the given binding error to validation errors. If the
+// error is caused by a type mismatch on a field, the error is associated with this field.
+// Otherwise, the error is associated with the root element.
+func bindingErrors(request *Request, err error) *validation.Errors {
+	path := &walk.Path{Type: walk.PathTypeElement}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		if p, err := walk.Parse(typeErr.Field); err == nil {
+			path = &walk.Path{Type: walk.PathTypeObject, Next: p}
+		}
+	}
+
+	fieldPath := path
+	if path.Next != nil {
+		fieldPath = path.Next
+	}
+	message := request.Lang.Get("validation.rules.bind", ":field", validation.GetFieldName(request.Lang, fieldPath))
+	errs := &validation.Errors{}
+	errs.Add(path, message)
+	return errs
+}
@@ -0,0 +1,198 @@
+package goyave
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goyave.dev/goyave/v5/config"
+	"goyave.dev/goyave/v5/slog"
+	"goyave.dev/goyave/v5/validation"
+)
+
+type testBindDTO struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+type testBindQueryDTO struct {
+	Page int `json:"page"`
+}
+
+func TestBind(t *testing.T) {
+	prepareServer := func(t *testing.T) *Server {
+		cfg := config.LoadDefault()
+		server, err := New(Options{Config: cfg, Logger: slog.New(slog.NewHandler(false, &bytes.Buffer{}))})
+		require.NoError(t, err)
+		return server
+	}
+
+	execute := func(server *Server, m *validateRequestMiddleware, query map[string]any, data any) (*Request, *Response, bool) {
+		m.Init(server)
+		request := NewRequest(httptest.NewRequest(http.MethodPost, "/test", nil))
+		request.Lang = server.Lang.GetDefault()
+		request.Query = query
+		request.Data = data
+		response := NewResponse(server, request, httptest.NewRecorder())
+
+		pass := false
+		m.Handle(func(_ *Response, _ *Request) {
+			pass = true
+		})(response, request)
+		return request, response, pass
+	}
+
+	bodyRules := func(_ *Request) validation.RuleSet {
+		return validation.RuleSet{
+			{Path: validation.CurrentElement, Rules: validation.List{validation.Object()}},
+			{Path: "name", Rules: validation.List{validation.Required(), validation.String()}},
+			{Path: "age", Rules: validation.List{validation.Required()}},
+		}
+	}
+	queryRules := func(_ *Request) validation.RuleSet {
+		return validation.RuleSet{
+			{Path: "page", Rules: validation.List{validation.Int()}},
+		}
+	}
+
+	t.Run("ValidateBodyInto", func(t *testing.T) {
+		server := prepareServer(t)
+		router := NewRouter(server)
+		route := ValidateBodyInto[testBindDTO](router.Post("/test", nil), bodyRules)
+		assert.NotNil(t, route.GetBodyValidationRules())
+
+		m := findMiddleware[*validateRequestMiddleware](route.middleware)
+		require.NotNil(t, m)
+		require.NotNil(t, m.BodyBinder)
+		assert.Nil(t, m.QueryBinder)
+
+		request, response, pass := execute(server, m, nil, map[string]any{"name": "John", "age": 42})
+		assert.True(t, pass)
+		assert.Equal(t, testBindDTO{Name: "John", Age: 42}, request.Extra[ExtraBoundBody{}])
+		assert.Equal(t, 0, response.GetStatus())
+
+		dto, err := Bind[testBindDTO](request)
+		require.NoError(t, err)
+		assert.Equal(t, testBindDTO{Name: "John", Age: 42}, dto)
+	})
+
+	t.Run("ValidateBodyInto_conversion_error", func(t *testing.T) {
+		server := prepareServer(t)
+		router := NewRouter(server)
+		route := ValidateBodyInto[testBindDTO](router.Post("/test", nil), bodyRules)
+		m := findMiddleware[*validateRequestMiddleware](route.middleware)
+
+		request, response, pass := execute(server, m, nil, map[string]any{"name": "John", "age": "not a number"})
+		assert.False(t, pass)
+		assert.Equal(t, http.StatusUnprocessableEntity, response.GetStatus())
+		assert.NotContains(t, request.Extra, ExtraBoundBody{})
+		expected := &validation.Errors{Fields: validation.FieldsErrors{
+			"age": &validation.Errors{Errors: []string{"The age has an invalid type."}},
+		}}
+		assert.Equal(t, expected, request.Extra[ExtraValidationError{}])
+	})
+
+	t.Run("ValidateBodyInto_validation_error", func(t *testing.T) {
+		server := prepareServer(t)
+		router := NewRouter(server)
+		route := ValidateBodyInto[testBindDTO](router.Post("/test", nil), bodyRules)
+		m := findMiddleware[*validateRequestMiddleware](route.middleware)
+
+		request, response, pass := execute(server, m, nil, map[string]any{"age": 1})
+		assert.False(t, pass)
+		assert.Equal(t, http.StatusUnprocessableEntity, response.GetStatus())
+		assert.NotContains(t, request.Extra, ExtraBoundBody{})
+		expected := &validation.Errors{Fields: validation.FieldsErrors{
+			"name": &validation.Errors{Errors: []string{"The name is required.", "The name must be a string."}},
+		}}
+		assert.Equal(t, expected, request.Extra[ExtraValidationError{}])
+	})
+
+	t.Run("ValidateBodyInto_root_conversion_error", func(t *testing.T) {
+		server := prepareServer(t)
+		router := NewRouter(server)
+		route := ValidateBodyInto[testBindDTO](router.Post("/test", nil), func(_ *Request) validation.RuleSet {
+			return validation.RuleSet{
+				{Path: validation.CurrentElement, Rules: validation.List{validation.Array()}},
+			}
+		})
+		m := findMiddleware[*validateRequestMiddleware](route.middleware)
+
+		request, response, pass := execute(server, m, nil, []any{"a"})
+		assert.False(t, pass)
+		assert.Equal(t, http.StatusUnprocessableEntity, response.GetStatus())
+		expected := &validation.Errors{Errors: []string{"The body has an invalid type."}}
+		assert.Equal(t, expected, request.Extra[ExtraValidationError{}])
+	})
+
+	t.Run("ValidateQueryInto", func(t *testing.T) {
+		server := prepareServer(t)
+		router := NewRouter(server)
+		route := ValidateQueryInto[testBindQueryDTO](router.Get("/test", nil), queryRules)
+		route = ValidateBodyInto[testBindDTO](route, bodyRules)
+		assert.NotNil(t, route.GetQueryValidationRules())
+
+		m := findMiddleware[*validateRequestMiddleware](route.middleware)
+		require.NotNil(t, m.QueryBinder)
+		require.NotNil(t, m.BodyBinder)
+
+		request, _, pass := execute(server, m, map[string]any{"page": "3"}, map[string]any{"name": "John", "age": 42})
+		assert.True(t, pass)
+		assert.Equal(t, testBindQueryDTO{Page: 3}, request.Extra[ExtraBoundQuery{}])
+
+		query, err := BindQuery[testBindQueryDTO](request)
+		require.NoError(t, err)
+		assert.Equal(t, testBindQueryDTO{Page: 3}, query)
+	})
+
+	t.Run("ValidateBody_resets_binder", func(t *testing.T) {
+		server := prepareServer(t)
+		router := NewRouter(server)
+		route := ValidateQueryInto[testBindQueryDTO](router.Post("/test", nil), queryRules)
+		route = ValidateBodyInto[testBindDTO](route, bodyRules)
+		route.ValidateBody(func(_ *Request) validation.RuleSet {
+			return validation.RuleSet{
+				{Path: validation.CurrentElement, Rules: validation.List{validation.Array()}},
+			}
+		})
+		route.ValidateQuery(queryRules)
+
+		m := findMiddleware[*validateRequestMiddleware](route.middleware)
+		assert.Nil(t, m.BodyBinder)
+		assert.Nil(t, m.QueryBinder)
+
+		request, response, pass := execute(server, m, map[string]any{"page": "3"}, []any{"a"})
+		assert.True(t, pass)
+		assert.Equal(t, 0, response.GetStatus())
+		assert.NotContains(t, request.Extra, ExtraBoundBody{})
+		assert.NotContains(t, request.Extra, ExtraBoundQuery{})
+	})
+
+	t.Run("Bind_without_middleware", func(t *testing.T) {
+		request := NewRequest(httptest.NewRequest(http.MethodPost, "/test", nil))
+		request.Data = map[string]any{"name": "John", "age": 42}
+		request.Query = map[string]any{"page": 2}
+
+		dto, err := Bind[testBindDTO](request)
+		require.NoError(t, err)
+		assert.Equal(t, testBindDTO{Name: "John", Age: 42}, dto)
+		assert.Equal(t, dto, request.Extra[ExtraBoundBody{}])
+
+		// Cached
+		request.Data = nil
+		dto, err = Bind[testBindDTO](request)
+		require.NoError(t, err)
+		assert.Equal(t, testBindDTO{Name: "John", Age: 42}, dto)
+
+		query, err := BindQuery[testBindQueryDTO](request)
+		require.NoError(t, err)
+		assert.Equal(t, testBindQueryDTO{Page: 2}, query)
+
+		request.Data = map[string]any{"age": "not a number"}
+		_, err = Bind[map[string]int](request)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,119 @@
+package goyave
+
+import (
+	"context"
+	"crypto/tls"
+	"io/fs"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"goyave.dev/goyave/v5/util/errors"
+	"goyave.dev/goyave/v5/util/fsutil"
+)
+
+// CertificateProvider loads a TLS certificate and its private key from a file system
+// and keeps them up to date. When watching, the files are periodically checked for
+// modification and the certificate is swapped without interrupting the server.
+//
+// Use `GetCertificate` as the `tls.Config.GetCertificate` function so new TLS handshakes
+// always use the latest valid certificate.
+//
+// A `CertificateProvider` is created automatically by the server if the "server.tls.cert"
+// and "server.tls.key" config entries are set.
+type CertificateProvider struct {
+	fs       fsutil.FS
+	certFile string
+	keyFile  string
+
+	certificate atomic.Pointer[tls.Certificate]
+
+	mu          sync.Mutex
+	certModTime time.Time
+	keyModTime  time.Time
+}
+
+// NewCertificateProvider creates a new `CertificateProvider` for the given certificate
+// and key files, read from the given file system. The certificate is loaded immediately.
+// Returns an error if the certificate or the key could not be loaded.
+func NewCertificateProvider(fs fsutil.FS, certFile, keyFile string) (*CertificateProvider, error) {
+	p := &CertificateProvider{
+		fs:       fs,
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+	if _, err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// GetCertificate returns the current certificate. This function is meant to be
+// used as `tls.Config.GetCertificate`.
+// This operation is concurrently safe.
+func (p *CertificateProvider) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return p.certificate.Load(), nil
+}
+
+// Reload the certificate and its key if at least one of the files
+// has been modified since the last successful load.
+// Returns `true` if the certificate has been swapped.
+//
+// If the new certificate is invalid, the previous one is kept and an error is returned.
+// This operation is concurrently safe.
+func (p *CertificateProvider) Reload() (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	certInfo, err := p.fs.Stat(p.certFile)
+	if err != nil {
+		return false, errors.New(err)
+	}
+	keyInfo, err := p.fs.Stat(p.keyFile)
+	if err != nil {
+		return false, errors.New(err)
+	}
+
+	if p.certificate.Load() != nil && certInfo.ModTime().Equal(p.certModTime) && keyInfo.ModTime().Equal(p.keyModTime) {
+		return false, nil
+	}
+
+	certPEM, err := fs.ReadFile(p.fs, p.certFile)
+	if err != nil {
+		return false, errors.New(err)
+	}
+	keyPEM, err := fs.ReadFile(p.fs, p.keyFile)
+	if err != nil {
+		return false, errors.New(err)
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return false, errors.New(err)
+	}
+
+	p.certificate.Store(&cert)
+	p.certModTime = certInfo.ModTime()
+	p.keyModTime = keyInfo.ModTime()
+	return true, nil
+}
+
+// Watch the certificate and key files for changes every `interval`, reloading
+// the certificate when needed. This operation is blocking and returns
+// when the given context is canceled.
+//
+// `onError` is called each time a reload fails. The previous certificate is
+// kept in use in this case.
+func (p *CertificateProvider) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := p.Reload(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package goyave
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goyave.dev/goyave/v5/util/fsutil/osfs"
+)
+
+func TestCertificateProvider(t *testing.T) {
+	t.Run("New", func(t *testing.T) {
+		certFile, keyFile := generateTestCertificate(t)
+		provider, err := NewCertificateProvider(&osfs.FS{}, certFile, keyFile)
+		require.NoError(t, err)
+
+		cert, err := provider.GetCertificate(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+		assert.NotNil(t, cert)
+	})
+
+	t.Run("New_error", func(t *testing.T) {
+		certFile, keyFile := generateTestCertificate(t)
+		provider, err := NewCertificateProvider(&osfs.FS{}, certFile, "notafile.key")
+		require.Error(t, err)
+		assert.Nil(t, provider)
+
+		provider, err = NewCertificateProvider(&osfs.FS{}, keyFile, keyFile)
+		require.Error(t, err)
+		assert.Nil(t, provider)
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		certFile, keyFile := generateTestCertificate(t)
+		provider, err := NewCertificateProvider(&osfs.FS{}, certFile, keyFile)
+		require.NoError(t, err)
+		original, _ := provider.GetCertificate(&tls.ClientHelloInfo{})
+
+		// Files not modified
+		reloaded, err := provider.Reload()
+		require.NoError(t, err)
+		assert.False(t, reloaded)
+		cert, _ := provider.GetCertificate(&tls.ClientHelloInfo{})
+		assert.Same(t, original, cert)
+
+		replaceTestCertificate(t, certFile, keyFile, time.Now().Add(time.Minute))
+
+		reloaded, err = provider.Reload()
+		require.NoError(t, err)
+		assert.True(t, reloaded)
+		cert, _ = provider.GetCertificate(&tls.ClientHelloInfo{})
+		assert.NotSame(t, original, cert)
+		assert.NotEqual(t, original.Certificate, cert.Certificate)
+	})
+
+	t.Run("Reload_invalid_keeps_previous", func(t *testing.T) {
+		certFile, keyFile := generateTestCertificate(t)
+		provider, err := NewCertificateProvider(&osfs.FS{}, certFile, keyFile)
+		require.NoError(t, err)
+		original, _ := provider.GetCertificate(&tls.ClientHelloInfo{})
+
+		require.NoError(t, os.WriteFile(certFile, []byte("invalid"), 0644))
+		future := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(certFile, future, future))
+
+		reloaded, err := provider.Reload()
+		require.Error(t, err)
+		assert.False(t, reloaded)
+		cert, _ := provider.GetCertificate(&tls.ClientHelloInfo{})
+		assert.Same(t, original, cert)
+
+		require.NoError(t, os.Remove(keyFile))
+		reloaded, err = provider.Reload()
+		require.Error(t, err)
+		assert.False(t, reloaded)
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		certFile, keyFile := generateTestCertificate(t)
+		provider, err := NewCertificateProvider(&osfs.FS{}, certFile, keyFile)
+		require.NoError(t, err)
+		original, _ := provider.GetCertificate(&tls.ClientHelloInfo{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			// The files are not swapped atomically, the watcher may
+			// momentarily see a mismatched pair: ignore errors.
+			provider.Watch(ctx, time.Millisecond, nil)
+			wg.Done()
+		}()
+
+		replaceTestCertificate(t, certFile, keyFile, time.Now().Add(time.Minute))
+
+		assert.Eventually(t, func() bool {
+			cert, _ := provider.GetCertificate(&tls.ClientHelloInfo{})
+			return cert != original
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		wg.Wait()
+	})
+}
+
+// replaceTestCertificate generates a new certificate and moves it to the given
+// paths, setting their modification time to the given time.
+func replaceTestCertificate(t *testing.T, certFile, keyFile string, modTime time.Time) {
+	newCertFile, newKeyFile := generateTestCertificate(t)
+	tmpDir := filepath.Dir(certFile)
+	for src, dst := range map[string]string{newCertFile: certFile, newKeyFile: keyFile} {
+		content, err := os.ReadFile(src)
+		require.NoError(t, err)
+		tmp := filepath.Join(tmpDir, filepath.Base(dst)+".tmp")
+		require.NoError(t, os.WriteFile(tmp, content, 0600))
+		require.NoError(t, os.Chtimes(tmp, modTime, modTime))
+		require.NoError(t, os.Rename(tmp, dst))
+	}
+}
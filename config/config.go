@@ -1,9 +1,9 @@
 package config
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/fs"
+	"maps"
 	"os"
 	"reflect"
 	"strings"
@@ -15,8 +15,6 @@ import (
 
 type object map[string]any
 
-type readFunc func(string) (object, error)
-
 // Config structure holding a configuration that should be used for a single
 // instance of `goyave.Server`.
 //
@@ -25,6 +23,12 @@ type readFunc func(string) (object, error)
 // is in use by an already running server.
 type Config struct {
 	config object
+
+	// sources the name of the source that provided each entry (dot-separated path).
+	sources map[string]string
+
+	// secrets the keys of the secret entries (see `RegisterSecret()`).
+	secrets map[string]struct{}
 }
 
 // Error returned when the configuration could not
@@ -43,12 +47,21 @@ func (e *Error) Unwrap() error {
 }
 
 type loader struct {
-	defaults object
-	mu       sync.RWMutex
+	defaults        object
+	secrets         map[string]struct{}
+	secretResolvers map[string]SecretResolver
+	mu              sync.RWMutex
+	strict          bool
 }
 
 var defaultLoader = &loader{
 	defaults: configDefaults,
+	secrets: map[string]struct{}{
+		"database.password": {},
+	},
+	secretResolvers: map[string]SecretResolver{
+		"file": &FileSecretResolver{},
+	},
 }
 
 // Register a new config entry and its validation.
@@ -87,21 +100,30 @@ func (l *loader) register(key string, entry Entry) {
 }
 
 func (l *loader) loadFrom(fs fs.FS, path string) (*Config, error) {
-	return l.load(func(_ string) (object, error) { return l.readConfigFile(fs, path) }, path)
+	return l.loadSources(&File{FS: fs, Path: path})
 }
 
 func (l *loader) loadJSON(cfg string) (*Config, error) {
-	return l.load(l.readString, cfg)
+	return l.loadString(DecodeJSON, cfg)
 }
 
-func (l *loader) load(readFunc readFunc, source string) (*Config, error) {
+func (l *loader) loadString(decoder Decoder, cfg string) (*Config, error) {
+	return l.loadSources(&Raw{Decoder: decoder, Data: []byte(cfg)})
+}
+
+func (l *loader) loadSources(sources ...Source) (*Config, error) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	config := make(object, len(l.defaults))
 	loadDefaults(l.defaults, config)
+	cfg := &Config{
+		config:  config,
+		sources: map[string]string{},
+		secrets: maps.Clone(l.secrets),
+	}
 
-	if readFunc != nil {
-		conf, err := readFunc(source)
+	for _, source := range sources {
+		conf, err := source.Read(cfg)
 		if err != nil {
 			return nil, errors.New(&Error{err})
 		}
@@ -109,33 +131,67 @@ func (l *loader) load(readFunc readFunc, source string) (*Config, error) {
 		if err := override(conf, config); err != nil {
 			return nil, errors.New(&Error{err})
 		}
+		recordSources(cfg.sources, "", conf, source.Name())
+	}
+
+	if l.strict {
+		if err := l.unknownEntries(cfg); err != nil {
+			return nil, errors.New(&Error{err})
+		}
+	}
+
+	if err := cfg.resolveSecrets(l.secretResolvers); err != nil {
+		return nil, errors.New(&Error{err})
 	}
 
 	if err := config.validate(""); err != nil {
 		return nil, errors.New(&Error{err})
 	}
 
-	return &Config{
-		config: config,
-	}, nil
+	return cfg, nil
 }
 
-// Load loads the config.json file in the current working directory.
+// Load loads the config file in the current working directory.
 // If the "GOYAVE_ENV" env variable is set, the config file will be picked like so:
 //   - "production": "config.production.json"
 //   - "test": "config.test.json"
 //   - By default: "config.json"
+//
+// If the JSON file doesn't exist, the files with the other supported extensions
+// are tried in this order: ".yaml", ".yml", ".toml", then the extensions registered
+// with `RegisterDecoder()`. The first file found is loaded.
 func Load() (*Config, error) {
-	return defaultLoader.loadFrom(&osfs.FS{}, getConfigFilePath())
+	filesystem := &osfs.FS{}
+	return defaultLoader.loadFrom(filesystem, findConfigFile(filesystem, getConfigFilePath()))
 }
 
 // LoadDefault loads default config.
 func LoadDefault() *Config {
-	cfg, _ := defaultLoader.load(nil, "")
+	cfg, _ := defaultLoader.loadSources()
 	return cfg
 }
 
-// LoadFrom loads a config file from the given path.
+// LoadSources loads a configuration composed of the given sources. The sources are
+// merged in order on top of the defaults: each source overrides the entries provided by
+// the previous ones. The resulting configuration is validated once all the sources
+// are merged.
+//
+// Use `Config.Source()` to know which source provided an entry.
+//
+//	cfg, err := config.LoadSources(
+//		&config.File{Path: "config.json"},
+//		&config.File{Path: "config.production.json", Optional: true},
+//		&config.Directory{Path: "config.d"},
+//		&config.Env{Prefix: "GOYAVE_"},
+//		&config.Flags{},
+//	)
+func LoadSources(sources ...Source) (*Config, error) {
+	return defaultLoader.loadSources(sources...)
+}
+
+// LoadFrom loads a config file from the given path. The decoder is selected
+// using the file extension (see `RegisterDecoder()`). Files with an unknown
+// extension are decoded as JSON.
 func LoadFrom(path string) (*Config, error) {
 	return defaultLoader.loadFrom(&osfs.FS{}, path)
 }
@@ -167,6 +223,22 @@ func LoadJSON(cfg string) (*Config, error) {
 	return defaultLoader.loadJSON(cfg)
 }
 
+// LoadYAML load a configuration file from raw YAML. Can be used in combination with
+// Go's embed directive, just like `LoadJSON()`.
+//
+//	var (
+//		//go:embed config.yaml
+//		cfgYAML string
+//	)
+//
+//	func main() {
+//		cfg, err := config.LoadYAML(cfgYAML)
+//		// ...
+//	}
+func LoadYAML(cfg string) (*Config, error) {
+	return defaultLoader.loadString(DecodeYAML, cfg)
+}
+
 func getConfigFilePath() string {
 	env := strings.ToLower(os.Getenv("GOYAVE_ENV"))
 	if env == "local" || env == "localhost" || env == "" {
@@ -175,33 +247,17 @@ func getConfigFilePath() string {
 	return "config." + env + ".json"
 }
 
-func (l *loader) readConfigFile(filesystem fs.FS, file string) (o object, err error) {
-	var configFile fs.File
-	o = make(object, len(l.defaults))
-	configFile, err = filesystem.Open(file)
-
-	if err == nil {
-		defer func() {
-			e := configFile.Close()
-			if err == nil && e != nil {
-				err = errors.New(e)
-			}
-		}()
-		jsonParser := json.NewDecoder(configFile)
-		err = errors.New(jsonParser.Decode(&o))
-	} else {
-		err = errors.New(err)
-	}
-
-	return
-}
-
-func (l *loader) readString(str string) (object, error) {
-	conf := make(object, len(l.defaults))
-	if err := json.NewDecoder(strings.NewReader(str)).Decode(&conf); err != nil {
-		return nil, err
+// findConfigFile returns the path of the first existing file having the same name
+// as the given JSON file path and one of the supported extensions.
+// If none exist, the given path is returned.
+func findConfigFile(filesystem fs.FS, jsonPath string) string {
+	base := strings.TrimSuffix(jsonPath, ".json")
+	for _, ext := range getDecoderExtensions() {
+		if _, err := fs.Stat(filesystem, base+ext); err == nil {
+			return base + ext
+		}
 	}
-	return conf, nil
+	return jsonPath
 }
 
 // walk the config using the key. Returns the deepest category, the entry key
@@ -357,6 +413,14 @@ func (c *Config) Get(key string) any {
 }
 
 func (c *Config) get(key string) (any, bool) {
+	entry := c.getEntry(key)
+	if entry == nil {
+		return nil, false
+	}
+	return entry.Value, entry.Value != nil // nil means unset
+}
+
+func (c *Config) getEntry(key string) *Entry {
 	currentCategory := c.config
 	start := 0
 	dotIndex := strings.Index(key, ".")
@@ -372,8 +436,7 @@ func (c *Config) get(key string) (any, bool) {
 		if category, ok := entry.(object); ok {
 			currentCategory = category
 		} else {
-			val := entry.(*Entry).Value
-			return val, val != nil // nil means unset
+			return entry.(*Entry)
 		}
 
 		if dotIndex+1 <= len(key) {
@@ -386,7 +449,7 @@ func (c *Config) get(key string) (any, bool) {
 			}
 		}
 	}
-	return nil, false
+	return nil
 }
 
 // GetString a config entry as string.
@@ -475,6 +538,19 @@ func (c *Config) Has(key string) bool {
 	return ok
 }
 
+// Source returns the name of the source that provided the value of the given entry.
+// Returns `SourceDefault` if the entry has its default value, `SourceRuntime` if it
+// was modified using `Set()`, or an empty string if the entry doesn't exist.
+func (c *Config) Source(key string) string {
+	if c.getEntry(key) == nil {
+		return ""
+	}
+	if source, ok := c.sources[key]; ok {
+		return source
+	}
+	return SourceDefault
+}
+
 // Set a config entry.
 // The change is temporary and will not be saved for next boot.
 // Use "nil" to unset a value.
@@ -504,4 +580,8 @@ func (c *Config) Set(key string, value any) {
 	} else {
 		category[entryKey] = makeEntryFromValue(value)
 	}
+	if c.sources == nil {
+		c.sources = map[string]string{}
+	}
+	c.sources[key] = SourceRuntime
 }
@@ -113,6 +113,35 @@ func TestLoad(t *testing.T) {
 		assert.Equal(t, expected, cfg.config["app"].(object)["name"])
 	})
 
+	t.Run("LoadFrom_yaml_toml", func(t *testing.T) {
+		for _, file := range []string{"../resources/custom_config.yaml", "../resources/custom_config.toml"} {
+			t.Run(file, func(t *testing.T) {
+				cfg, err := LoadFrom(file)
+				require.NoError(t, err)
+
+				assert.Equal(t, "value", cfg.Get("custom-entry"))
+				assert.Equal(t, 8081, cfg.GetInt("server.port"))
+				assert.Equal(t, 20.5, cfg.GetFloat("server.maxUploadSize"))
+				assert.False(t, cfg.GetBool("app.debug"))
+				assert.Equal(t, []any{1.0, 2.0, 3.0}, cfg.Get("database.config.ints"))
+				assert.Equal(t, "goyave", cfg.GetString("app.name"))
+			})
+		}
+	})
+
+	t.Run("LoadYAML", func(t *testing.T) {
+		cfg, err := LoadYAML("custom-entry: value\nserver:\n  port: 1234")
+		require.NoError(t, err)
+		assert.Equal(t, "value", cfg.Get("custom-entry"))
+		assert.Equal(t, 1234, cfg.GetInt("server.port"))
+
+		_, err = LoadYAML("server:\n  port: 'not a number'")
+		require.Error(t, err)
+
+		_, err = LoadYAML("- not an object")
+		require.Error(t, err)
+	})
+
 	t.Run("LoadJSON", func(t *testing.T) {
 		cfg, err := LoadJSON(`{"custom-entry": "value"}`)
 		require.NoError(t, err)
@@ -240,27 +269,105 @@ func TestLoad(t *testing.T) {
 		t.Setenv("TEST_ENV_BOOL", "TRUE")
 	})
 
-	t.Run("Load Env Variables Unsupported", func(t *testing.T) {
+	t.Run("Load Env Variables Slices", func(t *testing.T) {
 		loader := loader{
 			defaults: make(object, len(defaultLoader.defaults)),
 		}
 
-		loader.register("envUnsupported", Entry{
+		loader.register("envStrings", Entry{
 			Value:            []string{},
 			AuthorizedValues: []any{},
 			Type:             reflect.String,
 			IsSlice:          true,
 		})
-
-		t.Setenv("TEST_ENV_UNSUPPORTED", "[hello]")
+		loader.register("envInts", Entry{
+			Value:            []int{},
+			AuthorizedValues: []any{},
+			Type:             reflect.Int,
+			IsSlice:          true,
+		})
+		loader.register("envFloats", Entry{
+			Value:            []float64{},
+			AuthorizedValues: []any{},
+			Type:             reflect.Float64,
+			IsSlice:          true,
+		})
+		loader.register("envBools", Entry{
+			Value:            []bool{},
+			AuthorizedValues: []any{},
+			Type:             reflect.Bool,
+			IsSlice:          true,
+		})
+		loader.register("envAuthorized", Entry{
+			Value:            []string{},
+			AuthorizedValues: []any{"a", "b"},
+			Type:             reflect.String,
+			IsSlice:          true,
+		})
 
 		json := `{
-			"envUnsupported": "${TEST_ENV_UNSUPPORTED}"
+			"envStrings": "${TEST_ENV_STRINGS}",
+			"envInts": "${TEST_ENV_INTS}",
+			"envFloats": "${TEST_ENV_FLOATS}",
+			"envBools": "${TEST_ENV_BOOLS}",
+			"envAuthorized": "${TEST_ENV_AUTHORIZED}"
 		}`
 
+		setEnv := func(strings, ints, floats, bools, authorized string) {
+			t.Setenv("TEST_ENV_STRINGS", strings)
+			t.Setenv("TEST_ENV_INTS", ints)
+			t.Setenv("TEST_ENV_FLOATS", floats)
+			t.Setenv("TEST_ENV_BOOLS", bools)
+			t.Setenv("TEST_ENV_AUTHORIZED", authorized)
+		}
+
+		// Comma-separated
+		setEnv("https://example.org, https://example.com", "1,2, 3", "1.5,2", "true,0", "a,b,a")
 		cfg, err := loader.loadJSON(json)
-		require.Error(t, err)
-		assert.Nil(t, cfg)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://example.org", "https://example.com"}, cfg.GetStringSlice("envStrings"))
+		assert.Equal(t, []int{1, 2, 3}, cfg.GetIntSlice("envInts"))
+		assert.Equal(t, []float64{1.5, 2}, cfg.GetFloatSlice("envFloats"))
+		assert.Equal(t, []bool{true, false}, cfg.GetBoolSlice("envBools"))
+		assert.Equal(t, []string{"a", "b", "a"}, cfg.GetStringSlice("envAuthorized"))
+
+		// JSON arrays
+		setEnv(`["a,b", "c"]`, "[1, 2]", "[1.5]", "[true]", `[]`)
+		cfg, err = loader.loadJSON(json)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a,b", "c"}, cfg.GetStringSlice("envStrings"))
+		assert.Equal(t, []int{1, 2}, cfg.GetIntSlice("envInts"))
+		assert.Equal(t, []float64{1.5}, cfg.GetFloatSlice("envFloats"))
+		assert.Equal(t, []bool{true}, cfg.GetBoolSlice("envBools"))
+		assert.Equal(t, []string{}, cfg.GetStringSlice("envAuthorized"))
+
+		// Empty
+		setEnv("", "", " ", "", "")
+		cfg, err = loader.loadJSON(json)
+		require.NoError(t, err)
+		assert.Equal(t, []string{}, cfg.GetStringSlice("envStrings"))
+		assert.Equal(t, []int{}, cfg.GetIntSlice("envInts"))
+		assert.Equal(t, []float64{}, cfg.GetFloatSlice("envFloats"))
+
+		invalid := []struct {
+			env      []string
+			expected string
+		}{
+			{env: []string{"", "1,a", "", "", ""}, expected: "\"envInts\" could not be converted to a slice of int from environment variable \"TEST_ENV_INTS\" of value \"1,a\""},
+			{env: []string{"", "1.5", "", "", ""}, expected: "\"envInts\" could not be converted to a slice of int from environment variable \"TEST_ENV_INTS\" of value \"1.5\""},
+			{env: []string{"", "", "a", "", ""}, expected: "\"envFloats\" could not be converted to a slice of float64 from environment variable \"TEST_ENV_FLOATS\" of value \"a\""},
+			{env: []string{"", "", "", "yes", ""}, expected: "\"envBools\" could not be converted to a slice of bool from environment variable \"TEST_ENV_BOOLS\" of value \"yes\""},
+			{env: []string{"", "", "", "", "a,c"}, expected: "\"envAuthorized\" elements must have one of the following values: [a b]"},
+			{env: []string{"[hello]", "", "", "", ""}, expected: "\"envStrings\" could not be decoded as a JSON array from environment variable \"TEST_ENV_STRINGS\" of value \"[hello]\""},
+			{env: []string{"", "[\"a\"]", "", "", ""}, expected: "\"envInts\" must be a slice of int"},
+		}
+		for _, c := range invalid {
+			setEnv(c.env[0], c.env[1], c.env[2], c.env[3], c.env[4])
+			cfg, err = loader.loadJSON(json)
+			require.Error(t, err)
+			assert.Nil(t, cfg)
+			assert.Contains(t, err.Error(), c.expected)
+		}
 	})
 
 	t.Run("Load Env Variables Missing", func(t *testing.T) {
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+	"goyave.dev/goyave/v5/util/errors"
+)
+
+// Decoder decodes raw configuration data into a map. Nested categories are
+// represented by `map[string]any`.
+//
+// The decoded values don't need to use JSON types: they are normalized
+// before being loaded so all numbers become `float64`, all arrays become `[]any`
+// and dates become RFC 3339 strings. This way, a configuration behaves the same
+// regardless of its format.
+type Decoder func(data []byte) (map[string]any, error)
+
+var (
+	decoders = map[string]Decoder{
+		".json": DecodeJSON,
+		".yaml": DecodeYAML,
+		".yml":  DecodeYAML,
+		".toml": DecodeTOML,
+	}
+	// decoderExtensions the order in which extensions are tried when discovering config files.
+	decoderExtensions = []string{".json", ".yaml", ".yml", ".toml"}
+	decodersMu        sync.RWMutex
+)
+
+// RegisterDecoder registers a decoder for the config files having the given extension
+// (including the leading dot, e.g. ".hcl"). If a decoder already exists for this extension,
+// it is replaced.
+//
+// The extensions registered with this function are also used by `Load()` when discovering
+// the config file, after the built-in extensions (".json", ".yaml", ".yml", ".toml").
+func RegisterDecoder(extension string, decoder Decoder) {
+	extension = strings.ToLower(extension)
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	if _, ok := decoders[extension]; !ok {
+		decoderExtensions = append(decoderExtensions, extension)
+	}
+	decoders[extension] = decoder
+}
+
+// getDecoder returns the decoder associated with the extension of the given file.
+// Files with an unknown extension are decoded as JSON.
+func getDecoder(file string) Decoder {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+	if decoder, ok := decoders[strings.ToLower(path.Ext(file))]; ok {
+		return decoder
+	}
+	return DecodeJSON
+}
+
+func getDecoderExtensions() []string {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+	return append([]string{}, decoderExtensions...)
+}
+
+// DecodeJSON decodes a JSON configuration.
+func DecodeJSON(data []byte) (map[string]any, error) {
+	conf := map[string]any{}
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(&conf); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// DecodeYAML decodes a YAML configuration. Only the first document is decoded.
+func DecodeYAML(data []byte) (map[string]any, error) {
+	conf := map[string]any{}
+	if err := yaml.Unmarshal(data, &conf); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// DecodeTOML decodes a TOML v1.0 configuration. Offset date-times are converted to
+// RFC 3339 strings, local dates and times are kept as strings.
+func DecodeTOML(data []byte) (map[string]any, error) {
+	conf := map[string]any{}
+	if err := toml.Unmarshal(data, &conf); err != nil {
+		if decodeErr, ok := err.(*toml.DecodeError); ok {
+			row, column := decodeErr.Position()
+			return nil, fmt.Errorf("toml: line %d, column %d: %s", row, column, strings.TrimPrefix(decodeErr.Error(), "toml: "))
+		}
+		return nil, err
+	}
+	return conf, nil
+}
+
+// decode the given data using the given decoder and normalize the result.
+func decode(decoder Decoder, data []byte) (object, error) {
+	conf, err := decoder(data)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	normalized, err := normalize(conf)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	return normalized.(map[string]any), nil
+}
+
+// normalize converts the given decoded value so it only uses the types
+// produced by the JSON decoder.
+func normalize(value any) (any, error) {
+	switch v := value.(type) {
+	case nil, string, bool, float64:
+		return v, nil
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, val := range v {
+			n, err := normalize(val)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = n
+		}
+		return m, nil
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, val := range v {
+			n, err := normalize(val)
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprintf("%v", key)] = n
+		}
+		return m, nil
+	case []any:
+		s := make([]any, 0, len(v))
+		for _, val := range v {
+			n, err := normalize(val)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, n)
+		}
+		return s, nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return string(text), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	case reflect.Float32:
+		return rv.Float(), nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return rv.Bool(), nil
+	}
+	return nil, fmt.Errorf("unsupported config value type %T", value)
+}
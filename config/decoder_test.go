@@ -0,0 +1,347 @@
+package config
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoders(t *testing.T) {
+	t.Run("getDecoder", func(t *testing.T) {
+		funcPointer := func(f Decoder) uintptr { return reflect.ValueOf(f).Pointer() }
+		cases := map[string]string{
+			"config.json": ".json",
+			"config.YAML": ".yaml",
+			"config.yml":  ".yml",
+			"config.toml": ".toml",
+			"config":      ".json",
+			"config.conf": ".json",
+		}
+		for file, ext := range cases {
+			decoder := getDecoder(file)
+			assert.Equal(t, funcPointer(decoders[ext]), funcPointer(decoder), file)
+		}
+	})
+
+	t.Run("RegisterDecoder", func(t *testing.T) {
+		called := false
+		RegisterDecoder(".CONF", func(_ []byte) (map[string]any, error) {
+			called = true
+			return map[string]any{}, nil
+		})
+		t.Cleanup(func() {
+			decodersMu.Lock()
+			delete(decoders, ".conf")
+			decoderExtensions = decoderExtensions[:len(decoderExtensions)-1]
+			decodersMu.Unlock()
+		})
+		assert.Equal(t, []string{".json", ".yaml", ".yml", ".toml", ".conf"}, getDecoderExtensions())
+
+		_, err := getDecoder("config.conf")(nil)
+		require.NoError(t, err)
+		assert.True(t, called)
+
+		// Replace
+		RegisterDecoder(".conf", DecodeJSON)
+		assert.Len(t, getDecoderExtensions(), 5)
+	})
+
+	t.Run("findConfigFile", func(t *testing.T) {
+		filesystem := fstest.MapFS{
+			"config.yml":             &fstest.MapFile{},
+			"config.toml":            &fstest.MapFile{},
+			"config.production.toml": &fstest.MapFile{},
+			"config.test.json":       &fstest.MapFile{},
+			"config.test.yaml":       &fstest.MapFile{},
+		}
+		assert.Equal(t, "config.yml", findConfigFile(filesystem, "config.json"))
+		assert.Equal(t, "config.production.toml", findConfigFile(filesystem, "config.production.json"))
+		assert.Equal(t, "config.test.json", findConfigFile(filesystem, "config.test.json"))
+		assert.Equal(t, "config.staging.json", findConfigFile(filesystem, "config.staging.json"))
+	})
+
+	t.Run("decode_equivalence", func(t *testing.T) {
+		jsonData := `{"a": 1, "b": [1, "2", true], "c": {"d": 1.5, "e": null}}`
+		yamlData := "a: 1\nb: [1, \"2\", true]\nc:\n  d: 1.5\n  e: null\n"
+		tomlData := "a = 1\nb = [1, \"2\", true]\n[c]\nd = 1.5\n"
+
+		expected := object{"a": 1.0, "b": []any{1.0, "2", true}, "c": map[string]any{"d": 1.5, "e": nil}}
+
+		o, err := decode(DecodeJSON, []byte(jsonData))
+		require.NoError(t, err)
+		assert.Equal(t, expected, o)
+
+		o, err = decode(DecodeYAML, []byte(yamlData))
+		require.NoError(t, err)
+		assert.Equal(t, expected, o)
+
+		o, err = decode(DecodeTOML, []byte(tomlData))
+		require.NoError(t, err)
+		delete(expected["c"].(map[string]any), "e") // No null in TOML
+		assert.Equal(t, expected, o)
+	})
+
+	t.Run("decode_errors", func(t *testing.T) {
+		_, err := decode(DecodeJSON, []byte(`{"a":`))
+		require.Error(t, err)
+
+		_, err = decode(DecodeYAML, []byte("a: [1"))
+		require.Error(t, err)
+
+		_, err = decode(DecodeTOML, []byte("a = "))
+		require.Error(t, err)
+
+		_, err = decode(func(_ []byte) (map[string]any, error) {
+			return map[string]any{"a": struct{}{}}, nil
+		}, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	value, err := normalize(map[string]any{
+		"int":     int(1),
+		"int64":   int64(2),
+		"uint":    uint8(3),
+		"float32": float32(1.5),
+		"time":    date,
+		"map":     map[any]any{1: "a", "b": []any{int64(1)}},
+		"string":  "str",
+		"bool":    true,
+		"nil":     nil,
+	})
+	require.NoError(t, err)
+	expected := map[string]any{
+		"int":     1.0,
+		"int64":   2.0,
+		"uint":    3.0,
+		"float32": 1.5,
+		"time":    "2024-01-02T03:04:05Z",
+		"map":     map[string]any{"1": "a", "b": []any{1.0}},
+		"string":  "str",
+		"bool":    true,
+		"nil":     nil,
+	}
+	assert.Equal(t, expected, value)
+
+	type customString string
+	value, err = normalize(customString("a"))
+	require.NoError(t, err)
+	assert.Equal(t, "a", value)
+
+	_, err = normalize([]any{struct{}{}})
+	require.Error(t, err)
+	_, err = normalize(map[any]any{"a": struct{}{}})
+	require.Error(t, err)
+}
+
+func TestDecodeTOML(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		data := `
+# Comment
+title = "TOML \"example\" \u00e9\U0001F600" # trailing comment
+literal = 'C:\Users\nodejs'
+empty = ""
+"quoted key" = 1
+'literal key' = 2
+dotted.key.value = true
+bare-key_1 = false
+
+multiline = """
+Roses are red
+Violets are blue"""
+trimmed = """\
+    The quick brown \
+    fox."""
+quotes = """Here are two quotation marks: "". Ending with one.""""
+multilineLiteral = '''
+The first newline is
+trimmed in raw strings.'''
+
+int = +99
+negative = -17
+underscore = 1_000
+hex = 0xDEAD_beef
+oct = 0o755
+bin = 0b1101
+float = 6.626e-34
+fraction = -0.01
+exponent = 5e+22
+inf = -inf
+date = 1979-05-27T07:32:00Z
+dateSpace = 1979-05-27 07:32:00.999-07:00
+localDate = 1979-05-27
+localTime = 07:32:00
+
+array = [ 1, 2, 3 ]
+nested = [ [ 1, 2 ], ["a", 'b'] ]
+multilineArray = [
+  1, # comment
+  2,
+]
+inline = { x = 1, y.z = "a" }
+emptyInline = {}
+
+[server]
+port = 8080
+
+[server.tls]
+enabled = true
+
+[database."connection options"]
+maxOpen = 10
+
+[[products]]
+name = "Hammer"
+
+[[products]]
+
+[[products]]
+name = "Nail"
+[products.details]
+color = "gray"
+
+[fruit]
+apple.color = "red"
+[fruit.apple.texture]
+smooth = true
+`
+		result, err := decode(DecodeTOML, []byte(data))
+		require.NoError(t, err)
+
+		assert.True(t, math.IsInf(result["inf"].(float64), -1))
+		delete(result, "inf")
+
+		expected := object{
+			"title":            "TOML \"example\" é😀",
+			"literal":          `C:\Users\nodejs`,
+			"empty":            "",
+			"quoted key":       float64(1),
+			"literal key":      float64(2),
+			"dotted":           map[string]any{"key": map[string]any{"value": true}},
+			"bare-key_1":       false,
+			"multiline":        "Roses are red\nViolets are blue",
+			"trimmed":          "The quick brown fox.",
+			"quotes":           `Here are two quotation marks: "". Ending with one."`,
+			"multilineLiteral": "The first newline is\ntrimmed in raw strings.",
+			"int":              float64(99),
+			"negative":         float64(-17),
+			"underscore":       float64(1000),
+			"hex":              float64(0xdeadbeef),
+			"oct":              float64(0o755),
+			"bin":              float64(13),
+			"float":            6.626e-34,
+			"fraction":         -0.01,
+			"exponent":         5e+22,
+			"date":             "1979-05-27T07:32:00Z",
+			"dateSpace":        "1979-05-27T07:32:00.999-07:00",
+			"localDate":        "1979-05-27",
+			"localTime":        "07:32:00",
+			"array":            []any{float64(1), float64(2), float64(3)},
+			"nested":           []any{[]any{float64(1), float64(2)}, []any{"a", "b"}},
+			"multilineArray":   []any{float64(1), float64(2)},
+			"inline":           map[string]any{"x": float64(1), "y": map[string]any{"z": "a"}},
+			"emptyInline":      map[string]any{},
+			"server": map[string]any{
+				"port": float64(8080),
+				"tls":  map[string]any{"enabled": true},
+			},
+			"database": map[string]any{
+				"connection options": map[string]any{"maxOpen": float64(10)},
+			},
+			"products": []any{
+				map[string]any{"name": "Hammer"},
+				map[string]any{},
+				map[string]any{"name": "Nail", "details": map[string]any{"color": "gray"}},
+			},
+			"fruit": map[string]any{
+				"apple": map[string]any{
+					"color":   "red",
+					"texture": map[string]any{"smooth": true},
+				},
+			},
+		}
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("crlf", func(t *testing.T) {
+		result, err := decode(DecodeTOML, []byte("a = 1\r\n[b]\r\nc = 'd'\r\n"))
+		require.NoError(t, err)
+		assert.Equal(t, object{"a": 1.0, "b": map[string]any{"c": "d"}}, result)
+	})
+
+	t.Run("nan", func(t *testing.T) {
+		result, err := decode(DecodeTOML, []byte("a = nan\nb = +inf"))
+		require.NoError(t, err)
+		assert.True(t, math.IsNaN(result["a"].(float64)))
+		assert.True(t, math.IsInf(result["b"].(float64), 1))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := map[string]string{
+			"duplicate_key":           "a = 1\na = 2",
+			"duplicate_table":         "[a]\n[a]",
+			"table_redefines_value":   "a = 1\n[a]",
+			"dotted_then_table":       "[a]\nb.c = 1\n[a.b]",
+			"table_then_dotted":       "[a.b]\n[a]\nb.c = 1",
+			"extend_inline":           "a = {b = 1}\n[a.c]",
+			"extend_inline_dotted":    "a = {b = 1}\na.c = 1",
+			"array_then_table_array":  "a = []\n[[a]]",
+			"table_then_table_array":  "[a]\n[[a]]",
+			"missing_value":           "a =",
+			"missing_equal":           "a 1",
+			"invalid_key":             "= 1",
+			"unterminated_string":     "a = \"abc",
+			"unterminated_multiline":  "a = \"\"\"abc",
+			"unterminated_literal":    "a = 'abc",
+			"unterminated_ml_literal": "a = '''abc",
+			"newline_in_string":       "a = \"a\nb\"",
+			"invalid_escape":          `a = "\x"`,
+			"invalid_unicode":         `a = "\uZZZZ"`,
+			"leading_zero":            "a = 01",
+			"double_underscore":       "a = 1__0",
+			"invalid_float":           "a = 1.",
+			"bare_value":              "a = abc",
+			"two_values":              "a = 1 2",
+			"unclosed_header":         "[a",
+			"unclosed_array_header":   "[[a]",
+			"unclosed_array":          "a = [1, 2",
+			"array_missing_comma":     "a = [1 2]",
+			"inline_trailing_comma":   "a = {b = 1,}",
+			"inline_newline":          "a = {b = 1\n}",
+			"multiline_key":           "\"\"\"a\"\"\" = 1",
+			"multiline_literal_key":   "'''a''' = 1",
+			"control_char":            "a = \"\x01\"",
+			"comment_control_char":    "# \x01",
+			"invalid_utf8":            "a = \"\xff\"",
+			"key_not_table":           "a = 1\nb = 2\na.c = 3",
+			"header_not_table":        "a = 1\n[a.b]",
+			"out_of_range":            "a = 99999999999999999999",
+			"invalid_month":           "a = 2021-13-01",
+			"invalid_day":             "a = 2021-12-45",
+			"invalid_leap_day":        "a = 2021-02-29",
+			"invalid_date_time":       "a = 2021-04-31T07:32:00Z",
+			"invalid_hour":            "a = 25:00:00",
+			"invalid_minute":          "a = 07:60:00",
+			"invalid_offset":          "a = 1979-05-27T07:32:00+25:00",
+			"short_date":              "a = 1979-5-27",
+		}
+		for name, data := range cases {
+			t.Run(name, func(t *testing.T) {
+				_, err := DecodeTOML([]byte(data))
+				assert.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("error_line", func(t *testing.T) {
+		_, err := DecodeTOML([]byte("a = 1\nb = 2\nc = ?"))
+		assert.ErrorContains(t, err, "toml: line 3, column 5")
+	})
+}
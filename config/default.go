@@ -25,6 +25,12 @@ var configDefaults = object{
 			"port":     &Entry{80, []any{}, reflect.Int, false, true},
 			"base":     &Entry{"", []any{}, reflect.String, false, true},
 		},
+		"tls": object{
+			"cert":           &Entry{nil, []any{}, reflect.String, false, false},
+			"key":            &Entry{nil, []any{}, reflect.String, false, false},
+			"redirectPort":   &Entry{nil, []any{}, reflect.Int, false, false},
+			"reloadInterval": &Entry{60, []any{}, reflect.Int, false, true},
+		},
 	},
 	"database": object{
 		"connection":               &Entry{"none", []any{}, reflect.String, false, true},
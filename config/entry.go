@@ -1,6 +1,8 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"reflect"
 	"strconv"
@@ -152,10 +154,6 @@ func (e *Entry) tryEnvVarConversion(key string) error {
 	if ok {
 		val, err := e.convertEnvVar(str, key)
 		if err == nil && val != nil {
-			if e.IsSlice {
-				return errors.Errorf("%q is a slice entry, it cannot be loaded from env", key)
-			}
-
 			e.Value = val
 		}
 		return err
@@ -172,27 +170,94 @@ func (e *Entry) convertEnvVar(str, key string) (any, error) {
 			return nil, errors.Errorf("%q: %q environment variable is not set", key, varName)
 		}
 
-		switch e.Type {
-		case reflect.Int:
-			if i, err := strconv.Atoi(value); err == nil {
-				return i, nil
-			}
-			return nil, errors.Errorf("%q could not be converted to int from environment variable %q of value %q", key, varName, value)
-		case reflect.Float64:
-			if f, err := strconv.ParseFloat(value, 64); err == nil {
-				return f, nil
-			}
-			return nil, errors.Errorf("%q could not be converted to float64 from environment variable %q of value %q", key, varName, value)
-		case reflect.Bool:
-			if b, err := strconv.ParseBool(value); err == nil {
-				return b, nil
-			}
-			return nil, errors.Errorf("%q could not be converted to bool from environment variable %q of value %q", key, varName, value)
-		default:
-			// Keep value as string if type is not supported and let validation do its job
-			return value, nil
-		}
+		return e.convertString(key, fmt.Sprintf("environment variable %q", varName), value)
 	}
 
 	return nil, nil
 }
+
+// convertString converts the given string to the type of the entry. The origin
+// describes where the value comes from and is used in the error messages.
+//
+// For slice entries, the value is either a JSON array (`["a","b"]`) or a list of
+// comma-separated values (`a,b`). Spaces around comma-separated values are trimmed
+// and an empty string results in an empty slice.
+func (e *Entry) convertString(key, origin, value string) (any, error) {
+	if e.IsSlice {
+		return e.convertSliceString(key, origin, value)
+	}
+
+	switch e.Type {
+	case reflect.Int:
+		if i, err := strconv.Atoi(value); err == nil {
+			return i, nil
+		}
+		return nil, errors.Errorf("%q could not be converted to int from %s of value %q", key, origin, value)
+	case reflect.Float64:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f, nil
+		}
+		return nil, errors.Errorf("%q could not be converted to float64 from %s of value %q", key, origin, value)
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b, nil
+		}
+		return nil, errors.Errorf("%q could not be converted to bool from %s of value %q", key, origin, value)
+	default:
+		// Keep value as string if type is not supported and let validation do its job
+		return value, nil
+	}
+}
+
+func (e *Entry) convertSliceString(key, origin, value string) (any, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "[") {
+		var slice []any
+		if err := json.Unmarshal([]byte(value), &slice); err != nil {
+			return nil, errors.Errorf("%q could not be decoded as a JSON array from %s of value %q", key, origin, value)
+		}
+		// The elements are converted to the type of the entry during validation,
+		// just like slices coming from config files.
+		return slice, nil
+	}
+
+	elements := []string{}
+	if value != "" {
+		elements = strings.Split(value, ",")
+		for i, element := range elements {
+			elements[i] = strings.TrimSpace(element)
+		}
+	}
+
+	var result any
+	var err error
+	switch e.Type {
+	case reflect.Int:
+		result, err = parseSlice(elements, strconv.Atoi)
+	case reflect.Float64:
+		result, err = parseSlice(elements, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+	case reflect.Bool:
+		result, err = parseSlice(elements, strconv.ParseBool)
+	case reflect.String:
+		result = elements
+	default:
+		// Let validation do its job
+		result = lo.ToAnySlice(elements)
+	}
+	if err != nil {
+		return nil, errors.Errorf("%q could not be converted to a slice of %s from %s of value %q", key, e.Type, origin, value)
+	}
+	return result, nil
+}
+
+func parseSlice[T any](elements []string, parse func(string) (T, error)) ([]T, error) {
+	result := make([]T, 0, len(elements))
+	for _, element := range elements {
+		v, err := parse(element)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
@@ -0,0 +1,272 @@
+package config
+
+import (
+	"context"
+	"io/fs"
+	"maps"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Change describes the modification of a config entry after a reload.
+type Change struct {
+	// Config the new configuration.
+	Config *Config
+
+	// Previous value of the entry. `nil` if the entry didn't exist or was unset.
+	Previous any
+
+	// Value the new value of the entry. `nil` if the entry doesn't exist anymore or is unset.
+	Value any
+
+	// Key the dot-separated path of the entry.
+	Key string
+}
+
+// Reloadable holds a configuration composed of sources (see `LoadSources()`) that
+// can be reloaded at runtime. When reloading, all the sources are read again and
+// the new configuration is validated against the registered entries before replacing
+// the current one atomically. If the new configuration is invalid, the current one
+// is kept.
+//
+// Components can subscribe to changes on specific entries to adapt their behavior
+// without restarting the server.
+//
+// The `*Config` returned by `Config()` is never modified by a reload: a new one is created
+// instead. Components should therefore always use `Config()` (or `Server.Config()`) instead
+// of keeping a reference to a previous configuration. Changes made with `Config.Set()` are
+// lost after a reload.
+type Reloadable struct {
+	loader  *loader
+	sources []Source
+	current atomic.Pointer[Config]
+
+	reloadMu sync.Mutex
+	modTimes map[string]time.Time
+
+	mu            sync.Mutex
+	subscriptions []*subscription
+}
+
+type subscription struct {
+	callback func(Change)
+	key      string
+}
+
+// NewReloadable loads a new configuration from the given sources (see `LoadSources()`)
+// and returns a `Reloadable` holding it. Returns an error if the initial configuration
+// could not be loaded.
+func NewReloadable(sources ...Source) (*Reloadable, error) {
+	r := &Reloadable{
+		loader:  defaultLoader,
+		sources: sources,
+	}
+	r.modTimes = r.stat()
+	cfg, err := r.loader.loadSources(sources...)
+	if err != nil {
+		return nil, err
+	}
+	r.current.Store(cfg)
+	return r, nil
+}
+
+// Config returns the current configuration.
+// This operation is concurrently safe.
+func (r *Reloadable) Config() *Config {
+	return r.current.Load()
+}
+
+// Subscribe registers a callback executed after each reload for every changed entry
+// matching the given key. The key can be the path of an entry ("app.debug") or of a
+// category ("server"), in which case the callback is executed for all the changed entries
+// inside this category. An empty key matches all entries.
+//
+// Callbacks are executed synchronously in the goroutine that triggered the reload,
+// after the new configuration has been swapped in.
+//
+// The returned function removes the subscription.
+// This operation is concurrently safe.
+func (r *Reloadable) Subscribe(key string, callback func(Change)) (unsubscribe func()) {
+	sub := &subscription{key: key, callback: callback}
+	r.mu.Lock()
+	r.subscriptions = append(r.subscriptions, sub)
+	r.mu.Unlock()
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for i, s := range r.subscriptions {
+			if s == sub {
+				r.subscriptions = append(r.subscriptions[:i], r.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Reload reads all the sources again and replaces the current configuration if the new
+// one is valid. The subscribers of the changed entries are then notified.
+// Returns `true` if at least one entry changed.
+//
+// If the new configuration is invalid, the previous one is kept and an error is returned.
+// This operation is concurrently safe.
+func (r *Reloadable) Reload() (bool, error) {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+	return r.reload()
+}
+
+func (r *Reloadable) reload() (bool, error) {
+	// Update the modification times even if the new configuration is invalid
+	// so the same invalid files are not reloaded again by `Watch()`.
+	r.modTimes = r.stat()
+	cfg, err := r.loader.loadSources(r.sources...)
+	if err != nil {
+		return false, err
+	}
+
+	previous := r.current.Swap(cfg)
+	changes := diff(previous, cfg)
+	if len(changes) == 0 {
+		return false, nil
+	}
+
+	r.mu.Lock()
+	subscriptions := append([]*subscription{}, r.subscriptions...)
+	r.mu.Unlock()
+	for _, change := range changes {
+		for _, sub := range subscriptions {
+			if sub.key == "" || change.Key == sub.key || strings.HasPrefix(change.Key, sub.key+".") {
+				sub.callback(change)
+			}
+		}
+	}
+	return true, nil
+}
+
+// Watch the files read by the `File` and `Directory` sources for changes every `interval`,
+// reloading the configuration when at least one of them has been added, removed or
+// modified. This operation is blocking and returns when the given context is canceled.
+//
+// `onError` is called each time a reload fails. The previous configuration is
+// kept in use in this case.
+func (r *Reloadable) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.reloadIfModified(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+func (r *Reloadable) reloadIfModified() error {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+	if maps.EqualFunc(r.modTimes, r.stat(), time.Time.Equal) {
+		return nil
+	}
+	_, err := r.reload()
+	return err
+}
+
+// WatchSignal reloads the configuration each time one of the given signals is received.
+// If no signal is given, listens to `SIGHUP`. This operation is blocking and returns
+// when the given context is canceled.
+//
+// `onError` is called each time a reload fails. The previous configuration is
+// kept in use in this case.
+func (r *Reloadable) WatchSignal(ctx context.Context, onError func(error), signals ...os.Signal) {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+	sigChannel := make(chan os.Signal, 1)
+	signal.Notify(sigChannel, signals...)
+	defer signal.Stop(sigChannel)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigChannel:
+			if _, err := r.Reload(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// stat returns the modification time of all the files read by the
+// `File` and `Directory` sources. Missing files are omitted.
+func (r *Reloadable) stat() map[string]time.Time {
+	modTimes := map[string]time.Time{}
+	for i, source := range r.sources {
+		var filesystem fs.FS
+		var files []string
+		switch s := source.(type) {
+		case *File:
+			filesystem = s.filesystem()
+			files = []string{s.Path}
+		case *Directory:
+			filesystem, files, _ = s.files()
+		default:
+			continue
+		}
+		for _, file := range files {
+			if info, err := fs.Stat(filesystem, file); err == nil {
+				// Prefix with the source index as different sources may use different file systems.
+				modTimes[strconv.Itoa(i)+":"+file] = info.ModTime()
+			}
+		}
+	}
+	return modTimes
+}
+
+// diff returns the changes between the entries of the two given configurations.
+func diff(previous, current *Config) []Change {
+	previousValues := map[string]any{}
+	flatten(previous.config, "", previousValues)
+	currentValues := map[string]any{}
+	flatten(current.config, "", currentValues)
+
+	changes := []Change{}
+	for key, value := range currentValues {
+		if prev, ok := previousValues[key]; !ok || !reflect.DeepEqual(prev, value) {
+			changes = append(changes, Change{Config: current, Key: key, Previous: prev, Value: value})
+		}
+	}
+	for key, prev := range previousValues {
+		if _, ok := currentValues[key]; !ok {
+			changes = append(changes, Change{Config: current, Key: key, Previous: prev})
+		}
+	}
+	slices.SortFunc(changes, func(a, b Change) int { return strings.Compare(a.Key, b.Key) })
+	return changes
+}
+
+// flatten stores the value of all the entries of the given category into the
+// given map, using their dot-separated path as key.
+func flatten(category object, prefix string, values map[string]any) {
+	for k, v := range category {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if sub, ok := v.(object); ok {
+			flatten(sub, key, values)
+			continue
+		}
+		values[key] = v.(*Entry).Value
+	}
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"os/signal"
+	"runtime"
+	"sync"
+	"syscall"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncMapFS a `fstest.MapFS` that can safely be updated while the
+// watchers of a `Reloadable` are reading it.
+type syncMapFS struct {
+	files fstest.MapFS
+	mu    sync.RWMutex
+}
+
+func (f *syncMapFS) Open(name string) (fs.File, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.files.Open(name)
+}
+
+func TestReloadable(t *testing.T) {
+	newFS := func() *syncMapFS {
+		return &syncMapFS{files: fstest.MapFS{
+			"config.json":         {Data: []byte(`{"app": {"debug": true}, "server": {"port": 8081}}`), ModTime: time.Now()},
+			"config.d/cache.json": {Data: []byte(`{"cache": {"ttl": 60}}`), ModTime: time.Now()},
+		}}
+	}
+	updateFile := func(filesystem *syncMapFS, name, data string) {
+		filesystem.mu.Lock()
+		defer filesystem.mu.Unlock()
+		filesystem.files[name] = &fstest.MapFile{Data: []byte(data), ModTime: filesystem.files[name].ModTime.Add(time.Second)}
+	}
+
+	t.Run("NewReloadable", func(t *testing.T) {
+		r, err := NewReloadable(&File{FS: newFS(), Path: "config.json"})
+		require.NoError(t, err)
+		assert.True(t, r.Config().GetBool("app.debug"))
+		assert.Equal(t, 8081, r.Config().GetInt("server.port"))
+		assert.Equal(t, "config.json", r.Config().Source("server.port"))
+
+		r, err = NewReloadable(&File{FS: newFS(), Path: "notafile.json"})
+		require.Error(t, err)
+		assert.Nil(t, r)
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		filesystem := newFS()
+		r, err := NewReloadable(&File{FS: filesystem, Path: "config.json"}, &Directory{FS: filesystem, Path: "config.d"})
+		require.NoError(t, err)
+		previous := r.Config()
+
+		changes := []Change{}
+		r.Subscribe("app.debug", func(c Change) { changes = append(changes, c) })
+		serverChanges := []Change{}
+		unsubscribe := r.Subscribe("server", func(c Change) { serverChanges = append(serverChanges, c) })
+		allChanges := []Change{}
+		r.Subscribe("", func(c Change) { allChanges = append(allChanges, c) })
+		r.Subscribe("app.debugger", func(_ Change) { assert.Fail(t, "unexpected change notification") })
+
+		// Nothing changed
+		changed, err := r.Reload()
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Empty(t, allChanges)
+
+		updateFile(filesystem, "config.json", `{"app": {"debug": false}, "server": {"port": 8082}, "new": "entry"}`)
+		changed, err = r.Reload()
+		require.NoError(t, err)
+		assert.True(t, changed)
+
+		current := r.Config()
+		assert.NotSame(t, previous, current)
+		assert.True(t, previous.GetBool("app.debug")) // The previous config is untouched
+		assert.False(t, current.GetBool("app.debug"))
+		assert.Equal(t, 8082, current.GetInt("server.port"))
+
+		assert.Equal(t, []Change{{Config: current, Key: "app.debug", Previous: true, Value: false}}, changes)
+		assert.Equal(t, []Change{{Config: current, Key: "server.port", Previous: 8081, Value: 8082}}, serverChanges)
+		assert.Equal(t, []Change{
+			{Config: current, Key: "app.debug", Previous: true, Value: false},
+			{Config: current, Key: "new", Previous: nil, Value: "entry"},
+			{Config: current, Key: "server.port", Previous: 8081, Value: 8082},
+		}, allChanges)
+
+		// Removed entry and unsubscribe
+		unsubscribe()
+		unsubscribe() // No-op
+		allChanges = []Change{}
+		updateFile(filesystem, "config.json", `{"app": {"debug": false}, "server": {"port": 8083}}`)
+		changed, err = r.Reload()
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Len(t, serverChanges, 1)
+		assert.Equal(t, []Change{
+			{Config: r.Config(), Key: "new", Previous: "entry", Value: nil},
+			{Config: r.Config(), Key: "server.port", Previous: 8082, Value: 8083},
+		}, allChanges)
+	})
+
+	t.Run("Reload_invalid", func(t *testing.T) {
+		filesystem := newFS()
+		r, err := NewReloadable(&File{FS: filesystem, Path: "config.json"})
+		require.NoError(t, err)
+		previous := r.Config()
+		r.Subscribe("", func(_ Change) { assert.Fail(t, "unexpected change notification") })
+
+		updateFile(filesystem, "config.json", `{"server": {"port": "not an int"}}`)
+		changed, err := r.Reload()
+		require.Error(t, err)
+		assert.False(t, changed)
+		assert.Same(t, previous, r.Config())
+
+		updateFile(filesystem, "config.json", `{"server": {"port":`)
+		changed, err = r.Reload()
+		require.Error(t, err)
+		assert.False(t, changed)
+		assert.Same(t, previous, r.Config())
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		filesystem := newFS()
+		r, err := NewReloadable(&File{FS: filesystem, Path: "config.json"}, &Directory{FS: filesystem, Path: "config.d"}, &Raw{Data: []byte(`{}`)})
+		require.NoError(t, err)
+		assert.Len(t, r.modTimes, 2)
+
+		// Not modified
+		require.NoError(t, r.reloadIfModified())
+
+		// Directory fragment added
+		changed := make(chan Change, 10)
+		r.Subscribe("", func(c Change) { changed <- c })
+		filesystem.files["config.d/db.json"] = &fstest.MapFile{Data: []byte(`{"database": {"name": "db"}}`)}
+		require.NoError(t, r.reloadIfModified())
+		assert.Equal(t, "database.name", (<-changed).Key)
+
+		// Invalid file only reported once
+		updateFile(filesystem, "config.json", `{"server": {"port": "not an int"}}`)
+		require.Error(t, r.reloadIfModified())
+		require.NoError(t, r.reloadIfModified())
+
+		updateFile(filesystem, "config.json", `{"server": {"port": 8090}}`)
+		errors := make(chan error, 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			r.Watch(ctx, time.Millisecond, func(err error) { errors <- err })
+			wg.Done()
+		}()
+
+		select {
+		case c := <-changed:
+			assert.Equal(t, "server.port", c.Key)
+		case err := <-errors:
+			assert.Fail(t, "unexpected error", err)
+		case <-time.After(time.Second):
+			assert.Fail(t, "timeout")
+		}
+		cancel()
+		wg.Wait()
+		assert.Equal(t, 8090, r.Config().GetInt("server.port"))
+	})
+
+	t.Run("WatchSignal", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Testing on a windows machine. Cannot test proc signals")
+		}
+		filesystem := newFS()
+		r, err := NewReloadable(&File{FS: filesystem, Path: "config.json"})
+		require.NoError(t, err)
+
+		// Prevent the process from being terminated if the signal is received
+		// before the reloadable starts listening to it.
+		sigChannel := make(chan os.Signal, 10)
+		signal.Notify(sigChannel, syscall.SIGHUP)
+		defer signal.Stop(sigChannel)
+
+		changed := make(chan Change, 10)
+		r.Subscribe("", func(c Change) { changed <- c })
+		errors := make(chan error, 10)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			r.WatchSignal(ctx, func(err error) { errors <- err })
+			wg.Done()
+		}()
+
+		proc, err := os.FindProcess(os.Getpid())
+		require.NoError(t, err)
+		updateFile(filesystem, "config.json", `{"server": {"port": "not an int"}}`)
+		time.Sleep(10 * time.Millisecond) // Wait for the goroutine to listen to the signal
+		require.NoError(t, proc.Signal(syscall.SIGHUP))
+		select {
+		case err := <-errors:
+			assert.Error(t, err)
+		case <-time.After(time.Second):
+			assert.Fail(t, "timeout")
+		}
+
+		updateFile(filesystem, "config.json", `{"server": {"port": 8090}}`)
+		require.NoError(t, proc.Signal(syscall.SIGHUP))
+		select {
+		case c := <-changed:
+			assert.Equal(t, "server.port", c.Key)
+		case <-time.After(time.Second):
+			assert.Fail(t, "timeout")
+		}
+		cancel()
+		wg.Wait()
+	})
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"goyave.dev/goyave/v5/util/errors"
+)
+
+// JSONSchemaVersion the JSON Schema dialect used by `JSONSchema()`.
+const JSONSchemaVersion = "https://json-schema.org/draft/2020-12/schema"
+
+// placeholderPattern matches env variable placeholders ("${VAR}"), which are accepted
+// for entries of any type.
+const placeholderPattern = `^\$\{.+\}$`
+
+// referencePattern matches env variable placeholders ("${VAR}") and secret references
+// ("scheme://reference"), which are accepted for secret entries of any type.
+const referencePattern = `^(\$\{.+\}|[a-zA-Z][a-zA-Z0-9+.-]*://.+)$`
+
+// SetStrict enables or disables the strict mode. In strict mode, loading a configuration
+// containing entries that are not registered (using `Register()` or the defaults)
+// results in an error instead of creating a new entry. This helps detecting typos such as
+// "server.readTimout".
+//
+// The strict mode only applies to loading. `Config.Set()` can still create new entries.
+// It is disabled by default.
+func SetStrict(strict bool) {
+	defaultLoader.setStrict(strict)
+}
+
+func (l *loader) setStrict(strict bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.strict = strict
+}
+
+// unknownEntries returns an error listing all the entries of the given config that
+// are not registered in the given defaults, along with the source that provided them.
+func (l *loader) unknownEntries(cfg *Config) error {
+	values := map[string]any{}
+	flatten(cfg.config, "", values)
+	defaults := &Config{config: l.defaults}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if defaults.getEntry(key) == nil {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	slices.Sort(keys)
+
+	message := ""
+	for _, key := range keys {
+		message += fmt.Sprintf("\n\t- %q is not a registered entry (%s)", key, cfg.Source(key))
+	}
+	return fmt.Errorf("%s", message)
+}
+
+// JSONSchema exports the schema of all the registered entries as a JSON Schema (draft 2020-12).
+// This schema can be used by editors and CI tools to validate config files.
+//
+// For each entry, the schema describes its type, its authorized values and its
+// default value. Env variable placeholders ("${VAR}") are accepted for entries of
+// all types, and secret references ("scheme://reference") for secret entries.
+// Entries that are required and don't have a default value are marked as required.
+// If the strict mode is enabled, additional properties are not allowed.
+//
+//	schema, err := config.JSONSchema()
+//	if err != nil {
+//		panic(err)
+//	}
+//	os.WriteFile("config.schema.json", schema, 0644)
+func JSONSchema() ([]byte, error) {
+	schema := defaultLoader.jsonSchema()
+	b, err := json.MarshalIndent(schema, "", "  ")
+	return b, errors.New(err)
+}
+
+func (l *loader) jsonSchema() map[string]any {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	schema := l.defaults.jsonSchema("", l.secrets, l.strict)
+	schema["$schema"] = JSONSchemaVersion
+	schema["title"] = "Goyave configuration"
+	return schema
+}
+
+func (o object) jsonSchema(prefix string, secrets map[string]struct{}, strict bool) map[string]any {
+	properties := make(map[string]any, len(o))
+	required := []string{}
+	for k, v := range o {
+		switch val := v.(type) {
+		case object:
+			properties[k] = val.jsonSchema(prefix+k+".", secrets, strict)
+		case *Entry:
+			_, secret := secrets[prefix+k]
+			properties[k] = val.jsonSchema(secret)
+			if val.Required && val.Value == nil {
+				required = append(required, k)
+			}
+		}
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		slices.Sort(required)
+		schema["required"] = required
+	}
+	if strict {
+		schema["additionalProperties"] = false
+	}
+	return schema
+}
+
+func (e *Entry) jsonSchema(secret bool) map[string]any {
+	valueSchema := map[string]any{}
+	if t := jsonSchemaType(e.Type); t != "" {
+		valueSchema["type"] = t
+	}
+	if len(e.AuthorizedValues) > 0 {
+		valueSchema["enum"] = e.AuthorizedValues
+	}
+	if e.IsSlice {
+		valueSchema = map[string]any{
+			"type":  "array",
+			"items": valueSchema,
+		}
+	}
+
+	alternatives := []any{valueSchema}
+	if e.IsSlice || e.Type != reflect.String || len(e.AuthorizedValues) > 0 {
+		pattern := placeholderPattern
+		if secret {
+			pattern = referencePattern
+		}
+		alternatives = append(alternatives, map[string]any{"type": "string", "pattern": pattern})
+	}
+	if !e.Required {
+		alternatives = append(alternatives, map[string]any{"type": "null"})
+	}
+
+	schema := map[string]any{}
+	if len(alternatives) == 1 {
+		schema = valueSchema
+	} else {
+		schema["anyOf"] = alternatives
+	}
+	if e.Value != nil {
+		schema["default"] = e.Value
+	}
+	if secret {
+		schema["writeOnly"] = true
+	}
+	return schema
+}
+
+func jsonSchemaType(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrict(t *testing.T) {
+	l := &loader{
+		defaults: object{},
+	}
+	l.register("server.readTimeout", Entry{Value: 10, AuthorizedValues: []any{}, Type: reflect.Int})
+	l.register("server.host", Entry{Value: "127.0.0.1", AuthorizedValues: []any{}, Type: reflect.String})
+
+	cfg, err := l.loadJSON(`{"server": {"readTimout": 20}}`)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 20.0, cfg.Get("server.readTimout"), 0)
+
+	l.setStrict(true)
+	cfg, err = l.loadSources(
+		&Raw{SourceName: "base", Data: []byte(`{"server": {"readTimout": 20, "host": "0.0.0.0"}}`)},
+		&Raw{SourceName: "overlay", Data: []byte(`{"custom": {"entry": true}}`)},
+	)
+	assert.Nil(t, cfg)
+	require.Error(t, err)
+	assert.Equal(t, "Config error: \n\t- \"custom.entry\" is not a registered entry (overlay)\n\t- \"server.readTimout\" is not a registered entry (base)", err.Error())
+
+	cfg, err = l.loadJSON(`{"server": {"readTimeout": 20}}`)
+	require.NoError(t, err)
+	assert.Equal(t, 20, cfg.GetInt("server.readTimeout"))
+
+	// Set can still create new entries
+	cfg.Set("custom", "value")
+	assert.Equal(t, "value", cfg.Get("custom"))
+
+	l.setStrict(false)
+	_, err = l.loadJSON(`{"custom": "value"}`)
+	require.NoError(t, err)
+
+	SetStrict(true)
+	assert.True(t, defaultLoader.strict)
+	SetStrict(false)
+	assert.False(t, defaultLoader.strict)
+}
+
+func TestJSONSchema(t *testing.T) {
+	l := &loader{
+		defaults: object{},
+	}
+	l.register("app.name", Entry{Value: "goyave", AuthorizedValues: []any{}, Type: reflect.String, Required: true})
+	l.register("app.debug", Entry{Value: true, AuthorizedValues: []any{}, Type: reflect.Bool, Required: true})
+	l.register("server.port", Entry{Value: 8080, AuthorizedValues: []any{}, Type: reflect.Int, Required: true})
+	l.register("server.maxUploadSize", Entry{Value: 10.0, AuthorizedValues: []any{}, Type: reflect.Float64})
+	l.register("server.protocol", Entry{Value: "http", AuthorizedValues: []any{"http", "https"}, Type: reflect.String, Required: true})
+	l.register("server.hosts", Entry{Value: []string{"a"}, AuthorizedValues: []any{}, Type: reflect.String, IsSlice: true, Required: true})
+	l.register("auth.secret", Entry{Value: nil, AuthorizedValues: []any{}, Type: reflect.String, Required: true})
+	l.register("auth.pin", Entry{Value: nil, AuthorizedValues: []any{}, Type: reflect.Int})
+	l.registerSecret("auth.secret")
+	l.registerSecret("auth.pin")
+	l.register("any", Entry{Value: nil, AuthorizedValues: []any{}, Type: reflect.Interface})
+
+	placeholder := map[string]any{"type": "string", "pattern": placeholderPattern}
+	reference := map[string]any{"type": "string", "pattern": referencePattern}
+	null := map[string]any{"type": "null"}
+	expected := map[string]any{
+		"$schema": JSONSchemaVersion,
+		"title":   "Goyave configuration",
+		"type":    "object",
+		"properties": map[string]any{
+			"app": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name": map[string]any{"type": "string", "default": "goyave"},
+					"debug": map[string]any{
+						"anyOf":   []any{map[string]any{"type": "boolean"}, placeholder},
+						"default": true,
+					},
+				},
+			},
+			"server": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"port": map[string]any{
+						"anyOf":   []any{map[string]any{"type": "integer"}, placeholder},
+						"default": 8080,
+					},
+					"maxUploadSize": map[string]any{
+						"anyOf":   []any{map[string]any{"type": "number"}, placeholder, null},
+						"default": 10.0,
+					},
+					"protocol": map[string]any{
+						"anyOf":   []any{map[string]any{"type": "string", "enum": []any{"http", "https"}}, placeholder},
+						"default": "http",
+					},
+					"hosts": map[string]any{
+						"anyOf":   []any{map[string]any{"type": "array", "items": map[string]any{"type": "string"}}, placeholder},
+						"default": []string{"a"},
+					},
+				},
+			},
+			"auth": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"secret": map[string]any{"type": "string", "writeOnly": true},
+					"pin": map[string]any{
+						"anyOf":     []any{map[string]any{"type": "integer"}, reference, null},
+						"writeOnly": true,
+					},
+				},
+				"required": []string{"secret"},
+			},
+			"any": map[string]any{
+				"anyOf": []any{map[string]any{}, placeholder, null},
+			},
+		},
+	}
+	assert.Equal(t, expected, l.jsonSchema())
+
+	l.setStrict(true)
+	schema := l.jsonSchema()
+	assert.Equal(t, false, schema["additionalProperties"])
+	assert.Equal(t, false, schema["properties"].(map[string]any)["app"].(map[string]any)["additionalProperties"])
+
+	t.Run("JSONSchema", func(t *testing.T) {
+		b, err := JSONSchema()
+		require.NoError(t, err)
+		schema := map[string]any{}
+		require.NoError(t, json.Unmarshal(b, &schema))
+		assert.Equal(t, JSONSchemaVersion, schema["$schema"])
+		port := schema["properties"].(map[string]any)["server"].(map[string]any)["properties"].(map[string]any)["port"]
+		assert.Equal(t, map[string]any{
+			"anyOf":   []any{map[string]any{"type": "integer"}, map[string]any{"type": "string", "pattern": placeholderPattern}},
+			"default": 8080.0,
+		}, port)
+	})
+}
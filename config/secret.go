@@ -0,0 +1,255 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"goyave.dev/goyave/v5/util/errors"
+	"goyave.dev/goyave/v5/util/fsutil/osfs"
+)
+
+// placeholderRegex matches the env variable placeholders ("${VAR}") contained
+// in a secret reference.
+var placeholderRegex = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// Redacted the value replacing secret entries when the configuration
+// is printed or logged.
+const Redacted = "[REDACTED]"
+
+// SecretResolver resolves secret references. A secret reference is the string value of a
+// secret entry (see `RegisterSecret()`) of the form "scheme://reference". When loading
+// the configuration, such values are replaced
+// with the secret returned by the resolver registered for the scheme. The secret is then
+// converted to the type of the entry.
+type SecretResolver interface {
+	// ResolveSecret returns the secret identified by the given reference.
+	// The reference doesn't contain the scheme: for "file:///run/secrets/db_password",
+	// the reference is "/run/secrets/db_password".
+	ResolveSecret(reference string) (string, error)
+}
+
+// SecretResolverFunc an adapter allowing the use of ordinary functions as `SecretResolver`.
+type SecretResolverFunc func(reference string) (string, error)
+
+// ResolveSecret calls f(reference).
+func (f SecretResolverFunc) ResolveSecret(reference string) (string, error) {
+	return f(reference)
+}
+
+// FileSecretResolver resolves secret references by reading the referenced file.
+// Trailing line breaks are trimmed. This resolver is registered by default
+// for the "file" scheme: "file:///run/secrets/db_password" is resolved with the
+// content of "/run/secrets/db_password".
+type FileSecretResolver struct {
+	// FS the file system the secrets are read from. Defaults to the OS file system.
+	FS fs.FS
+}
+
+// ResolveSecret reads the file at the given path.
+func (r *FileSecretResolver) ResolveSecret(reference string) (string, error) {
+	filesystem := r.FS
+	if filesystem == nil {
+		filesystem = &osfs.FS{}
+	}
+	data, err := fs.ReadFile(filesystem, reference)
+	if err != nil {
+		return "", errors.New(err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// RegisterSecret marks the entry identified by the given key as secret. Like `Register()`,
+// it should be called in an "init()" function.
+//
+// Secret references (see `RegisterSecretResolver()`) are only resolved in secret entries.
+// Secret entries are redacted when the configuration is printed or logged.
+// The "database.password" entry is secret by default.
+func RegisterSecret(key string) {
+	defaultLoader.registerSecret(key)
+}
+
+func (l *loader) registerSecret(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.secrets == nil {
+		l.secrets = map[string]struct{}{}
+	}
+	l.secrets[key] = struct{}{}
+}
+
+// RegisterSecretResolver registers a resolver for the secret references using the given
+// scheme (e.g. "vault" for "vault://database/password"). If a resolver already exists for
+// this scheme, it is replaced. A resolver for the "file" scheme is registered by default.
+//
+// Secret references are only resolved in the entries marked as secret with `RegisterSecret()`,
+// when the configuration is loaded and before validation. The values of the other entries are
+// never interpreted as secret references.
+//
+// Env variable placeholders contained in the reference are expanded first, so
+// "file://${SECRETS_DIR}/db_password" reads the file in the directory given by the
+// "SECRETS_DIR" env variable. A value made of a single placeholder ("${DB_PASSWORD}")
+// is also expanded first and resolved if the env variable contains a secret reference.
+func RegisterSecretResolver(scheme string, resolver SecretResolver) {
+	defaultLoader.registerSecretResolver(scheme, resolver)
+}
+
+func (l *loader) registerSecretResolver(scheme string, resolver SecretResolver) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.secretResolvers == nil {
+		l.secretResolvers = map[string]SecretResolver{}
+	}
+	l.secretResolvers[scheme] = resolver
+}
+
+func (c *Config) resolveSecrets(resolvers map[string]SecretResolver) error {
+	keys := make([]string, 0, len(c.secrets))
+	for key := range c.secrets {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	message := ""
+	for _, key := range keys {
+		entry := c.getEntry(key)
+		if entry == nil {
+			continue
+		}
+		if err := entry.resolveSecret(key, resolvers); err != nil {
+			message += "\n\t- " + err.Error()
+		}
+	}
+
+	if message != "" {
+		return fmt.Errorf("%s", message)
+	}
+	return nil
+}
+
+// resolveSecret replaces the value of the entry with the secret it references, if
+// its value is a string starting with the scheme of one of the given resolvers.
+// Env variable placeholders are expanded before the reference is resolved. Values
+// that are not secret references are left untouched: their placeholders are expanded
+// during validation.
+// The secret value is never included in the returned errors.
+func (e *Entry) resolveSecret(key string, resolvers map[string]SecretResolver) error {
+	str, ok := e.Value.(string)
+	if !ok {
+		return nil
+	}
+	if strings.HasPrefix(str, "${") && strings.HasSuffix(str, "}") && !strings.Contains(str, "://") {
+		value, set := os.LookupEnv(str[2 : len(str)-1])
+		if !set {
+			return nil // Reported during validation
+		}
+		str = value
+	}
+	scheme, reference, ok := strings.Cut(str, "://")
+	if !ok {
+		return nil
+	}
+	resolver, ok := resolvers[scheme]
+	if !ok {
+		return nil
+	}
+	reference, err := expandPlaceholders(key, reference)
+	if err != nil {
+		return err
+	}
+
+	secret, err := resolver.ResolveSecret(reference)
+	if err != nil {
+		return errors.Errorf("%q could not be resolved from secret %q: %w", key, str, err)
+	}
+	value, err := e.convertString(key, "", secret)
+	if err != nil {
+		return errors.Errorf("%q could not be converted to %s from secret %q", key, e.Type, str)
+	}
+	e.Value = value
+	return nil
+}
+
+// expandPlaceholders replaces the env variable placeholders ("${VAR}") contained in
+// the given string with the value of the env variable. Returns an error if one of the
+// variables is not set.
+func expandPlaceholders(key, str string) (string, error) {
+	var err error
+	expanded := placeholderRegex.ReplaceAllStringFunc(str, func(placeholder string) string {
+		varName := placeholder[2 : len(placeholder)-1]
+		value, set := os.LookupEnv(varName)
+		if !set && err == nil {
+			err = errors.Errorf("%q: %q environment variable is not set", key, varName)
+		}
+		return value
+	})
+	return expanded, err
+}
+
+// IsSecret returns true if the given entry exists and is secret. Secret entries
+// are redacted when the configuration is printed or logged.
+func (c *Config) IsSecret(key string) bool {
+	_, ok := c.secrets[key]
+	return ok && c.getEntry(key) != nil
+}
+
+// String returns the configuration as indented JSON. The value of secret entries
+// is replaced with `Redacted`.
+func (c *Config) String() string {
+	b, err := json.MarshalIndent(c.redact(c.config, ""), "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%#v", err)
+	}
+	return string(b)
+}
+
+// LogValue implements `slog.LogValuer`. Categories are represented by groups and
+// the value of secret entries is replaced with `Redacted`.
+func (c *Config) LogValue() slog.Value {
+	return c.logValue(c.config, "")
+}
+
+func (c *Config) redact(category object, prefix string) map[string]any {
+	m := make(map[string]any, len(category))
+	for k, v := range category {
+		switch val := v.(type) {
+		case object:
+			m[k] = c.redact(val, prefix+k+".")
+		case *Entry:
+			if _, secret := c.secrets[prefix+k]; secret && val.Value != nil {
+				m[k] = Redacted
+			} else {
+				m[k] = val.Value
+			}
+		}
+	}
+	return m
+}
+
+func (c *Config) logValue(category object, prefix string) slog.Value {
+	keys := make([]string, 0, len(category))
+	for k := range category {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		switch val := category[k].(type) {
+		case object:
+			attrs = append(attrs, slog.Attr{Key: k, Value: c.logValue(val, prefix+k+".")})
+		case *Entry:
+			if _, secret := c.secrets[prefix+k]; secret && val.Value != nil {
+				attrs = append(attrs, slog.String(k, Redacted))
+			} else {
+				attrs = append(attrs, slog.Any(k, val.Value))
+			}
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecrets(t *testing.T) {
+	newLoader := func() *loader {
+		l := &loader{
+			defaults: object{},
+		}
+		l.register("database.password", Entry{Value: "", AuthorizedValues: []any{}, Type: reflect.String})
+		l.register("database.port", Entry{Value: 0, AuthorizedValues: []any{}, Type: reflect.Int})
+		l.register("database.name", Entry{Value: "", AuthorizedValues: []any{}, Type: reflect.String})
+		l.register("database.dsn", Entry{Value: "", AuthorizedValues: []any{}, Type: reflect.String})
+		l.register("hosts", Entry{Value: []string{}, AuthorizedValues: []any{}, Type: reflect.String, IsSlice: true})
+		l.registerSecret("database.password")
+		l.registerSecret("database.port")
+		l.registerSecret("database.name")
+		l.registerSecret("hosts")
+		l.registerSecret("notAnEntry")
+		l.registerSecretResolver("file", &FileSecretResolver{FS: fstest.MapFS{
+			"secrets/db_password": {Data: []byte("p4ssw0rd\n")},
+			"secrets/db_port":     {Data: []byte("5432")},
+			"secrets/hosts":       {Data: []byte("a,b\r\n")},
+		}})
+		l.registerSecretResolver("test", SecretResolverFunc(func(reference string) (string, error) {
+			if reference == "error" {
+				return "", fmt.Errorf("test error")
+			}
+			return "resolved " + reference, nil
+		}))
+		return l
+	}
+
+	t.Run("resolve", func(t *testing.T) {
+		l := newLoader()
+		cfg, err := l.loadJSON(`{
+			"database": {"password": "file://secrets/db_password", "port": "file://secrets/db_port", "name": "test://name", "dsn": "file:///var/app.db"},
+			"hosts": "file://secrets/hosts",
+			"url": "test://example.org"
+		}`)
+		require.NoError(t, err)
+
+		assert.Equal(t, "p4ssw0rd", cfg.GetString("database.password"))
+		assert.Equal(t, 5432, cfg.GetInt("database.port"))
+		assert.Equal(t, "resolved name", cfg.GetString("database.name"))
+		assert.Equal(t, []string{"a", "b"}, cfg.GetStringSlice("hosts"))
+		assert.Equal(t, "file:///var/app.db", cfg.GetString("database.dsn")) // Not secret, not resolved
+		assert.Equal(t, "test://example.org", cfg.GetString("url"))
+
+		assert.True(t, cfg.IsSecret("database.password"))
+		assert.True(t, cfg.IsSecret("database.port"))
+		assert.True(t, cfg.IsSecret("database.name"))
+		assert.True(t, cfg.IsSecret("hosts"))
+		assert.False(t, cfg.IsSecret("database.dsn"))
+		assert.False(t, cfg.IsSecret("url"))
+		assert.False(t, cfg.IsSecret("notAnEntry"))
+
+		// Defaults are not modified
+		assert.Equal(t, "", l.defaults["database"].(object)["name"].(*Entry).Value)
+	})
+
+	t.Run("RegisterSecret", func(t *testing.T) {
+		RegisterSecret("test.secret")
+		t.Cleanup(func() {
+			delete(defaultLoader.secrets, "test.secret")
+		})
+		assert.Contains(t, defaultLoader.secrets, "test.secret")
+		assert.Contains(t, defaultLoader.secrets, "database.password")
+
+		cfg := LoadDefault()
+		assert.True(t, cfg.IsSecret("database.password"))
+		assert.False(t, cfg.IsSecret("database.name"))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		l := newLoader()
+		cfg, err := l.loadJSON(`{"database": {"password": "file://secrets/missing", "port": "test://port", "name": "test://error"}}`)
+		assert.Nil(t, cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "\n\t- \"database.password\" could not be resolved from secret \"file://secrets/missing\": ")
+		assert.Contains(t, err.Error(), "\n\t- \"database.port\" could not be converted to int from secret \"test://port\"")
+		assert.NotContains(t, err.Error(), "resolved port")
+		assert.Contains(t, err.Error(), "\n\t- \"database.name\" could not be resolved from secret \"test://error\": test error")
+	})
+
+	t.Run("env_placeholders", func(t *testing.T) {
+		t.Setenv("TEST_SECRETS_DIR", "secrets")
+		t.Setenv("TEST_DB_PORT", "file://secrets/db_port")
+		t.Setenv("TEST_DB_NAME", "db")
+		l := newLoader()
+		cfg, err := l.loadJSON(`{
+			"database": {"password": "file://${TEST_SECRETS_DIR}/db_password", "port": "${TEST_DB_PORT}", "name": "${TEST_DB_NAME}"},
+			"url": "https://${TEST_UNSET_HOST}/path"
+		}`)
+		require.NoError(t, err)
+
+		assert.Equal(t, "p4ssw0rd", cfg.GetString("database.password"))
+		assert.Equal(t, 5432, cfg.GetInt("database.port"))
+		assert.Equal(t, "db", cfg.GetString("database.name"))
+		assert.Equal(t, "https://${TEST_UNSET_HOST}/path", cfg.GetString("url"))
+		assert.True(t, cfg.IsSecret("database.password"))
+		assert.True(t, cfg.IsSecret("database.port"))
+		assert.True(t, cfg.IsSecret("database.name"))
+
+		cfg, err = l.loadJSON(`{"database": {"password": "file://${TEST_UNSET_SECRETS_DIR}/db_password"}}`)
+		assert.Nil(t, cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "\n\t- \"database.password\": \"TEST_UNSET_SECRETS_DIR\" environment variable is not set")
+	})
+
+	t.Run("default_file_resolver", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "secret")
+		require.NoError(t, os.WriteFile(path, []byte("secret value\n"), 0o600))
+
+		cfg, err := LoadJSON(fmt.Sprintf(`{"database": {"password": "file://%s"}}`, filepath.ToSlash(path)))
+		require.NoError(t, err)
+		assert.Equal(t, "secret value", cfg.GetString("database.password"))
+	})
+
+	t.Run("redaction", func(t *testing.T) {
+		l := newLoader()
+		cfg, err := l.loadJSON(`{"database": {"port": "file://secrets/db_port", "name": "db", "dsn": "file:///var/app.db"}}`)
+		require.NoError(t, err)
+
+		expected := map[string]any{
+			"database": map[string]any{
+				"password": Redacted,
+				"port":     Redacted,
+				"name":     Redacted,
+				"dsn":      "file:///var/app.db",
+			},
+			"hosts": Redacted,
+		}
+		actual := map[string]any{}
+		require.NoError(t, json.Unmarshal([]byte(cfg.String()), &actual))
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, cfg.String(), fmt.Sprintf("%v", cfg))
+
+		buf := &bytes.Buffer{}
+		logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		}}))
+		logger.Info("config", "config", cfg)
+		assert.Equal(t, `{"level":"INFO","msg":"config","config":{"database":{"dsn":"file:///var/app.db","name":"[REDACTED]","password":"[REDACTED]","port":"[REDACTED]"},"hosts":"[REDACTED]"}}`+"\n", buf.String())
+	})
+}
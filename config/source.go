@@ -0,0 +1,345 @@
+package config
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+
+	"goyave.dev/goyave/v5/util/errors"
+	"goyave.dev/goyave/v5/util/fsutil/osfs"
+)
+
+// Names returned by `Config.Source()` for entries that were not provided by a `Source`.
+const (
+	// SourceDefault the entry has its default value.
+	SourceDefault = "default"
+
+	// SourceRuntime the entry was modified at runtime using `Config.Set()`.
+	SourceRuntime = "runtime"
+)
+
+// Source provides configuration values. Sources are merged in order by `LoadSources()`:
+// each source overrides the entries provided by the previous ones.
+type Source interface {
+	// Name identifies the source. It is returned by `Config.Source()` for
+	// the entries provided by this source.
+	Name() string
+
+	// Read returns the configuration provided by this source. Nested categories
+	// are represented by `map[string]any`. Returning a nil map is valid
+	// and means the source doesn't provide any entry.
+	//
+	// The given config contains the defaults merged with the previous sources.
+	// It is not validated yet. It can be used to resolve keys, for example when
+	// they are not case-sensitive.
+	Read(current *Config) (map[string]any, error)
+}
+
+// File source reading a config file. The decoder is selected using the file extension
+// (see `RegisterDecoder()`) unless `Decoder` is set. Files with an unknown extension
+// are decoded as JSON.
+type File struct {
+	// FS the file system the file is read from. Defaults to the OS file system.
+	FS fs.FS
+
+	// Decoder overrides the decoder selected from the file extension.
+	Decoder Decoder
+
+	// Path of the config file.
+	Path string
+
+	// Optional if true, a missing file is not considered an error.
+	// Useful for environment-specific overlays.
+	Optional bool
+}
+
+// Name returns the path of the file.
+func (f *File) Name() string {
+	return f.Path
+}
+
+// Read the config file.
+func (f *File) Read(_ *Config) (map[string]any, error) {
+	data, err := fs.ReadFile(f.filesystem(), f.Path)
+	if err != nil {
+		if f.Optional && stderrors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errors.New(err)
+	}
+	decoder := f.Decoder
+	if decoder == nil {
+		decoder = getDecoder(f.Path)
+	}
+	return decode(decoder, data)
+}
+
+func (f *File) filesystem() fs.FS {
+	if f.FS == nil {
+		return &osfs.FS{}
+	}
+	return f.FS
+}
+
+// Directory source reading all the config fragments matching a pattern inside a directory.
+// The fragments are merged in lexical order of their file name. The decoder of each
+// fragment is selected using its extension.
+//
+// A missing directory is not considered an error.
+type Directory struct {
+	// FS the file system the fragments are read from. Defaults to the OS file system.
+	FS fs.FS
+
+	// Path of the directory.
+	Path string
+
+	// Pattern the fragments file name must match (see `path.Match()`).
+	// Defaults to "*.json".
+	Pattern string
+}
+
+// Name returns the path of the directory.
+func (d *Directory) Name() string {
+	return d.Path
+}
+
+// Read and merge all the fragments matching the pattern.
+func (d *Directory) Read(current *Config) (map[string]any, error) {
+	filesystem, files, err := d.files()
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]any{}
+	for _, file := range files {
+		conf, err := (&File{FS: filesystem, Path: file}).Read(current)
+		if err != nil {
+			return nil, err
+		}
+		merge(result, conf)
+	}
+	return result, nil
+}
+
+// files returns the file system and the path of the fragments, sorted by name.
+func (d *Directory) files() (fs.FS, []string, error) {
+	filesystem := d.FS
+	if filesystem == nil {
+		filesystem = &osfs.FS{}
+	}
+	pattern := d.Pattern
+	if pattern == "" {
+		pattern = "*.json"
+	}
+	files, err := fs.Glob(filesystem, path.Join(d.Path, pattern))
+	return filesystem, files, errors.New(err)
+}
+
+// Env source reading environment variables starting with a prefix.
+// The rest of the variable name is the path to the config entry, with
+// each level separated by an underscore. Matching is case-insensitive:
+// `GOYAVE_SERVER_MAXUPLOADSIZE` overrides "server.maxUploadSize" if the
+// prefix is "GOYAVE_".
+//
+// Only existing entries (registered or provided by a previous source) can be overridden.
+// Variables that don't match an entry are ignored. Values are converted to the type
+// of the entry they override. Slice entries accept a JSON array (`["a","b"]`) or a list
+// of comma-separated values (`a,b`).
+type Env struct {
+	// Prefix the environment variables must start with, e.g. "GOYAVE_".
+	Prefix string
+}
+
+// Name returns "env:" followed by the prefix.
+func (e *Env) Name() string {
+	return "env:" + e.Prefix
+}
+
+// Read the environment variables matching existing entries.
+func (e *Env) Read(current *Config) (map[string]any, error) {
+	result := map[string]any{}
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		if !strings.HasPrefix(name, e.Prefix) || len(name) == len(e.Prefix) {
+			continue
+		}
+		key, entry := lookupEntry(current.config, strings.Split(name[len(e.Prefix):], "_"), "_")
+		if entry == nil {
+			continue
+		}
+		val, err := entry.convertString(key, fmt.Sprintf("environment variable %q", name), value)
+		if err != nil {
+			return nil, err
+		}
+		setPath(result, key, val)
+	}
+	return result, nil
+}
+
+// Flags source reading command-line arguments of the form "--key=value" or "--key value",
+// where "key" is the dot-separated path to the config entry (e.g. "--server.port=8081").
+// Matching is case-insensitive. The value can be omitted for bool entries ("--app.debug").
+// A single leading dash is also accepted. Slice entries accept a JSON array or a list
+// of comma-separated values ("--key=a,b").
+//
+// Only existing entries (registered or provided by a previous source) can be overridden.
+// Arguments that don't match an entry are ignored. Parsing stops after the "--" terminator.
+type Flags struct {
+	// Args the command-line arguments, without the program name. Defaults to `os.Args[1:]`.
+	Args []string
+}
+
+// Name returns "flags".
+func (f *Flags) Name() string {
+	return "flags"
+}
+
+// Read the command-line arguments matching existing entries.
+func (f *Flags) Read(current *Config) (map[string]any, error) {
+	args := f.Args
+	if args == nil && len(os.Args) > 0 {
+		args = os.Args[1:]
+	}
+
+	result := map[string]any{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(arg[1:], "-"), "=")
+		key, entry := lookupEntry(current.config, strings.Split(name, "."), ".")
+		if entry == nil {
+			continue
+		}
+		if !hasValue {
+			switch {
+			case entry.Type == reflect.Bool && !entry.IsSlice:
+				value = "true"
+			case i+1 < len(args):
+				i++
+				value = args[i]
+			default:
+				return nil, errors.Errorf("flag %q needs a value", arg)
+			}
+		}
+		val, err := entry.convertString(key, fmt.Sprintf("flag %q", name), value)
+		if err != nil {
+			return nil, err
+		}
+		setPath(result, key, val)
+	}
+	return result, nil
+}
+
+// Raw source decoding the given data. Can be used in combination with
+// Go's embed directive.
+type Raw struct {
+	// Decoder used to decode the data. Defaults to `DecodeJSON`.
+	Decoder Decoder
+
+	// SourceName the name of the source returned by `Name()`. Defaults to "raw".
+	SourceName string
+
+	// Data the raw configuration.
+	Data []byte
+}
+
+// Name returns the source name, or "raw" if not set.
+func (r *Raw) Name() string {
+	if r.SourceName == "" {
+		return "raw"
+	}
+	return r.SourceName
+}
+
+// Read decodes the data.
+func (r *Raw) Read(_ *Config) (map[string]any, error) {
+	decoder := r.Decoder
+	if decoder == nil {
+		decoder = DecodeJSON
+	}
+	return decode(decoder, r.Data)
+}
+
+// lookupEntry finds the entry matching the given key segments, ignoring case.
+// Consecutive segments joined with the separator can also match a single key
+// containing this separator. Returns the actual dot-separated path of the entry,
+// or nil if there is no matching entry.
+func lookupEntry(category object, segments []string, separator string) (string, *Entry) {
+	for i := 1; i <= len(segments); i++ {
+		name := strings.Join(segments[:i], separator)
+		for k, v := range category {
+			if !strings.EqualFold(k, name) {
+				continue
+			}
+			switch val := v.(type) {
+			case object:
+				if i < len(segments) {
+					if key, entry := lookupEntry(val, segments[i:], separator); entry != nil {
+						return k + "." + key, entry
+					}
+				}
+			case *Entry:
+				if i == len(segments) {
+					return k, val
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
+// setPath sets the value at the given dot-separated path, creating
+// the missing categories.
+func setPath(m map[string]any, key string, value any) {
+	segments := strings.Split(key, ".")
+	for _, segment := range segments[:len(segments)-1] {
+		sub, ok := m[segment].(map[string]any)
+		if !ok {
+			sub = map[string]any{}
+			m[segment] = sub
+		}
+		m = sub
+	}
+	m[segments[len(segments)-1]] = value
+}
+
+// merge deeply merges src into dst. Values from src take precedence.
+func merge(dst, src map[string]any) {
+	for k, v := range src {
+		if obj, ok := v.(map[string]any); ok {
+			if dstObj, ok := dst[k].(map[string]any); ok {
+				merge(dstObj, obj)
+				continue
+			}
+			copied := make(map[string]any, len(obj))
+			merge(copied, obj)
+			dst[k] = copied
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// recordSources associates all the entries of the given config with the source name.
+func recordSources(sources map[string]string, prefix string, conf map[string]any, name string) {
+	for k, v := range conf {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if obj, ok := v.(map[string]any); ok {
+			recordSources(sources, key, obj, name)
+			continue
+		}
+		sources[key] = name
+	}
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSources(t *testing.T) {
+	filesystem := fstest.MapFS{
+		"config.json":            {Data: []byte(`{"app": {"name": "base"}, "server": {"port": 8081, "host": "0.0.0.0"}, "custom": {"snake_case": 1}}`)},
+		"config.production.yml":  {Data: []byte("app:\n  name: production\nserver:\n  port: 'not an int'")},
+		"config.d/10-db.json":    {Data: []byte(`{"database": {"connection": "postgres", "port": 5432}}`)},
+		"config.d/20-db.toml":    {Data: []byte(`database.port = 5433`)},
+		"config.d/30-cache.json": {Data: []byte(`{"cache": {"ttl": 60}}`)},
+		"config.d/ignored.yml":   {Data: []byte(`invalid: [`)},
+		"config.invalid.json":    {Data: []byte(`{"unclosed":`)},
+	}
+
+	t.Run("LoadSources", func(t *testing.T) {
+		t.Setenv("GOYAVE_SERVER_HOST", "127.0.0.2")
+		t.Setenv("GOYAVE_SERVER_MAXUPLOADSIZE", "20.5")
+		t.Setenv("GOYAVE_CUSTOM_SNAKE_CASE", "2")
+		t.Setenv("GOYAVE_ENV", "production") // Doesn't match any entry
+		t.Setenv("GOYAVE_UNKNOWN", "value")
+
+		cfg, err := LoadSources(
+			&File{FS: filesystem, Path: "config.json"},
+			&File{FS: filesystem, Path: "config.production.yml", Optional: true},
+			&File{FS: filesystem, Path: "config.staging.json", Optional: true},
+			&Directory{FS: filesystem, Path: "config.d", Pattern: "*.*son"},
+			&Env{Prefix: "GOYAVE_"},
+			&Flags{Args: []string{"serve", "--server.port=8082", "-app.debug", "--database.NAME", "db", "--unknown", "--", "--app.name=ignored"}},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "production", cfg.GetString("app.name"))
+		assert.Equal(t, "config.production.yml", cfg.Source("app.name"))
+
+		assert.Equal(t, 8082, cfg.GetInt("server.port"))
+		assert.Equal(t, "flags", cfg.Source("server.port"))
+
+		assert.Equal(t, "127.0.0.2", cfg.GetString("server.host"))
+		assert.Equal(t, "env:GOYAVE_", cfg.Source("server.host"))
+		assert.InEpsilon(t, 20.5, cfg.GetFloat("server.maxUploadSize"), 0)
+		assert.InEpsilon(t, 2.0, cfg.GetFloat("custom.snake_case"), 0) // Unregistered numbers are float64
+		assert.False(t, cfg.Has("env"))
+		assert.False(t, cfg.Has("unknown"))
+
+		assert.True(t, cfg.GetBool("app.debug"))
+		assert.Equal(t, "db", cfg.GetString("database.name"))
+		assert.Equal(t, "flags", cfg.Source("database.name"))
+
+		assert.Equal(t, "postgres", cfg.GetString("database.connection"))
+		assert.Equal(t, 5432, cfg.GetInt("database.port")) // The toml fragment doesn't match the pattern
+		assert.Equal(t, "config.d", cfg.Source("database.port"))
+		assert.InEpsilon(t, 60.0, cfg.GetFloat("cache.ttl"), 0)
+
+		assert.Equal(t, SourceDefault, cfg.Source("server.readTimeout"))
+		assert.Equal(t, "", cfg.Source("server"))
+		assert.Equal(t, "", cfg.Source("notAnEntry"))
+
+		cfg.Set("server.readTimeout", 5)
+		assert.Equal(t, SourceRuntime, cfg.Source("server.readTimeout"))
+	})
+
+	t.Run("validated_once", func(t *testing.T) {
+		// The invalid value from the overlay is overridden by a following source.
+		cfg, err := LoadSources(
+			&File{FS: filesystem, Path: "config.production.yml"},
+			&Raw{SourceName: "embedded", Data: []byte(`{"server": {"port": 1234}}`)},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 1234, cfg.GetInt("server.port"))
+		assert.Equal(t, "embedded", cfg.Source("server.port"))
+
+		cfg, err = LoadSources(&File{FS: filesystem, Path: "config.production.yml"})
+		assert.Nil(t, cfg)
+		require.Error(t, err)
+		assert.Equal(t, "Config error: \n\t- \"server.port\" type must be int", err.Error())
+	})
+
+	t.Run("no_sources", func(t *testing.T) {
+		cfg, err := LoadSources()
+		require.NoError(t, err)
+		assert.Equal(t, defaultLoader.defaults, cfg.config)
+		assert.Equal(t, SourceDefault, cfg.Source("app.name"))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		cases := map[string]Source{
+			"missing_file":    &File{FS: filesystem, Path: "config.staging.json"},
+			"invalid_file":    &File{FS: filesystem, Path: "config.invalid.json"},
+			"invalid_pattern": &Directory{FS: filesystem, Path: "config.d", Pattern: "["},
+			"invalid_dir":     &Directory{FS: filesystem, Path: "config.d", Pattern: "*.yml"},
+			"flag_no_value":   &Flags{Args: []string{"--server.port"}},
+			"flag_invalid":    &Flags{Args: []string{"--server.port=abc"}},
+		}
+		for name, source := range cases {
+			t.Run(name, func(t *testing.T) {
+				cfg, err := LoadSources(source)
+				assert.Nil(t, cfg)
+				require.Error(t, err)
+			})
+		}
+
+		t.Run("env_invalid", func(t *testing.T) {
+			t.Setenv("TEST_SOURCE_SERVER_PORT", "abc")
+			cfg, err := LoadSources(&Env{Prefix: "TEST_SOURCE_"})
+			assert.Nil(t, cfg)
+			require.Error(t, err)
+			assert.Equal(t, "Config error: \"server.port\" could not be converted to int from environment variable \"TEST_SOURCE_SERVER_PORT\" of value \"abc\"", err.Error())
+		})
+
+	})
+
+	t.Run("slices", func(t *testing.T) {
+		loader := loader{
+			defaults: object{},
+		}
+		loader.register("strings", Entry{Value: []string{}, AuthorizedValues: []any{}, Type: reflect.String, IsSlice: true})
+		loader.register("floats", Entry{Value: []float64{}, AuthorizedValues: []any{}, Type: reflect.Float64, IsSlice: true})
+		loader.register("bools", Entry{Value: []bool{}, AuthorizedValues: []any{}, Type: reflect.Bool, IsSlice: true})
+
+		t.Setenv("TEST_SOURCE_STRINGS", "a, b")
+		cfg, err := loader.loadSources(
+			&Env{Prefix: "TEST_SOURCE_"},
+			&Flags{Args: []string{"--floats", "1.5,2", "--bools", "[true]"}},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, cfg.GetStringSlice("strings"))
+		assert.Equal(t, []float64{1.5, 2}, cfg.GetFloatSlice("floats"))
+		assert.Equal(t, []bool{true}, cfg.GetBoolSlice("bools"))
+
+		// Unregistered slices
+		cfg, err = LoadSources(&Raw{Data: []byte(`{"slice": ["a"]}`)}, &Flags{Args: []string{`--slice=["b",1]`}})
+		require.NoError(t, err)
+		assert.Equal(t, []any{"b", 1.0}, cfg.Get("slice"))
+	})
+
+	t.Run("Name", func(t *testing.T) {
+		assert.Equal(t, "config.json", (&File{Path: "config.json"}).Name())
+		assert.Equal(t, "config.d", (&Directory{Path: "config.d"}).Name())
+		assert.Equal(t, "env:APP_", (&Env{Prefix: "APP_"}).Name())
+		assert.Equal(t, "flags", (&Flags{}).Name())
+		assert.Equal(t, "raw", (&Raw{}).Name())
+	})
+
+	t.Run("Flags_default_args", func(t *testing.T) {
+		conf, err := (&Flags{}).Read(LoadDefault())
+		require.NoError(t, err)
+		assert.Empty(t, conf)
+	})
+
+	t.Run("lookupEntry", func(t *testing.T) {
+		category := object{
+			"server": object{
+				"maxUploadSize": &Entry{Value: 10.0, Type: reflect.Float64},
+				"snake_case":    object{"entry": &Entry{Value: 1, Type: reflect.Int}},
+			},
+		}
+		key, entry := lookupEntry(category, []string{"SERVER", "MAXUPLOADSIZE"}, "_")
+		assert.Equal(t, "server.maxUploadSize", key)
+		assert.Same(t, category["server"].(object)["maxUploadSize"], entry)
+
+		key, entry = lookupEntry(category, []string{"server", "snake", "case", "entry"}, "_")
+		assert.Equal(t, "server.snake_case.entry", key)
+		assert.NotNil(t, entry)
+
+		_, entry = lookupEntry(category, []string{"server"}, "_")
+		assert.Nil(t, entry)
+		_, entry = lookupEntry(category, []string{"server", "maxUploadSize", "sub"}, ".")
+		assert.Nil(t, entry)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		dst := map[string]any{"a": map[string]any{"b": 1, "c": 2}, "d": 3}
+		merge(dst, map[string]any{"a": map[string]any{"b": 4}, "d": map[string]any{"e": 5}})
+		assert.Equal(t, map[string]any{"a": map[string]any{"b": 4, "c": 2}, "d": map[string]any{"e": 5}}, dst)
+	})
+}
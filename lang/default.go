@@ -10,6 +10,15 @@ var enUS = &Language{
 		"auth.jwt-invalid":             "Your authentication token is invalid.",
 		"auth.jwt-not-valid-yet":       "Your authentication token is not valid yet.",
 		"auth.jwt-expired":             "Your authentication token is expired.",
+		"auth.jwt-revoked":             "Your authentication token has been revoked.",
+		"auth.jwt-invalid-issuer":      "Your authentication token was not issued by a trusted issuer.",
+		"auth.jwt-invalid-audience":    "Your authentication token is not intended for this application.",
+		"auth.jwt-insufficient-scope":  "Your authentication token does not grant the required scopes.",
+		"auth.refresh-token-invalid":   "Your refresh token is invalid or expired.",
+		"auth.api-key-expired":         "Your API key is expired.",
+		"format.date":                  "01/02/2006",
+		"format.time":                  "3:04 PM",
+		"format.datetime":              "01/02/2006 3:04 PM",
 	},
 	validation: validationLines{
 		rules: map[string]string{
@@ -43,33 +52,33 @@ var enUS = &Language{
 			"string.element":                     "The :field elements must be strings.",
 			"array":                              "The :field must be an array.",
 			"array.element":                      "The :field elements must be arrays.",
-			"min.string":                         "The :field must be at least :min characters.",
+			"min.string":                         "The :field must be at least {min, plural, one {:min character} other {:min characters}}.",
 			"min.numeric":                        "The :field must be at least :min.",
-			"min.array":                          "The :field must have at least :min items.",
+			"min.array":                          "The :field must have at least {min, plural, one {:min item} other {:min items}}.",
 			"min.file":                           "The :field must be at least :min KiB.",
-			"min.object":                         "The :field must have at least :min fields.",
-			"min.string.element":                 "The :field elements must be at least :min characters.",
+			"min.object":                         "The :field must have at least {min, plural, one {:min field} other {:min fields}}.",
+			"min.string.element":                 "The :field elements must be at least {min, plural, one {:min character} other {:min characters}}.",
 			"min.numeric.element":                "The :field elements must be at least :min.",
-			"min.array.element":                  "The :field elements must have at least :min items.",
-			"min.object.element":                 "The :field elements must have at least :min fields.",
-			"max.string":                         "The :field may not have more than :max characters.",
+			"min.array.element":                  "The :field elements must have at least {min, plural, one {:min item} other {:min items}}.",
+			"min.object.element":                 "The :field elements must have at least {min, plural, one {:min field} other {:min fields}}.",
+			"max.string":                         "The :field may not have more than {max, plural, one {:max character} other {:max characters}}.",
 			"max.numeric":                        "The :field may not be greater than :max.",
-			"max.array":                          "The :field may not have more than :max items.",
+			"max.array":                          "The :field may not have more than {max, plural, one {:max item} other {:max items}}.",
 			"max.file":                           "The :field may not be greater than :max KiB.",
-			"max.object":                         "The :field may not have more than :max fields.",
-			"max.string.element":                 "The :field elements may not have more than :max characters.",
+			"max.object":                         "The :field may not have more than {max, plural, one {:max field} other {:max fields}}.",
+			"max.string.element":                 "The :field elements may not have more than {max, plural, one {:max character} other {:max characters}}.",
 			"max.numeric.element":                "The :field elements may not be greater than :max.",
-			"max.array.element":                  "The :field elements may not have more than :max items.",
-			"max.object.element":                 "The :field elements may not have more than :max fields.",
-			"between.string":                     "The :field must be between :min and :max characters.",
+			"max.array.element":                  "The :field elements may not have more than {max, plural, one {:max item} other {:max items}}.",
+			"max.object.element":                 "The :field elements may not have more than {max, plural, one {:max field} other {:max fields}}.",
+			"between.string":                     "The :field must be between :min and {max, plural, one {:max character} other {:max characters}}.",
 			"between.numeric":                    "The :field must be between :min and :max.",
-			"between.array":                      "The :field must have between :min and :max items.",
-			"between.object":                     "The :field must have between :min and :max fields.",
+			"between.array":                      "The :field must have between :min and {max, plural, one {:max item} other {:max items}}.",
+			"between.object":                     "The :field must have between :min and {max, plural, one {:max field} other {:max fields}}.",
 			"between.file":                       "The :field must be between :min and :max KiB.",
-			"between.string.element":             "The :field elements must be between :min and :max characters.",
+			"between.string.element":             "The :field elements must be between :min and {max, plural, one {:max character} other {:max characters}}.",
 			"between.numeric.element":            "The :field elements must be between :min and :max.",
-			"between.array.element":              "The :field elements must have between :min and :max items.",
-			"between.object.element":             "The :field elements must have between :min and :max fields.",
+			"between.array.element":              "The :field elements must have between :min and {max, plural, one {:max item} other {:max items}}.",
+			"between.object.element":             "The :field elements must have between :min and {max, plural, one {:max field} other {:max fields}}.",
 			"greater_than.string":                "The :field must be longer than the :other.",
 			"greater_than.numeric":               "The :field must be greater than the :other.",
 			"greater_than.array":                 "The :field must have more items than the :other.",
@@ -114,15 +123,15 @@ var enUS = &Language{
 			"regex.element":                      "The :field element format is invalid.",
 			"email":                              "The :field must be a valid email address.",
 			"email.element":                      "The :field elements must be valid email addresses.",
-			"size.string":                        "The :field must be exactly :value characters-long.",
+			"size.string":                        "The :field must be exactly {value, plural, one {:value character-long} other {:value characters-long}}.",
 			"size.numeric":                       "The :field must be exactly :value.",
-			"size.array":                         "The :field must contain exactly :value items.",
+			"size.array":                         "The :field must contain exactly {value, plural, one {:value item} other {:value items}}.",
 			"size.file":                          "The :field must be exactly :value KiB.",
-			"size.object":                        "The :field must have exactly :value fields.",
-			"size.string.element":                "The :field elements must be exactly :value characters-long.",
+			"size.object":                        "The :field must have exactly {value, plural, one {:value field} other {:value fields}}.",
+			"size.string.element":                "The :field elements must be exactly {value, plural, one {:value character-long} other {:value characters-long}}.",
 			"size.numeric.element":               "The :field elements must be exactly :value.",
-			"size.array.element":                 "The :field elements must contain exactly :value items.",
-			"size.object.element":                "The :field elements must have exactly :value fields.",
+			"size.array.element":                 "The :field elements must contain exactly {value, plural, one {:value item} other {:value items}}.",
+			"size.object.element":                "The :field elements must have exactly {value, plural, one {:value field} other {:value fields}}.",
 			"alpha":                              "The :field may only contain letters.",
 			"alpha.element":                      "The :field elements may only contain letters.",
 			"alpha_dash":                         "The :field may only contain letters, numbers, dashes and underscores.",
@@ -167,9 +176,9 @@ var enUS = &Language{
 			"mime":                               "The :field must be a file of type: :values.",
 			"image":                              "The :field must be an image.",
 			"extension":                          "The :field must be a file with one of the following extensions: :values.",
-			"file_count":                         "The :field must have exactly :value file(s).",
-			"min_file_count":                     "The :field must have at least :value file(s).",
-			"max_file_count":                     "The :field may not have more than :value file(s).",
+			"file_count":                         "The :field must have exactly {value, plural, one {:value file} other {:value files}}.",
+			"min_file_count":                     "The :field must have at least {value, plural, one {:value file} other {:value files}}.",
+			"max_file_count":                     "The :field may not have more than {value, plural, one {:value file} other {:value files}}.",
 			"file_count_between":                 "The :field must have between :min and :max files.",
 			"date":                               "The :field is not a valid date.",
 			"date.element":                       "The :field elements are not valid dates.",
@@ -193,6 +202,7 @@ var enUS = &Language{
 			"keysin.element":                     "The :field elements keys must be one of the following: :values.",
 			"doesnt_end_with":                    "The :field must not end with any of the following values: :values.",
 			"doesnt_end_with.element":            "The :field elements must not end with any of the following values: :values.",
+			"bind":                               "The :field has an invalid type.",
 		},
 		fields: map[string]string{
 			"":        "body",
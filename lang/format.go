@@ -0,0 +1,334 @@
+package lang
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// Reserved lines defining the layouts used to format dates and times. The value
+// of these lines is a Go time layout (see `time.Layout`).
+const (
+	LineDateFormat     = "format.date"
+	LineTimeFormat     = "format.time"
+	LineDateTimeFormat = "format.datetime"
+)
+
+var pluralCategories = map[plural.Form]string{
+	plural.Other: "other",
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+}
+
+// Tag returns the BCP 47 language tag matching the name of the language.
+// If the name is not a valid tag, returns `language.Und`, for which the plural
+// category is always "other" and numbers use the default format.
+func (l *Language) Tag() language.Tag {
+	tag, err := language.Parse(l.name)
+	if err != nil {
+		return language.Und
+	}
+	return tag
+}
+
+// GetPlural a language line containing plural forms, selected using the given count.
+// Apart from the count, it behaves exactly like `Get()`.
+//
+// The count is available in the line as the "count" argument of ICU-style plural expressions,
+// and as the ":count" placeholder, formatted according to the language. If the ":count"
+// placeholder is given explicitly, it takes precedence.
+//
+// The count should be an integer or a float. For any other type, the "other" form is used.
+//
+//	// "items.count": "{count, plural, =0 {No item} one {# item} other {# items}}"
+//	lang.GetPlural("items.count", len(items))
+func (l *Language) GetPlural(line string, count any, placeholders ...string) string {
+	message := l.getLine(line)
+	if message == "" {
+		l.reportMissingLine(line)
+		return line
+	}
+	values := make([]string, 0, len(placeholders)+2)
+	values = append(values, placeholders...)
+	message = l.formatMessage(message, append(values, ":count", formatOperand(count)), "")
+	return processPlaceholders(message, append(values, ":count", l.FormatNumber(count)))
+}
+
+// PluralCategory returns the CLDR cardinal plural category ("zero", "one", "two",
+// "few", "many" or "other") of the given number for this language.
+func (l *Language) PluralCategory(n any) string {
+	return l.pluralCategory(plural.Cardinal, formatOperand(n))
+}
+
+// FormatNumber formats the given number (integer or float) using the decimal format
+// of the language. For example, 1234.5 is formatted as "1,234.5" in "en-US" and as
+// "1 234,5" in "fr-FR". Other types are formatted using their default format.
+func (l *Language) FormatNumber(n any) string {
+	return message.NewPrinter(l.Tag()).Sprint(number.Decimal(n))
+}
+
+// FormatDate formats the date part of the given time using the layout defined by
+// the "format.date" line of the language.
+func (l *Language) FormatDate(t time.Time) string {
+	return t.Format(l.layout(LineDateFormat))
+}
+
+// FormatTime formats the time part of the given time using the layout defined by
+// the "format.time" line of the language.
+func (l *Language) FormatTime(t time.Time) string {
+	return t.Format(l.layout(LineTimeFormat))
+}
+
+// FormatDateTime formats the given time using the layout defined by
+// the "format.datetime" line of the language.
+func (l *Language) FormatDateTime(t time.Time) string {
+	return t.Format(l.layout(LineDateTimeFormat))
+}
+
+func (l *Language) layout(line string) string {
+	if layout := l.getLine(line); layout != "" {
+		return layout
+	}
+	return enUS.lines[line]
+}
+
+// formatMessage evaluates the ICU-style arguments contained in the given message:
+//   - "{name, number}": the argument formatted as a number
+//   - "{name, date}", "{name, time}", "{name, datetime}": the argument (RFC 3339) formatted as a date
+//   - "{name, plural, =0 {...} one {...} other {...}}": select a message using the cardinal plural category
+//   - "{name, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}": same using the ordinal plural category
+//   - "{name, select, male {...} other {...}}": select a message using the argument's value
+//
+// The value of an argument is the replacement of the ":name" placeholder. In plural messages, "#"
+// is replaced with the formatted number. Expressions that cannot be parsed are left untouched.
+func (l *Language) formatMessage(message string, placeholders []string, pound string) string {
+	if !strings.ContainsAny(message, "{#") {
+		return message
+	}
+	builder := strings.Builder{}
+	for i := 0; i < len(message); i++ {
+		switch message[i] {
+		case '{':
+			if result, length, ok := l.formatArgument(message[i:], placeholders, pound); ok {
+				builder.WriteString(result)
+				i += length - 1
+				continue
+			}
+		case '#':
+			if pound != "" {
+				builder.WriteString(pound)
+				continue
+			}
+		}
+		builder.WriteByte(message[i])
+	}
+	return builder.String()
+}
+
+// formatArgument parses and evaluates the argument at the start of the given message.
+// Returns the result, the length of the expression and false if the expression could not be parsed.
+func (l *Language) formatArgument(message string, placeholders []string, pound string) (string, int, bool) {
+	end := matchingBrace(message)
+	if end == -1 {
+		return "", 0, false
+	}
+	expression := message[1:end]
+	name, rest, ok := strings.Cut(expression, ",")
+	if !ok {
+		return "", 0, false
+	}
+	name = strings.TrimSpace(name)
+	if !isArgumentName(name) {
+		return "", 0, false
+	}
+	argType, rest, _ := strings.Cut(rest, ",")
+	argType = strings.TrimSpace(argType)
+	value, _ := lookupPlaceholder(placeholders, ":"+name)
+
+	var result string
+	switch argType {
+	case "number":
+		result = l.formatNumberString(value)
+	case "date", "time", "datetime":
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			result = value
+			break
+		}
+		result = t.Format(l.layout("format." + argType))
+	case "plural", "selectordinal", "select":
+		branches, ok := parseBranches(rest)
+		if !ok {
+			return "", 0, false
+		}
+		switch argType {
+		case "select":
+			result = l.formatMessage(selectBranch(branches, value), placeholders, pound)
+		case "plural":
+			result = l.formatMessage(l.pluralBranch(plural.Cardinal, branches, value), placeholders, l.formatNumberString(value))
+		default:
+			result = l.formatMessage(l.pluralBranch(plural.Ordinal, branches, value), placeholders, l.formatNumberString(value))
+		}
+	default:
+		return "", 0, false
+	}
+	return result, end + 1, true
+}
+
+type branch struct {
+	selector string
+	message  string
+}
+
+// parseBranches parses the branches of a plural or select expression ("one {...} other {...}").
+func parseBranches(expression string) ([]branch, bool) {
+	branches := []branch{}
+	for {
+		expression = strings.TrimSpace(expression)
+		if expression == "" {
+			break
+		}
+		start := strings.IndexByte(expression, '{')
+		if start <= 0 {
+			return nil, false
+		}
+		end := matchingBrace(expression[start:])
+		if end == -1 {
+			return nil, false
+		}
+		selector := strings.TrimSpace(expression[:start])
+		if strings.ContainsAny(selector, " \t\n") {
+			return nil, false
+		}
+		branches = append(branches, branch{selector: selector, message: expression[start+1 : start+end]})
+		expression = expression[start+end+1:]
+	}
+	return branches, len(branches) > 0
+}
+
+func selectBranch(branches []branch, value string) string {
+	other := ""
+	for _, b := range branches {
+		if b.selector == value {
+			return b.message
+		}
+		if b.selector == "other" {
+			other = b.message
+		}
+	}
+	return other
+}
+
+func (l *Language) pluralBranch(rules *plural.Rules, branches []branch, value string) string {
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		for _, b := range branches {
+			if exact, ok := strings.CutPrefix(b.selector, "="); ok {
+				if e, err := strconv.ParseFloat(exact, 64); err == nil && e == n {
+					return b.message
+				}
+			}
+		}
+	}
+	return selectBranch(branches, l.pluralCategory(rules, value))
+}
+
+// pluralCategory returns the plural category of the given decimal number.
+// Returns "other" if the value is not a valid decimal number.
+func (l *Language) pluralCategory(rules *plural.Rules, value string) string {
+	value = strings.TrimPrefix(value, "-")
+	integer, fraction, _ := strings.Cut(value, ".")
+	i, err := strconv.ParseUint(integer, 10, 64)
+	if err != nil {
+		return "other"
+	}
+	f, t := 0, 0
+	trimmed := strings.TrimRight(fraction, "0")
+	if fraction != "" {
+		if len(fraction) > 9 {
+			fraction = fraction[:9]
+			trimmed = strings.TrimRight(fraction, "0")
+		}
+		if f, err = strconv.Atoi(fraction); err != nil {
+			return "other"
+		}
+		t, _ = strconv.Atoi("0" + trimmed)
+	}
+	// Plural rules only use the last digits of large integers.
+	i %= 1_000_000_000
+	return pluralCategories[rules.MatchPlural(l.Tag(), int(i), len(fraction), len(trimmed), f, t)]
+}
+
+func (l *Language) formatNumberString(value string) string {
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return l.FormatNumber(i)
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return l.FormatNumber(f)
+	}
+	return value
+}
+
+// formatOperand returns the given number as a plain decimal string, without exponent.
+// Returns an empty string if the given value is not a number.
+func formatOperand(n any) string {
+	v := reflect.ValueOf(n)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// matchingBrace returns the index of the brace closing the one at the start of
+// the given string, or -1 if there is none.
+func matchingBrace(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isArgumentName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r == '_' || r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+func lookupPlaceholder(placeholders []string, placeholder string) (string, bool) {
+	for i := 0; i < len(placeholders)-1; i += 2 {
+		if placeholders[i] == placeholder {
+			return placeholders[i+1], true
+		}
+	}
+	return "", false
+}
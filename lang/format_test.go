@@ -0,0 +1,134 @@
+package lang
+
+import (
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+func newTestLanguage(name string, lines map[string]string) *Language {
+	return &Language{
+		name:  name,
+		lines: lines,
+		validation: validationLines{
+			rules:  map[string]string{},
+			fields: map[string]string{},
+		},
+	}
+}
+
+func (suite *LangTestSuite) TestTag() {
+	suite.Equal(language.AmericanEnglish, enUS.Tag())
+	suite.Equal(language.MustParse("fr-FR"), newTestLanguage("fr-FR", nil).Tag())
+	suite.Equal(language.Und, newTestLanguage("notalang", nil).Tag())
+}
+
+func (suite *LangTestSuite) TestPluralCategory() {
+	ru := newTestLanguage("ru-RU", nil)
+	suite.Equal("one", ru.PluralCategory(1))
+	suite.Equal("one", ru.PluralCategory(21))
+	suite.Equal("few", ru.PluralCategory(3))
+	suite.Equal("many", ru.PluralCategory(5))
+	suite.Equal("many", ru.PluralCategory(uint8(11)))
+	suite.Equal("other", ru.PluralCategory(1.5))
+
+	suite.Equal("one", enUS.PluralCategory(1))
+	suite.Equal("one", enUS.PluralCategory(1.0))
+	suite.Equal("one", enUS.PluralCategory(-1))
+	suite.Equal("other", enUS.PluralCategory(0))
+	suite.Equal("other", enUS.PluralCategory(1.5))
+	suite.Equal("other", enUS.PluralCategory("1"))
+
+	fr := newTestLanguage("fr-FR", nil)
+	suite.Equal("one", fr.PluralCategory(0))
+	suite.Equal("one", fr.PluralCategory(1.5))
+	suite.Equal("other", fr.PluralCategory(2))
+
+	suite.Equal("other", newTestLanguage("notalang", nil).PluralCategory(1))
+}
+
+func (suite *LangTestSuite) TestGetPlural() {
+	lines := map[string]string{
+		"items.count":  "{count, plural, =0 {No item} one {# item} other {# items}} in :place",
+		"simple":       "You have :count messages",
+		"placeholders": "{count, plural, one {:name has one item} other {:name has :count items}}",
+	}
+	en := newTestLanguage("en-US", lines)
+	suite.Equal("No item in cart", en.GetPlural("items.count", 0, ":place", "cart"))
+	suite.Equal("1 item in cart", en.GetPlural("items.count", 1, ":place", "cart"))
+	suite.Equal("1,234 items in cart", en.GetPlural("items.count", 1234, ":place", "cart"))
+	suite.Equal("1.5 items in cart", en.GetPlural("items.count", 1.5, ":place", "cart"))
+	suite.Equal("You have 1,000 messages", en.GetPlural("simple", 1000))
+	suite.Equal("You have a thousand messages", en.GetPlural("simple", 1000, ":count", "a thousand"))
+	suite.Equal("Kevin has one item", en.GetPlural("placeholders", 1, ":name", "Kevin"))
+	suite.Equal("Kevin has 2 items", en.GetPlural("placeholders", 2, ":name", "Kevin"))
+	suite.Equal("notaline", en.GetPlural("notaline", 2))
+
+	ru := newTestLanguage("ru-RU", map[string]string{
+		"items.count": "{count, plural, one {# предмет} few {# предмета} many {# предметов} other {# предмета}}",
+	})
+	suite.Equal("1 предмет", ru.GetPlural("items.count", 1))
+	suite.Equal("3 предмета", ru.GetPlural("items.count", 3))
+	suite.Equal("25 предметов", ru.GetPlural("items.count", 25))
+	suite.Equal("1\u00a0000 предметов", ru.GetPlural("items.count", 1000))
+
+	l := New()
+	l.languages["ru-RU"] = ru
+	suite.Equal("21 предмет", l.GetPlural("ru-RU", "items.count", 21))
+	suite.Equal("items.count", l.GetPlural("fr-FR", "items.count", 21))
+}
+
+func (suite *LangTestSuite) TestFormatMessage() {
+	en := newTestLanguage("en-US", map[string]string{
+		"number":  "Total: {total, number}",
+		"ordinal": "{rank, selectordinal, one {#st} two {#nd} few {#rd} other {#th}} place",
+		"select":  "{gender, select, female {She} male {He} other {They}} replied",
+		"nested":  "{gender, select, female {She has {count, plural, one {# item} other {# items}}} other {They have {count, number} items}}",
+		"date":    "Created on {date, date} at {date, time} ({date, datetime})",
+		"invalid": "{not closed, {count} {count, unknown} {count, plural, one} {?, number} {count, plural, one {#} other} # {}",
+		"json":    `{"count": :count}`,
+	})
+
+	suite.Equal("Total: 1,234,567.5", en.Get("number", ":total", "1234567.5"))
+	suite.Equal("Total: not a number", en.Get("number", ":total", "not a number"))
+	suite.Equal("1st place", en.Get("ordinal", ":rank", "1"))
+	suite.Equal("22nd place", en.Get("ordinal", ":rank", "22"))
+	suite.Equal("103rd place", en.Get("ordinal", ":rank", "103"))
+	suite.Equal("11th place", en.Get("ordinal", ":rank", "11"))
+	suite.Equal("She replied", en.Get("select", ":gender", "female"))
+	suite.Equal("They replied", en.Get("select", ":gender", "unknown"))
+	suite.Equal("They replied", en.Get("select"))
+	suite.Equal("She has 1 item", en.GetPlural("nested", 1, ":gender", "female"))
+	suite.Equal("They have 1,000 items", en.GetPlural("nested", 1000))
+	suite.Equal("Created on 03/04/2024 at 5:06 PM (03/04/2024 5:06 PM)", en.Get("date", ":date", "2024-03-04T17:06:00Z"))
+	suite.Equal("Created on yesterday at yesterday (yesterday)", en.Get("date", ":date", "yesterday"))
+	suite.Equal("{not closed, {count} {count, unknown} {count, plural, one} {?, number} {count, plural, one {#} other} # {}", en.Get("invalid", ":count", "1"))
+	suite.Equal(`{"count": 3}`, en.Get("json", ":count", "3"))
+
+	// Default validation messages
+	suite.Equal("The field must have at least 1 item.", enUS.Get("validation.rules.min.array", ":field", "field", ":min", "1"))
+	suite.Equal("The field must have at least 2 items.", enUS.Get("validation.rules.min.array", ":field", "field", ":min", "2"))
+	suite.Equal("The field must be between 0 and 1 character.", enUS.Get("validation.rules.between.string", ":field", "field", ":min", "0", ":max", "1"))
+}
+
+func (suite *LangTestSuite) TestFormat() {
+	en := newTestLanguage("en-US", map[string]string{})
+	suite.Equal("1,234.5", en.FormatNumber(1234.5))
+	suite.Equal("-1,000", en.FormatNumber(-1000))
+	suite.Equal("1\u00a0234,5", newTestLanguage("fr-FR", nil).FormatNumber(1234.5))
+	suite.Equal("1.234,5", newTestLanguage("de-DE", nil).FormatNumber(1234.5))
+
+	date := time.Date(2024, time.March, 4, 17, 6, 0, 0, time.UTC)
+	suite.Equal("03/04/2024", en.FormatDate(date))
+	suite.Equal("5:06 PM", en.FormatTime(date))
+	suite.Equal("03/04/2024 5:06 PM", en.FormatDateTime(date))
+
+	fr := newTestLanguage("fr-FR", map[string]string{
+		LineDateFormat:     "02/01/2006",
+		LineTimeFormat:     "15:04",
+		LineDateTimeFormat: "02/01/2006 15:04",
+	})
+	suite.Equal("04/03/2024", fr.FormatDate(date))
+	suite.Equal("17:06", fr.FormatTime(date))
+	suite.Equal("04/03/2024 17:06", fr.FormatDateTime(date))
+}
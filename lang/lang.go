@@ -2,6 +2,7 @@ package lang
 
 import (
 	"encoding/json"
+	"slices"
 	"strings"
 
 	"github.com/samber/lo"
@@ -15,8 +16,13 @@ import (
 // This structure is not protected for concurrent usage. Therefore, don't load
 // more languages when this instance is expected to receive reads.
 type Languages struct {
-	languages map[string]*Language
-	Default   string
+	languages          map[string]*Language
+	fallbacks          map[string][]string
+	missingLineHandler MissingLineHandler
+	Default            string
+
+	// order the names of the languages in the order they were loaded
+	order []string
 }
 
 // New create a `Languages` with preloaded default language "en-US".
@@ -26,12 +32,19 @@ type Languages struct {
 func New() *Languages {
 	l := &Languages{
 		languages: make(map[string]*Language, 1),
+		fallbacks: map[string][]string{},
 		Default:   enUS.name,
 	}
-	l.languages[enUS.name] = enUS.clone()
+	l.add(enUS.clone())
 	return l
 }
 
+func (l *Languages) add(lang *Language) {
+	lang.container = l
+	l.languages[lang.name] = lang
+	l.order = append(l.order, lang.name)
+}
+
 // LoadAllAvailableLanguages loads every available language directory.
 // If the given FS implements `fsutil.WorkingDirFS`, the directory
 // used will be "<working directory>/resources/lang".
@@ -110,7 +123,7 @@ func (l *Languages) load(fs fsutil.FS, lang string, path string) error {
 	if existingLang, exists := l.languages[lang]; exists {
 		mergeLang(existingLang, langStruct)
 	} else {
-		l.languages[lang] = langStruct
+		l.add(langStruct)
 	}
 	return nil
 }
@@ -143,15 +156,74 @@ func (l *Languages) IsAvailable(lang string) bool {
 	return exists
 }
 
-// GetAvailableLanguages returns a slice of all loaded languages.
-// This can be used to generate different routes for all languages
+// GetAvailableLanguages returns a slice of all loaded languages, in the order
+// they were loaded. This can be used to generate different routes for all languages
 // supported by your applications.
 //
 //	/en/products
 //	/fr/produits
 //	...
 func (l *Languages) GetAvailableLanguages() []string {
-	return lo.Keys(l.languages)
+	return slices.Clone(l.order)
+}
+
+// SetFallback defines the fallback chain of the given language. The chain is used
+// in the given order:
+//   - by `Language.Get()` when a line is missing in the language
+//   - by `DetectLanguage()` and `Get()` when the language is requested but not available
+//
+// Chains are followed transitively: with "fr-CA" falling back to "fr-FR" and "fr-FR"
+// falling back to "fr-BE", the chain of "fr-CA" is "fr-FR", "fr-BE". The default language
+// is used as the last resort by `Language.Get()`. Languages of the chain that are not
+// available are skipped. Calling this function again for the same language replaces its chain.
+//
+// Lines are only looked up in other languages for languages having a fallback chain. Missing
+// lines of other languages are returned as-is (the name of the line). To fall back to the
+// default language only, define an empty chain:
+//
+//	languages.SetFallback("fr-FR")
+//
+//	languages.SetFallback("fr-CA", "fr-FR", "en-US")
+func (l *Languages) SetFallback(lang string, fallbacks ...string) {
+	if l.fallbacks == nil {
+		l.fallbacks = map[string][]string{}
+	}
+	l.fallbacks[lang] = slices.Clone(fallbacks)
+}
+
+// fallbackChain returns the available languages of the fallback chain of the given
+// language, without the default language. Each language appears only once.
+func (l *Languages) fallbackChain(lang string) []*Language {
+	chain := []*Language{}
+	visited := map[string]struct{}{lang: {}}
+	var walk func(lang string)
+	walk = func(lang string) {
+		for _, fallback := range l.fallbacks[lang] {
+			if _, ok := visited[fallback]; ok {
+				continue
+			}
+			visited[fallback] = struct{}{}
+			if language, ok := l.languages[fallback]; ok {
+				chain = append(chain, language)
+			}
+			walk(fallback)
+		}
+	}
+	walk(lang)
+	return chain
+}
+
+// lookup returns the given language if available, or the first available language
+// of its fallback chain. Returns nil if none are available.
+func (l *Languages) lookup(lang string) *Language {
+	if language, ok := l.languages[lang]; ok {
+		return language
+	}
+	chain := l.fallbackChain(lang)
+	if len(chain) == 0 {
+		return nil
+	}
+	return chain[0]
 }
 
 // DetectLanguage detects the language to use based on the given lang string.
@@ -160,27 +232,35 @@ func (l *Languages) GetAvailableLanguages() []string {
 // If "*" is provided, the default language will be used.
 // If multiple languages are given, the first available language will be used,
 // and if none are available, the default language will be used.
-// If no variant is given (for example "en"), the first available variant will be used.
+// If a language is not available but has a fallback chain (see `SetFallback()`),
+// the first available language of the chain will be used.
+// If no variant is given (for example "en"), the first available variant will be used,
+// in the order the languages were loaded.
 func (l *Languages) DetectLanguage(lang string) *Language {
+	if match := l.Match(lang); match != nil {
+		return match
+	}
+	return l.GetLanguage(l.Default)
+}
+
+// Match works like `DetectLanguage()` but returns nil instead of the default language
+// if none of the given languages are available or if "*" is provided.
+func (l *Languages) Match(lang string) *Language {
 	values := httputil.ParseMultiValuesHeader(lang)
 	for _, lang := range values {
-		if lang.Value == "*" { // Accept anything, so return default language
+		if lang.Value == "*" { // Accept anything
 			break
 		}
-		if match, ok := l.languages[lang.Value]; ok {
+		if match := l.lookup(lang.Value); match != nil {
 			return match
 		}
-		for key, v := range l.languages {
-			if strings.HasPrefix(key, lang.Value) {
-				// TODO priority for languages? The "first available variant" is random because keys are not ordered.
-				// Ordering alphabetically won't always produce the desired result (e.g. en-UK < en-US)
-				// Can create a slice of language names (so the order will be the order in which the languages have been added)
-				return v
+		for _, name := range l.order {
+			if strings.HasPrefix(name, lang.Value) {
+				return l.languages[name]
 			}
 		}
 	}
-
-	return l.GetLanguage(l.Default)
+	return nil
 }
 
 // Get a language line.
@@ -192,7 +272,8 @@ func (l *Languages) DetectLanguage(lang string) *Language {
 // For normal lines, just use the name of the line. Note that if you have
 // a line called "validation", it won't conflict with the dot-separated paths.
 //
-// If not found, returns the exact "line" argument.
+// If the language is not available, the first available language of its fallback
+// chain is used. If there is none, returns the exact "line" argument.
 //
 // The placeholders parameter is a variadic associative slice of placeholders and their
 // replacement. In the following example, the placeholder ":username" will be replaced
@@ -200,14 +281,29 @@ func (l *Languages) DetectLanguage(lang string) *Language {
 //
 //	lang.Get("en-US", "greetings", ":username", user.Name)
 func (l *Languages) Get(lang string, line string, placeholders ...string) string {
-	language, exists := l.languages[lang]
-	if !exists {
+	language := l.lookup(lang)
+	if language == nil {
 		return line
 	}
 
 	return language.Get(line, placeholders...)
 }
 
+// GetPlural a language line containing plural forms, selected using the given count.
+// If the language is not available, the first available language of its fallback
+// chain is used. If there is none, returns the exact "line" argument.
+// See `Language.GetPlural()` for more details.
+//
+//	lang.GetPlural("en-US", "items.count", len(items))
+func (l *Languages) GetPlural(lang string, line string, count any, placeholders ...string) string {
+	language := l.lookup(lang)
+	if language == nil {
+		return line
+	}
+
+	return language.GetPlural(line, count, placeholders...)
+}
+
 func readLangFile(fs fsutil.FS, path string, dest any) (err error) {
 	if !fsutil.FileExists(fs, path) {
 		return nil
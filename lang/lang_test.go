@@ -36,9 +36,12 @@ func (suite *LangTestSuite) SetupSuite() {
 func (suite *LangTestSuite) TestNew() {
 	l := New()
 	expected := &Languages{
-		languages: map[string]*Language{enUS.name: enUS},
+		languages: map[string]*Language{enUS.name: enUS.clone()},
+		fallbacks: map[string][]string{},
 		Default:   enUS.name,
+		order:     []string{enUS.name},
 	}
+	expected.languages[enUS.name].container = expected
 	suite.Equal(expected, l)
 }
 
@@ -79,6 +82,7 @@ func (suite *LangTestSuite) TestLoad() {
 			rules:  map[string]string{},
 			fields: map[string]string{},
 		},
+		container: l,
 	}
 	suite.Equal(expected, l.languages["en-UK"])
 
@@ -104,6 +108,7 @@ func (suite *LangTestSuite) TestLoad() {
 				"email": "email address",
 			},
 		},
+		container: l,
 	}
 	suite.Equal(expected, l.languages["en-UK"])
 }
@@ -152,7 +157,7 @@ func (suite *LangTestSuite) TestGetAvailableLanguages() {
 		return
 	}
 
-	suite.ElementsMatch([]string{"en-US", "en-UK"}, l.GetAvailableLanguages())
+	suite.Equal([]string{"en-US", "en-UK"}, l.GetAvailableLanguages())
 }
 
 func (suite *LangTestSuite) TestDetectLanguage() {
@@ -172,6 +177,79 @@ func (suite *LangTestSuite) TestDetectLanguage() {
 	suite.Equal(l.languages["en-US"], l.DetectLanguage("*"))
 	suite.Equal(l.languages["en-US"], l.DetectLanguage("notalang"))
 	suite.Equal(l.languages["en-US"], l.DetectLanguage(""))
+
+	// First available variant in loading order
+	if err := l.Load(&osfs.FS{}, "fr-CA", "resources/lang/en-UK"); err != nil {
+		panic(err)
+	}
+	for i := 0; i < 10; i++ {
+		suite.Equal(l.languages["fr-FR"], l.DetectLanguage("fr"))
+	}
+
+	// Fallback chains
+	l.SetFallback("fr-BE", "fr-LU", "fr-CA", "fr-FR")
+	suite.Equal(l.languages["fr-CA"], l.DetectLanguage("fr-BE"))
+	suite.Equal(l.languages["fr-CA"], l.DetectLanguage("fr-BE, fr-FR"))
+	suite.Equal(l.languages["en-US"], l.DetectLanguage("de-DE"))
+}
+
+func (suite *LangTestSuite) TestMatch() {
+	l := New()
+	if err := l.Load(&osfs.FS{}, "fr-FR", "resources/lang/en-US"); err != nil {
+		panic(err)
+	}
+	suite.Equal(l.languages["fr-FR"], l.Match("fr"))
+	suite.Equal(l.languages["fr-FR"], l.Match("de-DE, fr-FR;q=0.9"))
+	suite.Nil(l.Match("*"))
+	suite.Nil(l.Match("de-DE"))
+	suite.Nil(l.Match(""))
+}
+
+func (suite *LangTestSuite) TestFallback() {
+	l := New()
+	if err := l.Load(&osfs.FS{}, "en-UK", "resources/lang/en-UK"); err != nil {
+		panic(err)
+	}
+	if err := l.Load(&osfs.FS{}, "fr-FR", "resources/lang/en-US"); err != nil {
+		panic(err)
+	}
+	l.languages["fr-CA"] = newTestLanguage("fr-CA", map[string]string{"test-load": "load CA"})
+	l.languages["fr-CA"].container = l
+	l.order = append(l.order, "fr-CA")
+
+	fr := l.GetLanguage("fr-CA")
+	suite.Equal("load CA", fr.Get("test-load"))
+	suite.Equal("auth.jwt-expired", fr.Get("auth.jwt-expired")) // No fallback chain
+	suite.Equal("custom-line", fr.Get("custom-line"))
+	suite.Equal("notaline", fr.Get("notaline"))
+
+	l.SetFallback("fr-CA")
+	suite.Equal("Your authentication token is expired.", fr.Get("auth.jwt-expired")) // Default language
+	suite.Equal("custom-line", fr.Get("custom-line"))
+
+	l.SetFallback("fr-CA", "fr-BE", "fr-FR")
+	l.SetFallback("fr-FR", "en-UK", "fr-CA")
+	suite.Equal([]*Language{l.languages["fr-FR"], l.languages["en-UK"]}, l.fallbackChain("fr-CA"))
+	suite.Equal("Custom line", fr.Get("custom-line"))
+	suite.Equal("load CA", fr.Get("test-load"))
+	suite.Equal("The email address values are required.", fr.Get("validation.rules.required.array", ":field", fr.Get("validation.fields.email")))
+	suite.Equal("Line with 3 placeholders", fr.GetPlural("many-placeholders", 3, ":placeholders", "placeholders"))
+
+	// Languages.Get uses the fallback chain if the language is not available
+	l.SetFallback("fr-BE", "fr-CA")
+	suite.Equal("load CA", l.Get("fr-BE", "test-load"))
+	suite.Equal("Custom line", l.GetPlural("fr-BE", "custom-line", 1))
+	suite.Equal("test-load", l.Get("de-DE", "test-load"))
+
+	// Default language doesn't fall back on itself
+	l.SetFallback("en-US", "fr-CA")
+	suite.Equal("load CA", l.Get("en-US", "test-load"))
+	suite.Equal("notaline", l.Get("en-US", "notaline"))
+
+	// No container
+	suite.Equal("custom-line", newTestLanguage("fr-CA", map[string]string{}).Get("custom-line"))
+	suite.Equal("custom-line", (&Languages{languages: map[string]*Language{}}).GetLanguage("fr").Get("custom-line"))
+	(&Languages{}).SetFallback("fr-CA", "fr-FR")
 }
 
 func (suite *LangTestSuite) TestLanguagesGet() {
@@ -232,10 +310,10 @@ func (suite *LangTestSuite) TestMerge() {
 }
 
 func (suite *LangTestSuite) TestPlaceholders() {
-	suite.Equal("notaline", convertEmptyLine("notaline", "", []string{":username", "Kevin"}))
-	suite.Equal("Greetings, Kevin", convertEmptyLine("greetings", "Greetings, :username", []string{":username", "Kevin"}))
-	suite.Equal("Greetings, Kevin, today is Monday", convertEmptyLine("greetings", "Greetings, :username, today is :today", []string{":username", "Kevin", ":today", "Monday"}))
-	suite.Equal("Greetings, Kevin, today is :today", convertEmptyLine("greetings", "Greetings, :username, today is :today", []string{":username", "Kevin", ":today"}))
+	suite.Equal("notaline", enUS.convertEmptyLine("notaline", "", []string{":username", "Kevin"}))
+	suite.Equal("Greetings, Kevin", enUS.convertEmptyLine("greetings", "Greetings, :username", []string{":username", "Kevin"}))
+	suite.Equal("Greetings, Kevin, today is Monday", enUS.convertEmptyLine("greetings", "Greetings, :username, today is :today", []string{":username", "Kevin", ":today", "Monday"}))
+	suite.Equal("Greetings, Kevin, today is :today", enUS.convertEmptyLine("greetings", "Greetings, :username, today is :today", []string{":username", "Kevin", ":today"}))
 }
 
 func (suite *LangTestSuite) TestSetDefault() {
@@ -15,6 +15,9 @@ type Language struct {
 	lines      map[string]string
 	validation validationLines
 	name       string
+
+	// container the languages this language belongs to, used to find fallback lines
+	container *Languages
 }
 
 // Name returns the name of the language. For example "en-US".
@@ -48,28 +51,78 @@ func (l *Language) clone() *Language {
 // For normal lines, just use the name of the line. Note that if you have
 // a line called "validation", it won't conflict with the dot-separated paths.
 //
-// If not found, returns the exact "line" argument.
+// If the line is missing in this language and a fallback chain is defined for this language
+// (see `Languages.SetFallback()`), it is taken from the first language of the chain containing it,
+// then from the default language. Languages without a fallback chain don't fall back at all.
+// If not found, returns the exact "line" argument and calls the missing line handler
+// (see `Languages.SetMissingLineHandler()`).
 //
 // The placeholders parameter is a variadic associative slice of placeholders and their
 // replacement. In the following example, the placeholder ":username" will be replaced
 // with the Name field in the user struct.
 //
 //	lang.Get("greetings", ":username", user.Name)
+//
+// Lines can contain ICU-style arguments, such as plural forms, evaluated using
+// the placeholders. See `GetPlural()` for more details.
 func (l *Language) Get(line string, placeholders ...string) string {
+	message := l.getLine(line)
+	if message == "" {
+		l.reportMissingLine(line)
+	}
+	return l.convertEmptyLine(line, message, placeholders)
+}
+
+// Lookup returns the requested line, just like `Get()`, and true if the line exists
+// in this language or in its fallbacks. Unlike `Get()`, a missing line is not reported
+// to the missing line handler and results in an empty string and false. Use this to
+// retrieve optional lines.
+func (l *Language) Lookup(line string, placeholders ...string) (string, bool) {
+	message := l.getLine(line)
+	if message == "" {
+		return "", false
+	}
+	return l.convertEmptyLine(line, message, placeholders), true
+}
+
+func (l *Language) reportMissingLine(line string) {
+	if l.container != nil && l.container.missingLineHandler != nil {
+		l.container.missingLineHandler(l, line)
+	}
+}
+
+func (l *Language) getLine(line string) string {
+	if message := l.getOwnLine(line); message != "" || l.container == nil {
+		return message
+	}
+	if _, ok := l.container.fallbacks[l.name]; !ok {
+		return ""
+	}
+	for _, fallback := range l.container.fallbackChain(l.name) {
+		if message := fallback.getOwnLine(line); message != "" {
+			return message
+		}
+	}
+	if def, ok := l.container.languages[l.container.Default]; ok && def != l {
+		return def.getOwnLine(line)
+	}
+	return ""
+}
+
+func (l *Language) getOwnLine(line string) string {
 	if strings.HasPrefix(line, "validation.rules.") {
-		return convertEmptyLine(line, l.validation.rules[line[17:]], placeholders)
+		return l.validation.rules[line[17:]]
 	} else if strings.HasPrefix(line, "validation.fields.") {
-		return convertEmptyLine(line, l.validation.fields[line[18:]], placeholders)
+		return l.validation.fields[line[18:]]
 	}
-
-	return convertEmptyLine(line, l.lines[line], placeholders)
+	return l.lines[line]
 }
 
-func convertEmptyLine(entry, line string, placeholders []string) string {
+func (l *Language) convertEmptyLine(entry, line string, placeholders []string) string {
 	if line == "" {
 		return entry
 	}
-	return processPlaceholders(line, placeholders)
+	return processPlaceholders(l.formatMessage(line, placeholders, ""), placeholders)
 }
 
 func processPlaceholders(message string, values []string) string {
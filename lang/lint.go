@@ -0,0 +1,139 @@
+package lang
+
+import (
+	"cmp"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"goyave.dev/goyave/v5/util/errors"
+)
+
+// Names of the language files, used in `LintIssue`.
+const (
+	FileLocale = "locale.json"
+	FileRules  = "rules.json"
+	FileFields = "fields.json"
+)
+
+// Types of `LintIssue`.
+const (
+	// LintMissing the line exists in the default language but not in the linted language.
+	LintMissing = "missing"
+
+	// LintExtra the line exists in the linted language but not in the default language.
+	// This often indicates a typo in the line name.
+	LintExtra = "extra"
+)
+
+// MissingLineHandler is called when a line cannot be found in a language nor in
+// its fallbacks. See `Languages.SetMissingLineHandler()`.
+type MissingLineHandler func(language *Language, line string)
+
+// SetMissingLineHandler sets the function called every time `Language.Get()` or
+// `Language.GetPlural()` cannot find a line in a language nor in its fallbacks,
+// and therefore returns the line name. Set to nil to disable reporting (default).
+//
+// Optional lines retrieved with `Language.Lookup()`, such as the validation field names
+// ("validation.fields.<field_name>"), are not reported.
+func (l *Languages) SetMissingLineHandler(handler MissingLineHandler) {
+	l.missingLineHandler = handler
+}
+
+// LogMissingLines returns a `MissingLineHandler` logging missing lines at the warning level
+// using the given logger.
+//
+//	languages.SetMissingLineHandler(lang.LogMissingLines(server.Logger.Logger))
+func LogMissingLines(logger *slog.Logger) MissingLineHandler {
+	return func(language *Language, line string) {
+		logger.Warn("missing language line", slog.String("lang", language.Name()), slog.String("line", line))
+	}
+}
+
+// LintIssue describes a difference between a language and the default language.
+type LintIssue struct {
+	// Language the name of the linted language
+	Language string
+
+	// File the language file the line belongs to (`FileLocale`, `FileRules` or `FileFields`)
+	File string
+
+	// Line the name of the line, relative to the file (e.g. "min.array" for "validation.rules.min.array")
+	Line string
+
+	// Type `LintMissing` or `LintExtra`
+	Type string
+}
+
+func (i *LintIssue) String() string {
+	return fmt.Sprintf("%s: %s line %q in %s", i.Language, i.Type, i.Line, i.File)
+}
+
+// LintError returned by `Languages.Lint()` when at least one language is
+// not consistent with the default language.
+type LintError struct {
+	Issues []*LintIssue
+}
+
+func (e *LintError) Error() string {
+	message := "incomplete translations:"
+	for _, issue := range e.Issues {
+		message += "\n\t- " + issue.String()
+	}
+	return message
+}
+
+// Lint compares every loaded language with the default language. Lines that are
+// missing in a language or that don't exist in the default language are reported,
+// for each language file: normal lines (`FileLocale`), validation rules messages,
+// including each type-dependent variant such as "min.string" or "min.array.element"
+// (`FileRules`), and field names (`FileFields`).
+//
+// Returns a `*LintError` if at least one issue is found. This can be used to fail a
+// CI pipeline when a translation is incomplete:
+//
+//	func TestTranslations(t *testing.T) {
+//		languages := lang.New()
+//		require.NoError(t, languages.LoadAllAvailableLanguages(&osfs.FS{}))
+//		require.NoError(t, languages.Lint())
+//	}
+func (l *Languages) Lint() error {
+	def, ok := l.languages[l.Default]
+	if !ok {
+		return errors.Errorf("cannot lint languages: default language %q is not available", l.Default)
+	}
+
+	issues := []*LintIssue{}
+	for _, name := range l.order {
+		language := l.languages[name]
+		if language == def {
+			continue
+		}
+		issues = append(issues, lintLines(name, FileLocale, def.lines, language.lines)...)
+		issues = append(issues, lintLines(name, FileRules, def.validation.rules, language.validation.rules)...)
+		issues = append(issues, lintLines(name, FileFields, def.validation.fields, language.validation.fields)...)
+	}
+
+	if len(issues) > 0 {
+		return errors.New(&LintError{Issues: issues})
+	}
+	return nil
+}
+
+func lintLines(language, file string, reference, lines map[string]string) []*LintIssue {
+	issues := []*LintIssue{}
+	for line := range reference {
+		if _, ok := lines[line]; !ok {
+			issues = append(issues, &LintIssue{Language: language, File: file, Line: line, Type: LintMissing})
+		}
+	}
+	for line := range lines {
+		if _, ok := reference[line]; !ok {
+			issues = append(issues, &LintIssue{Language: language, File: file, Line: line, Type: LintExtra})
+		}
+	}
+	slices.SortFunc(issues, func(a, b *LintIssue) int {
+		return cmp.Compare(a.Line, b.Line)
+	})
+	return issues
+}
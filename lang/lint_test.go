@@ -0,0 +1,104 @@
+package lang
+
+import (
+	"bytes"
+	"log/slog"
+
+	"goyave.dev/goyave/v5/util/fsutil/osfs"
+)
+
+func (suite *LangTestSuite) TestMissingLineHandler() {
+	l := New()
+	if err := l.Load(&osfs.FS{}, "en-UK", "resources/lang/en-UK"); err != nil {
+		panic(err)
+	}
+
+	l.SetFallback("en-UK")
+
+	missing := []string{}
+	l.SetMissingLineHandler(func(language *Language, line string) {
+		missing = append(missing, language.Name()+" "+line)
+	})
+
+	uk := l.GetLanguage("en-UK")
+	suite.Equal("load UK", uk.Get("test-load"))
+	suite.Equal("Invalid credentials.", uk.Get("auth.invalid-credentials")) // From the default language
+	suite.Equal("notaline", uk.Get("notaline"))
+	suite.Equal("validation.fields.name", uk.Get("validation.fields.name"))
+	suite.Equal("items.count", uk.GetPlural("items.count", 2))
+	suite.Equal("notaline", l.GetLanguage("fr-FR").Get("notaline")) // Dummy language
+
+	// Lookups are not reported
+	line, ok := uk.Lookup("validation.fields.name")
+	suite.False(ok)
+	suite.Empty(line)
+	line, ok = uk.Lookup("auth.invalid-credentials")
+	suite.True(ok)
+	suite.Equal("Invalid credentials.", line)
+	line, ok = uk.Lookup("test-load")
+	suite.True(ok)
+	suite.Equal("load UK", line)
+	suite.Equal([]string{"en-UK notaline", "en-UK validation.fields.name", "en-UK items.count"}, missing)
+
+	l.SetMissingLineHandler(nil)
+	suite.Equal("notaline", uk.Get("notaline"))
+	suite.Len(missing, 3)
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}}))
+	l.SetMissingLineHandler(LogMissingLines(logger))
+	uk.Get("notaline")
+	suite.Equal("level=WARN msg=\"missing language line\" lang=en-UK line=notaline\n", buf.String())
+}
+
+func (suite *LangTestSuite) TestLint() {
+	l := New()
+	suite.NoError(l.Lint())
+
+	l.languages["en-UK"] = enUS.clone()
+	l.languages["en-UK"].name = "en-UK"
+	l.order = append(l.order, "en-UK")
+	suite.NoError(l.Lint())
+
+	fr := enUS.clone()
+	fr.name = "fr-FR"
+	delete(fr.lines, "malformed-json")
+	delete(fr.validation.rules, "min.array")
+	delete(fr.validation.rules, "min.array.element")
+	fr.validation.rules["min.arrays"] = "typo"
+	delete(fr.validation.fields, "email")
+	fr.validation.fields["username"] = "nom d'utilisateur"
+	l.languages["fr-FR"] = fr
+	l.order = append(l.order, "fr-FR")
+
+	err := l.Lint()
+	suite.Require().Error(err)
+	var lintErr *LintError
+	suite.Require().ErrorAs(err, &lintErr)
+	expected := []*LintIssue{
+		{Language: "fr-FR", File: FileLocale, Line: "malformed-json", Type: LintMissing},
+		{Language: "fr-FR", File: FileRules, Line: "min.array", Type: LintMissing},
+		{Language: "fr-FR", File: FileRules, Line: "min.array.element", Type: LintMissing},
+		{Language: "fr-FR", File: FileRules, Line: "min.arrays", Type: LintExtra},
+		{Language: "fr-FR", File: FileFields, Line: "email", Type: LintMissing},
+		{Language: "fr-FR", File: FileFields, Line: "username", Type: LintExtra},
+	}
+	suite.Equal(expected, lintErr.Issues)
+	suite.Equal(`incomplete translations:
+	- fr-FR: missing line "malformed-json" in locale.json
+	- fr-FR: missing line "min.array" in rules.json
+	- fr-FR: missing line "min.array.element" in rules.json
+	- fr-FR: extra line "min.arrays" in rules.json
+	- fr-FR: missing line "email" in fields.json
+	- fr-FR: extra line "username" in fields.json`, lintErr.Error())
+
+	l.Default = "de-DE"
+	err = l.Lint()
+	suite.Require().Error(err)
+	suite.Equal("cannot lint languages: default language \"de-DE\" is not available", err.Error())
+}
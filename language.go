@@ -0,0 +1,126 @@
+package goyave
+
+import (
+	"goyave.dev/goyave/v5/lang"
+)
+
+// LanguageResolver determines the language requested by the client of a request.
+// Resolvers are used in a chain by the server to negotiate the language of each request
+// (see `Options.LanguageResolvers`).
+type LanguageResolver interface {
+	// ResolveLanguage returns the language requested by the client. The returned string
+	// can use the HTTP "Accept-Language" header format. Returns an empty string if this
+	// resolver cannot determine the language of the request.
+	ResolveLanguage(request *Request) string
+}
+
+// LanguageResolverFunc an adapter allowing the use of ordinary functions as `LanguageResolver`.
+type LanguageResolverFunc func(request *Request) string
+
+// ResolveLanguage calls f(request).
+func (f LanguageResolverFunc) ResolveLanguage(request *Request) string {
+	return f(request)
+}
+
+// LanguagePreferrer can be implemented by user DTOs to define the preferred
+// language of the authenticated user. See `UserLanguageResolver`.
+type LanguagePreferrer interface {
+	// PreferredLanguage returns the name of the preferred language of the user
+	// (e.g. "en-US"), or an empty string if the user doesn't have a preference.
+	PreferredLanguage() string
+}
+
+// QueryLanguageResolver resolves the language using a query parameter of the request URL
+// (for example "?lang=fr-FR"). This is useful for requests for which clients cannot set
+// headers, such as downloads or websocket upgrades.
+type QueryLanguageResolver struct {
+	// Parameter the name of the query parameter. Defaults to "lang".
+	Parameter string
+}
+
+// ResolveLanguage returns the value of the query parameter.
+func (r *QueryLanguageResolver) ResolveLanguage(request *Request) string {
+	parameter := r.Parameter
+	if parameter == "" {
+		parameter = "lang"
+	}
+	return request.URL().Query().Get(parameter)
+}
+
+// CookieLanguageResolver resolves the language using a cookie.
+type CookieLanguageResolver struct {
+	// Name the name of the cookie. Defaults to "lang".
+	Name string
+}
+
+// ResolveLanguage returns the value of the cookie.
+func (r *CookieLanguageResolver) ResolveLanguage(request *Request) string {
+	name := r.Name
+	if name == "" {
+		name = "lang"
+	}
+	for _, cookie := range request.Cookies() {
+		if cookie.Name == name {
+			return cookie.Value
+		}
+	}
+	return ""
+}
+
+// UserLanguageResolver resolves the language using the preference of the authenticated
+// user if `request.User` implements `LanguagePreferrer`.
+//
+// The language middleware is executed before authentication. Therefore, the authentication
+// middleware negotiates the language of the request again if the authenticated user
+// implements `LanguagePreferrer`.
+type UserLanguageResolver struct{}
+
+// ResolveLanguage returns the preferred language of the user.
+func (r *UserLanguageResolver) ResolveLanguage(request *Request) string {
+	if preferrer, ok := request.User.(LanguagePreferrer); ok {
+		return preferrer.PreferredLanguage()
+	}
+	return ""
+}
+
+// HeaderLanguageResolver resolves the language using the "Accept-Language" header.
+type HeaderLanguageResolver struct{}
+
+// ResolveLanguage returns the value of the "Accept-Language" header.
+func (r *HeaderLanguageResolver) ResolveLanguage(request *Request) string {
+	return request.Header().Get("Accept-Language")
+}
+
+// DefaultLanguageResolvers returns the resolver chain used by default to negotiate
+// the language of requests, which only uses the "Accept-Language" header.
+//
+// The other resolvers are opt-in and can be enabled with `Options.LanguageResolvers`:
+//
+//	opts := goyave.Options{
+//		LanguageResolvers: []goyave.LanguageResolver{
+//			&goyave.QueryLanguageResolver{},
+//			&goyave.CookieLanguageResolver{},
+//			&goyave.UserLanguageResolver{},
+//			&goyave.HeaderLanguageResolver{},
+//		},
+//	}
+func DefaultLanguageResolvers() []LanguageResolver {
+	return []LanguageResolver{
+		&HeaderLanguageResolver{},
+	}
+}
+
+// NegotiateLanguage returns the language to use for the given request. The resolvers
+// of the server (see `Options.LanguageResolvers`) are tried in order. The first resolved
+// language that is available (see `lang.Languages.Match()`) is used. If none are
+// available, the default language is used.
+func (s *Server) NegotiateLanguage(request *Request) *lang.Language {
+	for _, resolver := range s.languageResolvers {
+		if l := resolver.ResolveLanguage(request); l != "" {
+			if match := s.Lang.Match(l); match != nil {
+				return match
+			}
+		}
+	}
+	return s.Lang.GetDefault()
+}
@@ -0,0 +1,141 @@
+package goyave
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goyave.dev/goyave/v5/config"
+)
+
+type testLanguageUser struct {
+	lang string
+}
+
+func (u *testLanguageUser) PreferredLanguage() string {
+	return u.lang
+}
+
+func TestLanguageResolvers(t *testing.T) {
+	newRequest := func(uri string) *Request {
+		return NewRequest(httptest.NewRequest(http.MethodGet, uri, nil))
+	}
+
+	t.Run("QueryLanguageResolver", func(t *testing.T) {
+		request := newRequest("/test?lang=en-UK&locale=fr-FR")
+		assert.Equal(t, "en-UK", (&QueryLanguageResolver{}).ResolveLanguage(request))
+		assert.Equal(t, "fr-FR", (&QueryLanguageResolver{Parameter: "locale"}).ResolveLanguage(request))
+		assert.Empty(t, (&QueryLanguageResolver{}).ResolveLanguage(newRequest("/test")))
+	})
+
+	t.Run("CookieLanguageResolver", func(t *testing.T) {
+		request := newRequest("/test")
+		request.Request().AddCookie(&http.Cookie{Name: "lang", Value: "en-UK"})
+		request.Request().AddCookie(&http.Cookie{Name: "locale", Value: "fr-FR"})
+		assert.Equal(t, "en-UK", (&CookieLanguageResolver{}).ResolveLanguage(request))
+		assert.Equal(t, "fr-FR", (&CookieLanguageResolver{Name: "locale"}).ResolveLanguage(request))
+		assert.Empty(t, (&CookieLanguageResolver{}).ResolveLanguage(newRequest("/test")))
+	})
+
+	t.Run("UserLanguageResolver", func(t *testing.T) {
+		request := newRequest("/test")
+		assert.Empty(t, (&UserLanguageResolver{}).ResolveLanguage(request))
+		request.User = "not a preferrer"
+		assert.Empty(t, (&UserLanguageResolver{}).ResolveLanguage(request))
+		request.User = &testLanguageUser{lang: "en-UK"}
+		assert.Equal(t, "en-UK", (&UserLanguageResolver{}).ResolveLanguage(request))
+	})
+
+	t.Run("HeaderLanguageResolver", func(t *testing.T) {
+		request := newRequest("/test")
+		assert.Empty(t, (&HeaderLanguageResolver{}).ResolveLanguage(request))
+		request.Header().Set("Accept-Language", "en-UK, en-US;q=0.9")
+		assert.Equal(t, "en-UK, en-US;q=0.9", (&HeaderLanguageResolver{}).ResolveLanguage(request))
+	})
+
+	t.Run("DefaultLanguageResolvers", func(t *testing.T) {
+		assert.Equal(t, []LanguageResolver{
+			&HeaderLanguageResolver{},
+		}, DefaultLanguageResolvers())
+	})
+}
+
+func TestNegotiateLanguage(t *testing.T) {
+	t.Run("default_resolvers", func(t *testing.T) {
+		server, err := New(Options{Config: config.LoadDefault()})
+		require.NoError(t, err)
+		assert.Equal(t, DefaultLanguageResolvers(), server.languageResolvers)
+
+		request := NewRequest(httptest.NewRequest(http.MethodGet, "/test", nil))
+		assert.Equal(t, "en-US", server.NegotiateLanguage(request).Name())
+
+		request.Header().Set("Accept-Language", "*")
+		assert.Equal(t, "en-US", server.NegotiateLanguage(request).Name())
+
+		request.Header().Set("Accept-Language", "en-UK")
+		assert.Equal(t, "en-UK", server.NegotiateLanguage(request).Name())
+
+		// Query, cookie and user preference are opt-in
+		request = NewRequest(httptest.NewRequest(http.MethodGet, "/test?lang=en-UK", nil))
+		request.Request().AddCookie(&http.Cookie{Name: "lang", Value: "en-UK"})
+		request.User = &testLanguageUser{lang: "en-UK"}
+		assert.Equal(t, "en-US", server.NegotiateLanguage(request).Name())
+	})
+
+	t.Run("opt_in_resolvers", func(t *testing.T) {
+		server, err := New(Options{
+			Config: config.LoadDefault(),
+			LanguageResolvers: []LanguageResolver{
+				&QueryLanguageResolver{},
+				&CookieLanguageResolver{},
+				&UserLanguageResolver{},
+				&HeaderLanguageResolver{},
+			},
+		})
+		require.NoError(t, err)
+
+		request := NewRequest(httptest.NewRequest(http.MethodGet, "/test?lang=en-UK", nil))
+		assert.Equal(t, "en-UK", server.NegotiateLanguage(request).Name())
+
+		request = NewRequest(httptest.NewRequest(http.MethodGet, "/test", nil))
+		request.Request().AddCookie(&http.Cookie{Name: "lang", Value: "en-UK"})
+		assert.Equal(t, "en-UK", server.NegotiateLanguage(request).Name())
+
+		request = NewRequest(httptest.NewRequest(http.MethodGet, "/test", nil))
+		request.User = &testLanguageUser{lang: "en-UK"}
+		assert.Equal(t, "en-UK", server.NegotiateLanguage(request).Name())
+
+		request.User = &testLanguageUser{lang: "fr-FR"} // Not available
+		request.Header().Set("Accept-Language", "en")
+		assert.Equal(t, "en-US", server.NegotiateLanguage(request).Name())
+	})
+
+	t.Run("custom_resolvers", func(t *testing.T) {
+		server, err := New(Options{
+			Config: config.LoadDefault(),
+			LanguageResolvers: []LanguageResolver{
+				LanguageResolverFunc(func(request *Request) string { return request.RouteParams["lang"] }),
+				&HeaderLanguageResolver{},
+			},
+		})
+		require.NoError(t, err)
+
+		request := NewRequest(httptest.NewRequest(http.MethodGet, "/test?lang=en-UK", nil))
+		assert.Equal(t, "en-US", server.NegotiateLanguage(request).Name())
+
+		request.RouteParams = map[string]string{"lang": "en-UK"}
+		request.Header().Set("Accept-Language", "en-US")
+		assert.Equal(t, "en-UK", server.NegotiateLanguage(request).Name())
+	})
+
+	t.Run("no_resolvers", func(t *testing.T) {
+		server, err := New(Options{Config: config.LoadDefault(), LanguageResolvers: []LanguageResolver{}})
+		require.NoError(t, err)
+
+		request := NewRequest(httptest.NewRequest(http.MethodGet, "/test?lang=en-UK", nil))
+		request.Header().Set("Accept-Language", "en-UK")
+		assert.Equal(t, "en-US", server.NegotiateLanguage(request).Name())
+	})
+}
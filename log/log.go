@@ -2,10 +2,11 @@ package log
 
 import (
 	"io"
-	"log/slog"
+	stdslog "log/slog"
 
 	"github.com/samber/lo"
 	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/slog"
 	"goyave.dev/goyave/v5/util/errors"
 )
 
@@ -26,7 +27,7 @@ type Context struct {
 // like Common Log Format or Combined Log Format.
 // The second returned value is a slice of structured logging attributes.
 // These attributes are ignored in dev mode (`app.debug = true`) to avoid clutter.
-type Formatter func(ctx *Context) (message string, attributes []slog.Attr)
+type Formatter func(ctx *Context) (message string, attributes []stdslog.Attr)
 
 // Writer chained writer keeping response body in memory.
 // Used for loggin in common format.
@@ -83,12 +84,16 @@ func (w *Writer) Close() error {
 	}
 	message, attrs := w.formatter(ctx)
 
+	// The request context attributes (such as the request ID) are always added
+	// so access logs can be correlated with the other logs of the request.
+	contextAttrs := slog.AttrsFromContext(w.request.Context())
 	if w.Config().GetBool("app.debug") {
 		// In dev mode, we omit the details to avoid clutter. The message itself is enough.
-		w.Logger().Info(message)
+		attrs = contextAttrs
 	} else {
-		w.Logger().Info(message, lo.Map(attrs, func(a slog.Attr, _ int) any { return a })...)
+		attrs = append(attrs, contextAttrs...)
 	}
+	w.Logger().Info(message, lo.Map(attrs, func(a stdslog.Attr, _ int) any { return a })...)
 
 	if wr, ok := w.writer.(io.Closer); ok {
 		return wr.Close()
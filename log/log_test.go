@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	stdslog "log/slog"
 	"net/http"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -67,6 +69,25 @@ func TestWriter(t *testing.T) {
 		)
 	})
 
+	t.Run("Write_context_attrs", func(t *testing.T) {
+		for _, debug := range []bool{false, true} {
+			cfg := config.LoadDefault()
+			cfg.Set("app.debug", debug)
+			buffer := bytes.NewBufferString("")
+			server := testutil.NewTestServerWithOptions(t, goyave.Options{Config: cfg, Logger: slog.New(slog.NewHandler(false, buffer))})
+			req := server.NewTestRequest(http.MethodGet, "/log", nil)
+			req.WithContext(slog.ContextWithAttrs(req.Context(), stdslog.String("requestId", "abc")))
+			resp, _ := server.NewTestResponse(req)
+
+			writer := NewWriter(server.Server, resp, req, CommonLogFormatter)
+			resp.SetWriter(writer)
+			assert.NoError(t, writer.Close())
+
+			assert.Contains(t, buffer.String(), `"requestId":"abc"`)
+			assert.Equal(t, !debug, strings.Contains(buffer.String(), `"details"`))
+		}
+	})
+
 	t.Run("Write_dev_mode", func(t *testing.T) {
 		ts := lo.Must(time.Parse(time.RFC3339, "2020-03-23T13:58:26.371Z"))
 		cfg := config.LoadDefault()
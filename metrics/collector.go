@@ -0,0 +1,293 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets the default histogram buckets, tailored to measure the
+// duration of HTTP requests or database queries in seconds.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// ExponentialBuckets returns `count` buckets, the first bucket having the upper
+// bound `start` and each following bucket's upper bound being `factor` times the previous one.
+// Panics if `start` is not positive, if `factor` is lower or equal to 1 or if `count` is lower than 1.
+func ExponentialBuckets(start, factor float64, count int) []float64 {
+	if start <= 0 || factor <= 1 || count < 1 {
+		panic(fmt.Errorf("metrics: invalid exponential buckets (start: %v, factor: %v, count: %d)", start, factor, count))
+	}
+	buckets := make([]float64, count)
+	for i := range buckets {
+		buckets[i] = start
+		start *= factor
+	}
+	return buckets
+}
+
+type collector interface {
+	describe() *desc
+	write(buf *bytes.Buffer)
+}
+
+// desc the description of a metric family.
+type desc struct {
+	name       string
+	help       string
+	typ        string
+	labelNames []string
+}
+
+func (d *desc) describe() *desc {
+	return d
+}
+
+func (d *desc) writeHeader(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s %s\n", d.name, escapeHelp(d.help), d.name, d.typ)
+}
+
+func (d *desc) seriesKey(labelValues []string) string {
+	if len(labelValues) != len(d.labelNames) {
+		panic(fmt.Errorf("metrics: %s: expected %d label values, got %d", d.name, len(d.labelNames), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+// series a single time series: a set of label values and its value(s).
+type series[T any] struct {
+	labelValues []string
+	value       T
+}
+
+// vec holds all the series of a metric family, identified by their label values.
+type vec[T any] struct {
+	desc
+	series map[string]*series[T]
+	mu     sync.Mutex
+}
+
+func newVec[T any](d desc) vec[T] {
+	return vec[T]{desc: d, series: map[string]*series[T]{}}
+}
+
+// update calls the given function with the value of the series identified by the given
+// label values, creating it using `init` if it doesn't exist yet.
+func (v *vec[T]) update(labelValues []string, init func() T, f func(value *T)) {
+	key := v.seriesKey(labelValues)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	s, ok := v.series[key]
+	if !ok {
+		s = &series[T]{labelValues: slices.Clone(labelValues), value: init()}
+		v.series[key] = s
+	}
+	f(&s.value)
+}
+
+// get returns the value of the series identified by the given label values,
+// or the zero value if it doesn't exist.
+func (v *vec[T]) get(labelValues []string) T {
+	key := v.seriesKey(labelValues)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	var value T
+	if s, ok := v.series[key]; ok {
+		value = s.value
+	}
+	return value
+}
+
+// sorted returns a snapshot of all the series, sorted by label values.
+func (v *vec[T]) sorted(clone func(T) T) []*series[T] {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	keys := make([]string, 0, len(v.series))
+	for k := range v.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	result := make([]*series[T], 0, len(keys))
+	for _, k := range keys {
+		s := v.series[k]
+		result = append(result, &series[T]{labelValues: s.labelValues, value: clone(s.value)})
+	}
+	return result
+}
+
+func zero() float64 { return 0 }
+
+func identity[T any](v T) T { return v }
+
+// Counter a metric family whose values can only increase.
+// Counters are safe for concurrent use.
+type Counter struct {
+	vec[float64]
+}
+
+// Inc increments by 1 the counter identified by the given label values.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds the given value to the counter identified by the given label values.
+// Panics if the value is negative.
+func (c *Counter) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		panic(fmt.Errorf("metrics: %s: counter cannot decrease", c.name))
+	}
+	c.update(labelValues, zero, func(v *float64) { *v += value })
+}
+
+// Value returns the current value of the counter identified by the given label values.
+func (c *Counter) Value(labelValues ...string) float64 {
+	return c.get(labelValues)
+}
+
+func (c *Counter) write(buf *bytes.Buffer) {
+	c.writeHeader(buf)
+	for _, s := range c.sorted(identity[float64]) {
+		writeSample(buf, c.name, c.labelNames, s.labelValues, "", "", s.value)
+	}
+}
+
+// Gauge a metric family whose values can arbitrarily go up and down.
+// Gauges are safe for concurrent use.
+type Gauge struct {
+	vec[float64]
+}
+
+// Set the value of the gauge identified by the given label values.
+func (g *Gauge) Set(value float64, labelValues ...string) {
+	g.update(labelValues, zero, func(v *float64) { *v = value })
+}
+
+// Add adds the given value (which can be negative) to the gauge identified by the given label values.
+func (g *Gauge) Add(value float64, labelValues ...string) {
+	g.update(labelValues, zero, func(v *float64) { *v += value })
+}
+
+// Inc increments by 1 the gauge identified by the given label values.
+func (g *Gauge) Inc(labelValues ...string) {
+	g.Add(1, labelValues...)
+}
+
+// Dec decrements by 1 the gauge identified by the given label values.
+func (g *Gauge) Dec(labelValues ...string) {
+	g.Add(-1, labelValues...)
+}
+
+// Value returns the current value of the gauge identified by the given label values.
+func (g *Gauge) Value(labelValues ...string) float64 {
+	return g.get(labelValues)
+}
+
+func (g *Gauge) write(buf *bytes.Buffer) {
+	g.writeHeader(buf)
+	for _, s := range g.sorted(identity[float64]) {
+		writeSample(buf, g.name, g.labelNames, s.labelValues, "", "", s.value)
+	}
+}
+
+// HistogramValue the state of a single histogram series.
+type HistogramValue struct {
+	// Buckets the non-cumulative count of observations for each bucket.
+	// The last element counts the observations greater than the highest bucket.
+	Buckets []uint64
+	Sum     float64
+	Count   uint64
+}
+
+func (h HistogramValue) clone() HistogramValue {
+	h.Buckets = slices.Clone(h.Buckets)
+	return h
+}
+
+// Histogram a metric family counting observations in configurable buckets.
+// Histograms are safe for concurrent use.
+type Histogram struct {
+	vec[HistogramValue]
+	buckets []float64
+}
+
+// Observe adds an observation to the histogram identified by the given label values.
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	i := sort.SearchFloat64s(h.buckets, value)
+	h.update(labelValues, h.newValue, func(v *HistogramValue) {
+		v.Buckets[i]++
+		v.Sum += value
+		v.Count++
+	})
+}
+
+// Value returns a copy of the current state of the histogram identified by the given label values.
+func (h *Histogram) Value(labelValues ...string) HistogramValue {
+	return h.get(labelValues).clone()
+}
+
+func (h *Histogram) newValue() HistogramValue {
+	return HistogramValue{Buckets: make([]uint64, len(h.buckets)+1)}
+}
+
+func (h *Histogram) write(buf *bytes.Buffer) {
+	h.writeHeader(buf)
+	for _, s := range h.sorted(HistogramValue.clone) {
+		cumulative := uint64(0)
+		for i, upperBound := range h.buckets {
+			cumulative += s.value.Buckets[i]
+			writeSample(buf, h.name+"_bucket", h.labelNames, s.labelValues, "le", formatFloat(upperBound), float64(cumulative))
+		}
+		writeSample(buf, h.name+"_bucket", h.labelNames, s.labelValues, "le", "+Inf", float64(s.value.Count))
+		writeSample(buf, h.name+"_sum", h.labelNames, s.labelValues, "", "", s.value.Sum)
+		writeSample(buf, h.name+"_count", h.labelNames, s.labelValues, "", "", float64(s.value.Count))
+	}
+}
+
+func writeSample(buf *bytes.Buffer, name string, labelNames, labelValues []string, extraLabel, extraValue string, value float64) {
+	buf.WriteString(name)
+	if len(labelNames) > 0 || extraLabel != "" {
+		buf.WriteByte('{')
+		for i, l := range labelNames {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			fmt.Fprintf(buf, `%s="%s"`, l, escapeLabelValue(labelValues[i]))
+		}
+		if extraLabel != "" {
+			if len(labelNames) > 0 {
+				buf.WriteByte(',')
+			}
+			fmt.Fprintf(buf, `%s="%s"`, extraLabel, extraValue)
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(formatFloat(value))
+	buf.WriteByte('\n')
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	}
+	return fmt.Sprint(f)
+}
+
+var helpReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeHelp(s string) string {
+	return helpReplacer.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelValueReplacer.Replace(s)
+}
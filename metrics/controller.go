@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"net/http"
+
+	"goyave.dev/goyave/v5"
+)
+
+// Controller exposes the metrics of a registry using the Prometheus text exposition format,
+// so they can be scraped by any compatible monitoring system.
+//
+// The route serving the metrics is not protected. Make sure it is not publicly reachable
+// or add an authentication middleware to it.
+type Controller struct {
+	goyave.Component
+
+	// Registry the registry containing the metrics to expose.
+	Registry *Registry
+
+	// URI of the route serving the metrics. Defaults to "/metrics".
+	URI string
+}
+
+// NewController create a new controller exposing the metrics of the given registry.
+func NewController(registry *Registry) *Controller {
+	return &Controller{
+		Registry: registry,
+	}
+}
+
+// RegisterRoutes register the route serving the metrics on the given router.
+// The route is named "metrics".
+func (c *Controller) RegisterRoutes(router *goyave.Router) {
+	uri := c.URI
+	if uri == "" {
+		uri = "/metrics"
+	}
+	router.Get(uri, c.Serve).Name("metrics")
+}
+
+// Serve GET handler writing the metrics in the Prometheus text exposition format.
+func (c *Controller) Serve(response *goyave.Response, _ *goyave.Request) {
+	response.Header().Set("Content-Type", ContentType)
+	response.WriteHeader(http.StatusOK)
+	if _, err := c.Registry.WriteTo(response); err != nil {
+		c.Logger().Error(err)
+	}
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/config"
+	"goyave.dev/goyave/v5/util/testutil"
+)
+
+func TestController(t *testing.T) {
+	registry := NewRegistry()
+	registry.Counter("test_total", "Test counter.").Inc()
+
+	prepare := func(t *testing.T, controller *Controller) *testutil.TestServer {
+		server := testutil.NewTestServerWithOptions(t, goyave.Options{Config: config.LoadDefault()})
+		server.RegisterRoutes(func(_ *goyave.Server, router *goyave.Router) {
+			router.Controller(controller)
+		})
+		return server
+	}
+
+	server := prepare(t, NewController(registry))
+	resp := server.TestRequest(httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, ContentType, resp.Header.Get("Content-Type"))
+	assert.Equal(t, "# HELP test_total Test counter.\n# TYPE test_total counter\ntest_total 1\n", string(body))
+
+	t.Run("custom_uri", func(t *testing.T) {
+		controller := NewController(registry)
+		controller.URI = "/internal/metrics"
+		server := prepare(t, controller)
+		resp := server.TestRequest(httptest.NewRequest(http.MethodGet, "/internal/metrics", nil))
+		assert.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
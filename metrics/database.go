@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	errorutil "goyave.dev/goyave/v5/util/errors"
+)
+
+// Names of the database metrics recorded by the GORM plugin.
+const (
+	DBQueryDurationSeconds = "db_query_duration_seconds"
+	DBQueryErrorsTotal     = "db_query_errors_total"
+)
+
+const (
+	metricsCallbackBeforeName = "goyave:metrics_before"
+	metricsCallbackAfterName  = "goyave:metrics_after"
+	metricsStartKey           = "goyave:metrics_start"
+)
+
+// GORMPlugin GORM plugin recording the duration of SQL queries and the number of
+// failed queries, labeled by operation ("create", "query", "update", "delete", "row", "raw")
+// and table. `gorm.ErrRecordNotFound` is not counted as an error.
+//
+// The plugin can be used alongside `database.TimeoutPlugin`.
+//
+// **Example:**
+//
+//	db.Use(metrics.NewGORMPlugin(registry))
+type GORMPlugin struct {
+	duration *Histogram
+	errors   *Counter
+}
+
+// NewGORMPlugin creates a new GORM plugin registering its metrics in the given registry.
+func NewGORMPlugin(registry *Registry) *GORMPlugin {
+	return &GORMPlugin{
+		duration: registry.Histogram(DBQueryDurationSeconds, "Duration of SQL queries in seconds.", DefaultBuckets, "operation", "table"),
+		errors:   registry.Counter(DBQueryErrorsTotal, "Total number of failed SQL queries.", "operation", "table"),
+	}
+}
+
+// Name returns the name of the plugin
+func (p *GORMPlugin) Name() string {
+	return "goyave:metrics"
+}
+
+// Initialize registers the callbacks for all operations.
+func (p *GORMPlugin) Initialize(db *gorm.DB) error {
+	createCallback := db.Callback().Create()
+	if err := createCallback.Before("*").Register(metricsCallbackBeforeName, p.before); err != nil {
+		return errorutil.New(err)
+	}
+	if err := createCallback.After("*").Register(metricsCallbackAfterName, p.after("create")); err != nil {
+		return errorutil.New(err)
+	}
+
+	queryCallback := db.Callback().Query()
+	if err := queryCallback.Before("*").Register(metricsCallbackBeforeName, p.before); err != nil {
+		return errorutil.New(err)
+	}
+	if err := queryCallback.After("*").Register(metricsCallbackAfterName, p.after("query")); err != nil {
+		return errorutil.New(err)
+	}
+
+	updateCallback := db.Callback().Update()
+	if err := updateCallback.Before("*").Register(metricsCallbackBeforeName, p.before); err != nil {
+		return errorutil.New(err)
+	}
+	if err := updateCallback.After("*").Register(metricsCallbackAfterName, p.after("update")); err != nil {
+		return errorutil.New(err)
+	}
+
+	deleteCallback := db.Callback().Delete()
+	if err := deleteCallback.Before("*").Register(metricsCallbackBeforeName, p.before); err != nil {
+		return errorutil.New(err)
+	}
+	if err := deleteCallback.After("*").Register(metricsCallbackAfterName, p.after("delete")); err != nil {
+		return errorutil.New(err)
+	}
+
+	rowCallback := db.Callback().Row()
+	if err := rowCallback.Before("*").Register(metricsCallbackBeforeName, p.before); err != nil {
+		return errorutil.New(err)
+	}
+	if err := rowCallback.After("*").Register(metricsCallbackAfterName, p.after("row")); err != nil {
+		return errorutil.New(err)
+	}
+
+	rawCallback := db.Callback().Raw()
+	if err := rawCallback.Before("*").Register(metricsCallbackBeforeName, p.before); err != nil {
+		return errorutil.New(err)
+	}
+	if err := rawCallback.After("*").Register(metricsCallbackAfterName, p.after("raw")); err != nil {
+		return errorutil.New(err)
+	}
+	return nil
+}
+
+func (p *GORMPlugin) before(db *gorm.DB) {
+	db.InstanceSet(metricsStartKey, time.Now())
+}
+
+func (p *GORMPlugin) after(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(metricsStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		table := db.Statement.Table
+		p.duration.Observe(time.Since(start).Seconds(), operation, table)
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			p.errors.Inc(operation, table)
+		}
+	}
+}
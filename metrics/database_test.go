@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"goyave.dev/goyave/v5/database"
+)
+
+type testModel struct {
+	Name string
+	ID   uint
+}
+
+func TestGORMPlugin(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:metrics_test?mode=memory"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		assert.NoError(t, sqlDB.Close())
+	})
+
+	registry := NewRegistry()
+	plugin := NewGORMPlugin(registry)
+	assert.Equal(t, "goyave:metrics", plugin.Name())
+	require.NoError(t, db.Use(plugin))
+	require.NoError(t, db.Use(&database.TimeoutPlugin{ReadTimeout: time.Second, WriteTimeout: time.Second}))
+
+	require.NoError(t, db.AutoMigrate(&testModel{}))
+	require.NoError(t, db.Create(&testModel{Name: "a"}).Error)
+	var models []*testModel
+	require.NoError(t, db.Find(&models).Error)
+	assert.ErrorIs(t, db.Where("id = ?", 100).First(&testModel{}).Error, gorm.ErrRecordNotFound)
+	assert.Error(t, db.Table("unknown").Find(&models).Error)
+
+	duration := registry.Histogram(DBQueryDurationSeconds, "", nil, "operation", "table")
+	errors := registry.Counter(DBQueryErrorsTotal, "", "operation", "table")
+
+	assert.Equal(t, uint64(1), duration.Value("create", "test_models").Count)
+	assert.Equal(t, uint64(2), duration.Value("query", "test_models").Count)
+	assert.Equal(t, uint64(1), duration.Value("query", "unknown").Count)
+	assert.Equal(t, float64(0), errors.Value("query", "test_models"))
+	assert.Equal(t, float64(1), errors.Value("query", "unknown"))
+}
@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/util/errors"
+)
+
+// Names of the HTTP metrics recorded by the middleware.
+const (
+	HTTPRequestsTotal          = "http_requests_total"
+	HTTPRequestDurationSeconds = "http_request_duration_seconds"
+	HTTPResponseSizeBytes      = "http_response_size_bytes"
+)
+
+// SizeBuckets the histogram buckets used for response sizes (100B to 100MB).
+var SizeBuckets = ExponentialBuckets(100, 10, 7)
+
+// Middleware records the number of requests, their duration and the size of the
+// responses. The metrics are labeled by method, route and response status.
+//
+// The route label is the name of the matched route, or its full URI if the route
+// has no name (e.g. "/users/{userId:[0-9]+}"). The raw request path is never used
+// to keep the cardinality of the metrics low.
+//
+// The metrics are recorded when the response is finalized, so the status and the
+// size written by status handlers are taken into account.
+//
+// This middleware should be registered as a global middleware.
+//
+// **Example:**
+//
+//	registry := metrics.NewRegistry()
+//	router.GlobalMiddleware(metrics.NewMiddleware(registry))
+type Middleware struct {
+	goyave.Component
+
+	requests *Counter
+	duration *Histogram
+	size     *Histogram
+}
+
+// NewMiddleware creates a new metrics middleware registering its metrics in the given registry.
+func NewMiddleware(registry *Registry) *Middleware {
+	labels := []string{"method", "route", "status"}
+	return &Middleware{
+		requests: registry.Counter(HTTPRequestsTotal, "Total number of HTTP requests.", labels...),
+		duration: registry.Histogram(HTTPRequestDurationSeconds, "Duration of HTTP requests in seconds.", DefaultBuckets, labels...),
+		size:     registry.Histogram(HTTPResponseSizeBytes, "Size of HTTP responses in bytes.", SizeBuckets, labels...),
+	}
+}
+
+// Handle adds the metrics chained writer to the response.
+func (m *Middleware) Handle(next goyave.Handler) goyave.Handler {
+	return func(response *goyave.Response, request *goyave.Request) {
+		response.SetWriter(&writer{
+			writer:     response.Writer(),
+			middleware: m,
+			request:    request,
+			response:   response,
+		})
+		next(response, request)
+	}
+}
+
+// RouteLabel returns the name of the given route, or its full URI if it has no name.
+func RouteLabel(route *goyave.Route) string {
+	if route == nil {
+		return ""
+	}
+	if name := route.GetName(); name != "" {
+		return name
+	}
+	return route.GetFullURI()
+}
+
+// writer chained writer counting the response size and recording the
+// metrics when closed.
+type writer struct {
+	writer     io.Writer
+	middleware *Middleware
+	request    *goyave.Request
+	response   *goyave.Response
+	length     int
+}
+
+var _ io.Closer = (*writer)(nil)
+var _ goyave.PreWriter = (*writer)(nil)
+
+// PreWrite calls PreWrite on the child writer if it implements PreWriter.
+func (w *writer) PreWrite(b []byte) {
+	if pr, ok := w.writer.(goyave.PreWriter); ok {
+		pr.PreWrite(b)
+	}
+}
+
+// Write writes the data to the child writer and keeps its length in memory.
+func (w *writer) Write(b []byte) (int, error) {
+	n, err := w.writer.Write(b)
+	w.length += n
+	return n, errors.New(err)
+}
+
+// Close records the metrics and closes the child writer.
+func (w *writer) Close() error {
+	status := w.response.GetStatus()
+	if status == 0 {
+		status = http.StatusOK
+	}
+	labels := []string{w.request.Method(), RouteLabel(w.request.Route), strconv.Itoa(status)}
+	w.middleware.requests.Inc(labels...)
+	w.middleware.duration.Observe(time.Since(w.request.Now).Seconds(), labels...)
+	w.middleware.size.Observe(float64(w.length), labels...)
+
+	if wr, ok := w.writer.(io.Closer); ok {
+		return errors.New(wr.Close())
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/config"
+	"goyave.dev/goyave/v5/util/testutil"
+)
+
+func TestMiddleware(t *testing.T) {
+	registry := NewRegistry()
+	server := testutil.NewTestServerWithOptions(t, goyave.Options{Config: config.LoadDefault()})
+	server.RegisterRoutes(func(_ *goyave.Server, router *goyave.Router) {
+		router.GlobalMiddleware(NewMiddleware(registry))
+		router.Get("/users/{userId:[0-9]+}", func(response *goyave.Response, _ *goyave.Request) {
+			response.String(http.StatusOK, "hello")
+		})
+		router.Get("/named", func(response *goyave.Response, _ *goyave.Request) {
+			response.Status(http.StatusNoContent)
+		}).Name("named-route")
+		router.Get("/error", func(response *goyave.Response, _ *goyave.Request) {
+			response.Status(http.StatusForbidden)
+		})
+	})
+
+	request := func(uri string) {
+		resp := server.TestRequest(httptest.NewRequest(http.MethodGet, uri, nil))
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	request("/users/1")
+	request("/users/2")
+	request("/named")
+	request("/error")
+	request("/not-found")
+
+	requests := registry.Counter(HTTPRequestsTotal, "", "method", "route", "status")
+	assert.Equal(t, float64(2), requests.Value(http.MethodGet, "/users/{userId:[0-9]+}", "200"))
+	assert.Equal(t, float64(1), requests.Value(http.MethodGet, "named-route", "204"))
+	assert.Equal(t, float64(1), requests.Value(http.MethodGet, "/error", "403"))
+	assert.Equal(t, float64(1), requests.Value(http.MethodGet, goyave.RouteNotFound, "404"))
+
+	duration := registry.Histogram(HTTPRequestDurationSeconds, "", nil, "method", "route", "status")
+	assert.Equal(t, uint64(2), duration.Value(http.MethodGet, "/users/{userId:[0-9]+}", "200").Count)
+
+	size := registry.Histogram(HTTPResponseSizeBytes, "", nil, "method", "route", "status")
+	value := size.Value(http.MethodGet, "/users/{userId:[0-9]+}", "200")
+	assert.Equal(t, float64(10), value.Sum)
+	assert.Equal(t, uint64(2), value.Buckets[0])
+
+	// Status handler writes the body
+	errorSize := size.Value(http.MethodGet, "/error", "403")
+	assert.Equal(t, uint64(1), errorSize.Count)
+	assert.Greater(t, errorSize.Sum, float64(0))
+}
+
+func TestRouteLabel(t *testing.T) {
+	assert.Equal(t, "", RouteLabel(nil))
+	server := testutil.NewTestServerWithOptions(t, goyave.Options{Config: config.LoadDefault()})
+	router := goyave.NewRouter(server.Server)
+	assert.Equal(t, "/users/{id}", RouteLabel(router.Get("/users/{id}", nil)))
+	assert.Equal(t, "users.index", RouteLabel(router.Get("/users", nil).Name("users.index")))
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"sort"
+	"sync"
+
+	"goyave.dev/goyave/v5/util/errors"
+)
+
+// ContentType the content type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+var (
+	metricNameRegex = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNameRegex  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// Registry holds metric families and writes them in the Prometheus text exposition format.
+// A registry is safe for concurrent use.
+type Registry struct {
+	collectors map[string]collector
+	mu         sync.RWMutex
+}
+
+// NewRegistry creates a new empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		collectors: map[string]collector{},
+	}
+}
+
+// Counter returns the counter identified by the given name, registering it if it
+// doesn't exist yet.
+//
+// Panics if the name or the label names are invalid, or if a metric with the same name
+// but a different type or different label names is already registered.
+func (r *Registry) Counter(name, help string, labelNames ...string) *Counter {
+	return register(r, desc{name: name, help: help, typ: "counter", labelNames: labelNames}, func(d desc) *Counter {
+		return &Counter{vec: newVec[float64](d)}
+	})
+}
+
+// Gauge returns the gauge identified by the given name, registering it if it
+// doesn't exist yet.
+//
+// Panics if the name or the label names are invalid, or if a metric with the same name
+// but a different type or different label names is already registered.
+func (r *Registry) Gauge(name, help string, labelNames ...string) *Gauge {
+	return register(r, desc{name: name, help: help, typ: "gauge", labelNames: labelNames}, func(d desc) *Gauge {
+		return &Gauge{vec: newVec[float64](d)}
+	})
+}
+
+// Histogram returns the histogram identified by the given name, registering it if it
+// doesn't exist yet. If `buckets` is empty, `DefaultBuckets` are used. The buckets of an
+// existing histogram are not changed.
+//
+// Panics if the name or the label names are invalid, if the buckets are not sorted in
+// increasing order, or if a metric with the same name but a different type or different
+// label names is already registered.
+func (r *Registry) Histogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			panic(fmt.Errorf("metrics: %s: buckets must be in increasing order", name))
+		}
+	}
+	if slices.Contains(labelNames, "le") {
+		panic(fmt.Errorf("metrics: %s: \"le\" is a reserved label name for histograms", name))
+	}
+	return register(r, desc{name: name, help: help, typ: "histogram", labelNames: labelNames}, func(d desc) *Histogram {
+		return &Histogram{vec: newVec[HistogramValue](d), buckets: slices.Clone(buckets)}
+	})
+}
+
+func register[T collector](r *Registry, d desc, create func(desc) T) T {
+	if !metricNameRegex.MatchString(d.name) {
+		panic(fmt.Errorf("metrics: invalid metric name %q", d.name))
+	}
+	for _, l := range d.labelNames {
+		if !labelNameRegex.MatchString(l) {
+			panic(fmt.Errorf("metrics: %s: invalid label name %q", d.name, l))
+		}
+	}
+	d.labelNames = slices.Clone(d.labelNames)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.collectors[d.name]; ok {
+		c, ok := existing.(T)
+		if !ok || !slices.Equal(existing.describe().labelNames, d.labelNames) {
+			panic(fmt.Errorf("metrics: %s is already registered with a different type or different labels", d.name))
+		}
+		return c
+	}
+	c := create(d)
+	r.collectors[d.name] = c
+	return c
+}
+
+// WriteTo writes all the metrics of the registry to the given writer using the
+// Prometheus text exposition format. Metric families are sorted by name.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.collectors))
+	for name := range r.collectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	collectors := make([]collector, 0, len(names))
+	for _, name := range names {
+		collectors = append(collectors, r.collectors[name])
+	}
+	r.mu.RUnlock()
+
+	buf := &bytes.Buffer{}
+	for _, c := range collectors {
+		c.write(buf)
+	}
+	n, err := w.Write(buf.Bytes())
+	return int64(n), errors.New(err)
+}
@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("WriteTo", func(t *testing.T) {
+		registry := NewRegistry()
+		counter := registry.Counter("test_total", "Test counter.\nWith \\ escapes.", "method", "path")
+		counter.Inc("GET", "/b")
+		counter.Add(2.5, "GET", "/a")
+		counter.Inc("POST", "/a\"\n\\")
+
+		gauge := registry.Gauge("test_gauge", "Test gauge.")
+		gauge.Set(3)
+		gauge.Dec()
+
+		histogram := registry.Histogram("test_duration_seconds", "Test histogram.", []float64{0.1, 1}, "route")
+		histogram.Observe(0.05, "home")
+		histogram.Observe(0.1, "home")
+		histogram.Observe(0.5, "home")
+		histogram.Observe(5, "home")
+
+		buf := &bytes.Buffer{}
+		n, err := registry.WriteTo(buf)
+		require.NoError(t, err)
+		assert.Equal(t, int64(buf.Len()), n)
+
+		expected := `# HELP test_duration_seconds Test histogram.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{route="home",le="0.1"} 2
+test_duration_seconds_bucket{route="home",le="1"} 3
+test_duration_seconds_bucket{route="home",le="+Inf"} 4
+test_duration_seconds_sum{route="home"} 5.65
+test_duration_seconds_count{route="home"} 4
+# HELP test_gauge Test gauge.
+# TYPE test_gauge gauge
+test_gauge 2
+# HELP test_total Test counter.\nWith \\ escapes.
+# TYPE test_total counter
+test_total{method="GET",path="/a"} 2.5
+test_total{method="GET",path="/b"} 1
+test_total{method="POST",path="/a\"\n\\"} 1
+`
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("get_or_register", func(t *testing.T) {
+		registry := NewRegistry()
+		counter := registry.Counter("test_total", "help", "label")
+		assert.Same(t, counter, registry.Counter("test_total", "help", "label"))
+
+		histogram := registry.Histogram("test_histogram", "help", nil)
+		assert.Equal(t, DefaultBuckets, histogram.buckets)
+		assert.Same(t, histogram, registry.Histogram("test_histogram", "help", nil))
+
+		assert.Panics(t, func() { registry.Gauge("test_total", "help", "label") })
+		assert.Panics(t, func() { registry.Counter("test_total", "help", "other") })
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		registry := NewRegistry()
+		assert.Panics(t, func() { registry.Counter("invalid-name", "help") })
+		assert.Panics(t, func() { registry.Counter("test_total", "help", "invalid-label") })
+		assert.Panics(t, func() { registry.Histogram("test_histogram", "help", []float64{1, 0.5}) })
+		assert.Panics(t, func() { registry.Histogram("test_histogram", "help", nil, "le") })
+	})
+}
+
+func TestCollectors(t *testing.T) {
+	registry := NewRegistry()
+
+	t.Run("Counter", func(t *testing.T) {
+		counter := registry.Counter("counter_total", "help", "label")
+		assert.Equal(t, float64(0), counter.Value("a"))
+		counter.Inc("a")
+		counter.Add(2, "a")
+		assert.Equal(t, float64(3), counter.Value("a"))
+		assert.Panics(t, func() { counter.Add(-1, "a") })
+		assert.Panics(t, func() { counter.Inc() })
+		assert.Panics(t, func() { counter.Inc("a", "b") })
+	})
+
+	t.Run("Gauge", func(t *testing.T) {
+		gauge := registry.Gauge("gauge", "help")
+		gauge.Inc()
+		gauge.Inc()
+		gauge.Add(-0.5)
+		assert.Equal(t, 1.5, gauge.Value())
+		gauge.Set(-3)
+		assert.Equal(t, float64(-3), gauge.Value())
+	})
+
+	t.Run("Histogram", func(t *testing.T) {
+		histogram := registry.Histogram("histogram", "help", []float64{1, 2})
+		assert.Equal(t, HistogramValue{}, histogram.Value())
+		histogram.Observe(1)
+		histogram.Observe(1.5)
+		histogram.Observe(3)
+		value := histogram.Value()
+		assert.Equal(t, HistogramValue{Buckets: []uint64{1, 1, 1}, Sum: 5.5, Count: 3}, value)
+
+		value.Buckets[0] = 10 // Value returns a copy
+		assert.Equal(t, uint64(1), histogram.Value().Buckets[0])
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		counter := registry.Counter("concurrent_total", "help")
+		wg := sync.WaitGroup{}
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				counter.Inc()
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, float64(100), counter.Value())
+	})
+}
+
+func TestExponentialBuckets(t *testing.T) {
+	assert.Equal(t, []float64{1, 10, 100}, ExponentialBuckets(1, 10, 3))
+	assert.Panics(t, func() { ExponentialBuckets(0, 10, 3) })
+	assert.Panics(t, func() { ExponentialBuckets(1, 1, 3) })
+	assert.Panics(t, func() { ExponentialBuckets(1, 10, 0) })
+}
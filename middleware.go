@@ -81,7 +81,7 @@ func (m *recoveryMiddleware) Handle(next Handler) Handler {
 		defer func() {
 			if err := recover(); err != nil || panicked {
 				e := errors.NewSkip(err, 4).(*errors.Error) // Skipped: runtime.Callers, NewSkip, this func, runtime.panic
-				m.Logger().Error(e)
+				m.Logger().ErrorCtx(request.Context(), e)
 				response.err = e
 				response.status = http.StatusInternalServerError // Force status override
 			}
@@ -94,13 +94,12 @@ func (m *recoveryMiddleware) Handle(next Handler) Handler {
 
 // languageMiddleware is a middleware that sets the language of a request.
 //
-// Uses the "Accept-Language" header to determine which language to use. If
-// the header is not set or the language is not available, uses the default
-// language as fallback.
+// Uses the language resolvers of the server (by default only the "Accept-Language"
+// header) to determine which language to use. If none of the resolved languages
+// are available, uses the default language as fallback.
 //
-// If "*" is provided, the default language will be used.
-// If multiple languages are given, the first available language will be used,
-// and if none are available, the default language will be used.
+// If "*" is provided, the next resolver is used.
+// If multiple languages are given, the first available language will be used.
 // If no variant is given (for example "en"), the first available variant will be used.
 // For example, if "en-US" and "en-UK" are available and the request accepts "en",
 // "en-US" will be used.
@@ -110,11 +109,7 @@ type languageMiddleware struct {
 
 func (m *languageMiddleware) Handle(next Handler) Handler {
 	return func(response *Response, request *Request) {
-		if header := request.Header().Get("Accept-Language"); len(header) > 0 {
-			request.Lang = m.Lang().DetectLanguage(header)
-		} else {
-			request.Lang = m.Lang().GetDefault()
-		}
+		request.Lang = m.Server().NegotiateLanguage(request)
 		next(response, request)
 	}
 }
@@ -125,10 +120,16 @@ func (m *languageMiddleware) Handle(next Handler) Handler {
 // `validation.Errors` returned by the validator.
 // This data can then be used in a status handler.
 // This middleware requires the parse middleware.
+//
+// If the validation passes and a binder is set, the validated data is converted
+// to a DTO and stored in the request's `Extra`. Conversion failures are treated as
+// validation errors.
 type validateRequestMiddleware struct {
 	Component
-	BodyRules  RuleSetFunc
-	QueryRules RuleSetFunc
+	BodyRules   RuleSetFunc
+	QueryRules  RuleSetFunc
+	BodyBinder  binder
+	QueryBinder binder
 }
 
 func (m *validateRequestMiddleware) Handle(next Handler) Handler {
@@ -194,6 +195,23 @@ func (m *validateRequestMiddleware) Handle(next Handler) Handler {
 			return
 		}
 
+		if queryErrsBag == nil && m.QueryBinder != nil {
+			if query, err := m.QueryBinder(r.Query); err != nil {
+				queryErrsBag = bindingErrors(r, err)
+				r.Extra[ExtraQueryValidationError{}] = queryErrsBag
+			} else {
+				r.Extra[ExtraBoundQuery{}] = query
+			}
+		}
+		if errsBag == nil && m.BodyBinder != nil {
+			if body, err := m.BodyBinder(r.Data); err != nil {
+				errsBag = bindingErrors(r, err)
+				r.Extra[ExtraValidationError{}] = errsBag
+			} else {
+				r.Extra[ExtraBoundBody{}] = body
+			}
+		}
+
 		if errsBag != nil || queryErrsBag != nil {
 			response.Status(http.StatusUnprocessableEntity)
 			return
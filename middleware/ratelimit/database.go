@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"goyave.dev/goyave/v5/util/errors"
+)
+
+// Record the database model used by `DatabaseStore`. Use it to create the table:
+//
+//	db.AutoMigrate(&ratelimit.Record{})
+type Record struct {
+	Time      time.Time
+	ExpiresAt time.Time `gorm:"index"`
+	Key       string    `gorm:"column:limit_key;primaryKey;size:255"`
+	Value     float64
+	Version   int64
+}
+
+// TableName returns "rate_limits".
+func (Record) TableName() string {
+	return "rate_limits"
+}
+
+// DatabaseStore a `Store` persisting states in a SQL database using the `Record` model.
+// It allows multiple instances of the application to share their quotas.
+//
+// Updates use optimistic locking: if the state was modified concurrently, the update
+// is retried up to `MaxRetries` times.
+//
+// Expired records are not deleted automatically. Call `DeleteExpired` periodically.
+type DatabaseStore struct {
+	DB *gorm.DB
+
+	// MaxRetries the maximum number of attempts when the state is updated concurrently.
+	// Defaults to 5 if zero.
+	MaxRetries int
+}
+
+// NewDatabaseStore creates a new store using the given database connection.
+func NewDatabaseStore(db *gorm.DB) *DatabaseStore {
+	return &DatabaseStore{DB: db}
+}
+
+// Update atomically loads the state identified by the given key, passes it
+// to the update function and saves the returned state.
+func (s *DatabaseStore) Update(ctx context.Context, key string, update func(state *State) State) error {
+	maxRetries := s.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = 5
+	}
+	db := s.DB.WithContext(ctx)
+
+	for i := 0; i < maxRetries; i++ {
+		record := &Record{}
+		res := db.Where("limit_key = ?", key).Take(record)
+		exists := true
+		if res.Error != nil {
+			if !stderrors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return errors.New(res.Error)
+			}
+			exists = false
+		}
+
+		var current *State
+		if exists {
+			current = &State{Value: record.Value, Time: record.Time, ExpiresAt: record.ExpiresAt}
+		}
+		state := update(current)
+
+		if !exists {
+			res = db.Clauses(clause.OnConflict{DoNothing: true}).Create(&Record{
+				Key:       key,
+				Value:     state.Value,
+				Time:      state.Time,
+				ExpiresAt: state.ExpiresAt,
+			})
+		} else {
+			res = db.Model(&Record{}).
+				Where("limit_key = ? AND version = ?", key, record.Version).
+				Updates(map[string]any{
+					"value":      state.Value,
+					"time":       state.Time,
+					"expires_at": state.ExpiresAt,
+					"version":    record.Version + 1,
+				})
+		}
+		if res.Error != nil {
+			return errors.New(res.Error)
+		}
+		if res.RowsAffected == 1 {
+			return nil
+		}
+	}
+	return errors.Errorf("ratelimit: could not update state %q: too many concurrent updates", key)
+}
+
+// DeleteExpired deletes all the records that expired before the given time.
+func (s *DatabaseStore) DeleteExpired(ctx context.Context, now time.Time) error {
+	return errors.New(s.DB.WithContext(ctx).Where("expires_at <= ?", now).Delete(&Record{}).Error)
+}
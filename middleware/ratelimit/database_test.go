@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func prepareDatabaseStore(t *testing.T) *DatabaseStore {
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&Record{}))
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		assert.NoError(t, sqlDB.Close())
+	})
+	return NewDatabaseStore(db)
+}
+
+func TestDatabaseStore(t *testing.T) {
+	t.Run("Update", func(t *testing.T) {
+		store := prepareDatabaseStore(t)
+		ctx := context.Background()
+		now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		err := store.Update(ctx, "key", func(state *State) State {
+			assert.Nil(t, state)
+			return State{Value: 1, Time: now, ExpiresAt: now.Add(time.Minute)}
+		})
+		require.NoError(t, err)
+
+		err = store.Update(ctx, "key", func(state *State) State {
+			require.NotNil(t, state)
+			assert.Equal(t, float64(1), state.Value)
+			assert.True(t, now.Equal(state.Time))
+			assert.True(t, now.Add(time.Minute).Equal(state.ExpiresAt))
+			return State{Value: 2, Time: now, ExpiresAt: now.Add(time.Minute)}
+		})
+		require.NoError(t, err)
+
+		record := &Record{}
+		require.NoError(t, store.DB.Where("limit_key = ?", "key").Take(record).Error)
+		assert.Equal(t, float64(2), record.Value)
+		assert.Equal(t, int64(1), record.Version)
+	})
+
+	t.Run("policy", func(t *testing.T) {
+		store := prepareDatabaseStore(t)
+		policy := &FixedWindow{Limit: 1, Window: time.Minute}
+		now := time.Now()
+
+		result, err := policy.Take(context.Background(), store, "key", now)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+
+		result, err = policy.Take(context.Background(), store, "key", now)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+	})
+
+	t.Run("concurrent_update", func(t *testing.T) {
+		store := prepareDatabaseStore(t)
+		store.MaxRetries = 2
+		ctx := context.Background()
+		require.NoError(t, store.Update(ctx, "key", func(_ *State) State { return State{Value: 1} }))
+
+		calls := 0
+		err := store.Update(ctx, "key", func(state *State) State {
+			calls++
+			// Simulate a concurrent update between read and write
+			require.NoError(t, store.DB.Model(&Record{}).Where("limit_key = ?", "key").Update("version", gorm.Expr("version + 1")).Error)
+			return State{Value: state.Value + 1}
+		})
+		assert.ErrorContains(t, err, "too many concurrent updates")
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("DeleteExpired", func(t *testing.T) {
+		store := prepareDatabaseStore(t)
+		ctx := context.Background()
+		now := time.Now()
+		require.NoError(t, store.Update(ctx, "expired", func(_ *State) State { return State{ExpiresAt: now.Add(-time.Second)} }))
+		require.NoError(t, store.Update(ctx, "valid", func(_ *State) State { return State{ExpiresAt: now.Add(time.Hour)} }))
+
+		require.NoError(t, store.DeleteExpired(ctx, now))
+
+		var keys []string
+		require.NoError(t, store.DB.Model(&Record{}).Pluck("limit_key", &keys).Error)
+		assert.Equal(t, []string{"valid"}, keys)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		store := prepareDatabaseStore(t)
+		require.NoError(t, store.DB.Migrator().DropTable(&Record{}))
+		err := store.Update(context.Background(), "key", func(_ *State) State { return State{} })
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,133 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"goyave.dev/goyave/v5/util/errors"
+)
+
+// Result of a rate limiting check.
+type Result struct {
+	// Policy description following the `RateLimit-Policy` header format.
+	Policy string
+
+	// Limit the maximum number of requests in the policy's window.
+	Limit int
+
+	// Remaining the number of requests the client can still make.
+	Remaining int
+
+	// Reset the time until the quota is fully restored.
+	Reset time.Duration
+
+	// RetryAfter the time until the client can make a new request.
+	// Only relevant if the request is not allowed.
+	RetryAfter time.Duration
+
+	// Allowed is `true` if the request didn't exceed the quota.
+	Allowed bool
+}
+
+// Policy defines how many requests a client can make.
+type Policy interface {
+	// Take consumes one request from the quota of the client identified by the given key.
+	// The state of the quota is read and updated using the given store.
+	Take(ctx context.Context, store Store, key string, now time.Time) (*Result, error)
+
+	// ID returns a string uniquely identifying the policy and its parameters.
+	// It is used to build the store keys so policies don't share their state.
+	ID() string
+}
+
+// FixedWindow policy allowing `Limit` requests per `Window`. The window starts
+// at the first request of the client and the quota is fully restored when it ends.
+type FixedWindow struct {
+	Limit  int
+	Window time.Duration
+}
+
+// ID returns "fw:<limit>:<window>".
+func (p *FixedWindow) ID() string {
+	return fmt.Sprintf("fw:%d:%s", p.Limit, p.Window)
+}
+
+// Take consumes one request from the quota of the current window.
+func (p *FixedWindow) Take(ctx context.Context, store Store, key string, now time.Time) (*Result, error) {
+	var result Result
+	err := store.Update(ctx, key, func(state *State) State {
+		s := State{Time: now}
+		if state != nil && now.Before(state.Time.Add(p.Window)) {
+			s = *state
+		}
+		s.ExpiresAt = s.Time.Add(p.Window)
+
+		result = Result{
+			Policy: fmt.Sprintf("%d;w=%d", p.Limit, seconds(p.Window)),
+			Limit:  p.Limit,
+			Reset:  s.ExpiresAt.Sub(now),
+		}
+		if int(s.Value) >= p.Limit {
+			result.RetryAfter = result.Reset
+			return s
+		}
+		s.Value++
+		result.Allowed = true
+		result.Remaining = p.Limit - int(s.Value)
+		return s
+	})
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	return &result, nil
+}
+
+// TokenBucket policy allowing bursts of up to `Capacity` requests. One request
+// is restored to the quota every `Refill`.
+type TokenBucket struct {
+	Capacity int
+	Refill   time.Duration
+}
+
+// ID returns "tb:<capacity>:<refill>".
+func (p *TokenBucket) ID() string {
+	return fmt.Sprintf("tb:%d:%s", p.Capacity, p.Refill)
+}
+
+// Take consumes one token from the bucket.
+func (p *TokenBucket) Take(ctx context.Context, store Store, key string, now time.Time) (*Result, error) {
+	var result Result
+	capacity := float64(p.Capacity)
+	err := store.Update(ctx, key, func(state *State) State {
+		tokens := capacity
+		if state != nil && state.ExpiresAt.After(now) {
+			elapsed := now.Sub(state.Time)
+			tokens = math.Min(capacity, state.Value+float64(elapsed)/float64(p.Refill))
+		}
+
+		result = Result{
+			Policy: fmt.Sprintf("%d;w=%d", p.Capacity, seconds(time.Duration(p.Capacity)*p.Refill)),
+			Limit:  p.Capacity,
+		}
+		if tokens >= 1 {
+			tokens--
+			result.Allowed = true
+		} else {
+			result.RetryAfter = time.Duration((1 - tokens) * float64(p.Refill))
+		}
+		result.Remaining = int(tokens)
+		result.Reset = time.Duration((capacity - tokens) * float64(p.Refill))
+
+		return State{
+			Value:     tokens,
+			Time:      now,
+			ExpiresAt: now.Add(result.Reset),
+		}
+	})
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	return &result, nil
+}
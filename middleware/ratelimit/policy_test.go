@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type errorStore struct{}
+
+func (errorStore) Update(_ context.Context, _ string, _ func(state *State) State) error {
+	return fmt.Errorf("store error")
+}
+
+func TestFixedWindow(t *testing.T) {
+	policy := &FixedWindow{Limit: 2, Window: time.Minute}
+	assert.Equal(t, "fw:2:1m0s", policy.ID())
+
+	store := NewMemoryStore()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	result, err := policy.Take(ctx, store, "key", now)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Policy: "2;w=60", Limit: 2, Remaining: 1, Reset: time.Minute, Allowed: true}, result)
+
+	result, err = policy.Take(ctx, store, "key", now.Add(10*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Policy: "2;w=60", Limit: 2, Remaining: 0, Reset: 50 * time.Second, Allowed: true}, result)
+
+	result, err = policy.Take(ctx, store, "key", now.Add(20*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Policy: "2;w=60", Limit: 2, Remaining: 0, Reset: 40 * time.Second, RetryAfter: 40 * time.Second}, result)
+
+	// Other key not affected
+	result, err = policy.Take(ctx, store, "other", now.Add(20*time.Second))
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	// New window
+	result, err = policy.Take(ctx, store, "key", now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Policy: "2;w=60", Limit: 2, Remaining: 1, Reset: time.Minute, Allowed: true}, result)
+
+	_, err = policy.Take(ctx, errorStore{}, "key", now)
+	assert.Error(t, err)
+}
+
+func TestTokenBucket(t *testing.T) {
+	policy := &TokenBucket{Capacity: 2, Refill: 10 * time.Second}
+	assert.Equal(t, "tb:2:10s", policy.ID())
+
+	store := NewMemoryStore()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	result, err := policy.Take(ctx, store, "key", now)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Policy: "2;w=20", Limit: 2, Remaining: 1, Reset: 10 * time.Second, Allowed: true}, result)
+
+	result, err = policy.Take(ctx, store, "key", now)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Policy: "2;w=20", Limit: 2, Remaining: 0, Reset: 20 * time.Second, Allowed: true}, result)
+
+	result, err = policy.Take(ctx, store, "key", now.Add(5*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Policy: "2;w=20", Limit: 2, Remaining: 0, Reset: 15 * time.Second, RetryAfter: 5 * time.Second}, result)
+
+	// One token refilled
+	result, err = policy.Take(ctx, store, "key", now.Add(10*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Policy: "2;w=20", Limit: 2, Remaining: 0, Reset: 20 * time.Second, Allowed: true}, result)
+
+	// Bucket full again, capped at capacity
+	result, err = policy.Take(ctx, store, "key", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Policy: "2;w=20", Limit: 2, Remaining: 1, Reset: 10 * time.Second, Allowed: true}, result)
+
+	_, err = policy.Take(ctx, errorStore{}, "key", now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,193 @@
+package ratelimit
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"goyave.dev/goyave/v5"
+)
+
+// MetaRateLimit the rate limiting middleware uses the `*Limiter` stored in this meta
+// in the matched route or any of its parent. Routes or routers can therefore have
+// their own limits. Set this meta to `nil` to disable rate limiting for a route or router
+// whose parent is limited.
+const MetaRateLimit = "goyave.rate-limit"
+
+// KeyFunc returns the key identifying the client of the given request. Requests
+// with the same key share the same quota.
+type KeyFunc func(request *goyave.Request) string
+
+// ByIP returns the IP address of the client (without the port).
+//
+// The remote address of the request is used, meaning the `X-Forwarded-For`
+// header is not taken into account. If your application is running behind a
+// reverse proxy, use a custom `KeyFunc` instead.
+func ByIP(request *goyave.Request) string {
+	addr := request.RemoteAddress()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// ByUser returns a `KeyFunc` identifying the client by the authenticated `request.User`.
+// The given function returns the unique identifier of the user (for example its ID).
+// If the request is not authenticated or if the user is not of type `*T`, the client
+// is identified by its IP address (see `ByIP`).
+//
+// The T parameter represents the user DTO and should not be a pointer. The rate
+// limiting middleware should be executed after the authentication middleware.
+//
+// **Example:**
+//
+//	ratelimit.ByUser(func(u *dto.User) string { return strconv.FormatUint(uint64(u.ID), 10) })
+func ByUser[T any](id func(user *T) string) KeyFunc {
+	return func(request *goyave.Request) string {
+		if user, ok := request.User.(*T); ok && user != nil {
+			return "user:" + id(user)
+		}
+		return "ip:" + ByIP(request)
+	}
+}
+
+// Limiter associates a rate limiting `Policy` with a `KeyFunc`.
+type Limiter struct {
+	// Policy defines how many requests a client can make.
+	Policy Policy
+
+	// Key identifies the client. Defaults to `ByIP` if nil.
+	Key KeyFunc
+
+	// Name identifies this limiter in the store. Limiters with the same name and
+	// the same policy share their quotas. This means a `Limiter` set on a router
+	// is shared by all its routes. Use distinct names to give routes separate quotas.
+	Name string
+}
+
+func (l *Limiter) storeKey(request *goyave.Request) string {
+	keyFunc := l.Key
+	if keyFunc == nil {
+		keyFunc = ByIP
+	}
+	return "ratelimit:" + l.Name + ":" + l.Policy.ID() + ":" + keyFunc(request)
+}
+
+// Middleware limiting the number of requests a client can make.
+//
+// The limiter is chosen from the `MetaRateLimit` meta of the matched route or any of
+// its parent. If no meta is found, the `Limiter` field is used. If none is defined, the
+// request passes without being limited.
+//
+// Each response contains the `RateLimit-Limit`, `RateLimit-Remaining`, `RateLimit-Reset`
+// and `RateLimit-Policy` headers. If the client exceeded its quota, the middleware
+// blocks with the status `429 Too Many Requests` and sets the `Retry-After` header.
+//
+// If the store returns an error, the request is aborted with a `500 Internal Server Error`,
+// unless `FailOpen` is `true`: the error is then logged and the request passes.
+//
+// If `ByUser` is used, this middleware should be executed after the authentication middleware.
+//
+// **Example:**
+//
+//	router.GlobalMiddleware(&ratelimit.Middleware{
+//		Limiter: &ratelimit.Limiter{
+//			Policy: &ratelimit.FixedWindow{Limit: 100, Window: time.Minute},
+//		},
+//	})
+//
+//	router.Post("/login", ctrl.Login).SetMeta(ratelimit.MetaRateLimit, &ratelimit.Limiter{
+//		Name:   "login",
+//		Policy: &ratelimit.TokenBucket{Capacity: 5, Refill: 10 * time.Second},
+//	})
+type Middleware struct {
+	goyave.Component
+
+	// Store persists the state of the limiters. Defaults to a `MemoryStore` if nil.
+	Store Store
+
+	// Limiter used if the matched route nor its parents define the `MetaRateLimit` meta.
+	Limiter *Limiter
+
+	// FailOpen if `true`, store errors are logged and don't prevent the request from passing.
+	FailOpen bool
+
+	now       func() time.Time
+	storeOnce sync.Once
+}
+
+// Handle implementation of `goyave.Middleware`.
+func (m *Middleware) Handle(next goyave.Handler) goyave.Handler {
+	return func(response *goyave.Response, request *goyave.Request) {
+		limiter := m.getLimiter(request)
+		if limiter == nil || limiter.Policy == nil {
+			next(response, request)
+			return
+		}
+
+		result, err := limiter.Policy.Take(request.Context(), m.getStore(), limiter.storeKey(request), m.getNow())
+		if err != nil {
+			if !m.FailOpen {
+				response.Error(err)
+				return
+			}
+			m.Logger().Error(err)
+			next(response, request)
+			return
+		}
+
+		setHeaders(response.Header(), result)
+		if !result.Allowed {
+			response.Header().Set("Retry-After", strconv.FormatInt(seconds(result.RetryAfter), 10))
+			response.Status(http.StatusTooManyRequests)
+			return
+		}
+		next(response, request)
+	}
+}
+
+func (m *Middleware) getLimiter(request *goyave.Request) *Limiter {
+	if request.Route != nil {
+		if meta, ok := request.Route.LookupMeta(MetaRateLimit); ok {
+			limiter, _ := meta.(*Limiter)
+			return limiter
+		}
+	}
+	return m.Limiter
+}
+
+func (m *Middleware) getStore() Store {
+	m.storeOnce.Do(func() {
+		if m.Store == nil {
+			m.Store = NewMemoryStore()
+		}
+	})
+	return m.Store
+}
+
+func (m *Middleware) getNow() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
+func setHeaders(header http.Header, result *Result) {
+	header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	header.Set("RateLimit-Reset", strconv.FormatInt(seconds(result.Reset), 10))
+	if result.Policy != "" {
+		header.Set("RateLimit-Policy", result.Policy)
+	}
+}
+
+// seconds converts the given duration to seconds, rounded up.
+func seconds(d time.Duration) int64 {
+	if d <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(d.Seconds()))
+}
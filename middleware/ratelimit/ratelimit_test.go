@@ -0,0 +1,181 @@
+package ratelimit
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/config"
+	"goyave.dev/goyave/v5/util/testutil"
+)
+
+type testUser struct {
+	Name string
+}
+
+func TestByIP(t *testing.T) {
+	request := testutil.NewTestRequest(http.MethodGet, "/", nil)
+	request.Request().RemoteAddr = "192.0.2.1:1234"
+	assert.Equal(t, "192.0.2.1", ByIP(request))
+
+	request.Request().RemoteAddr = "192.0.2.1"
+	assert.Equal(t, "192.0.2.1", ByIP(request))
+
+	request.Request().RemoteAddr = "[2001:db8::1]:1234"
+	assert.Equal(t, "2001:db8::1", ByIP(request))
+}
+
+func TestByUser(t *testing.T) {
+	keyFunc := ByUser(func(u *testUser) string { return u.Name })
+	request := testutil.NewTestRequest(http.MethodGet, "/", nil)
+	request.Request().RemoteAddr = "192.0.2.1:1234"
+	assert.Equal(t, "ip:192.0.2.1", keyFunc(request))
+
+	request.User = &testUser{Name: "johndoe"}
+	assert.Equal(t, "user:johndoe", keyFunc(request))
+
+	request.User = "not a user"
+	assert.Equal(t, "ip:192.0.2.1", keyFunc(request))
+}
+
+func TestMiddleware(t *testing.T) {
+	prepare := func(t *testing.T, middleware *Middleware) *testutil.TestServer {
+		server := testutil.NewTestServerWithOptions(t, goyave.Options{Config: config.LoadDefault()})
+		now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		middleware.now = func() time.Time { return now }
+		server.RegisterRoutes(func(_ *goyave.Server, router *goyave.Router) {
+			router.GlobalMiddleware(middleware)
+			handler := func(response *goyave.Response, _ *goyave.Request) {
+				response.String(http.StatusOK, "ok")
+			}
+			router.Get("/default", handler)
+			router.Get("/unlimited", handler).SetMeta(MetaRateLimit, nil)
+			router.Get("/login", handler).SetMeta(MetaRateLimit, &Limiter{
+				Name:   "login",
+				Policy: &TokenBucket{Capacity: 1, Refill: 30 * time.Second},
+			})
+
+			subrouter := router.Subrouter("/api")
+			subrouter.SetMeta(MetaRateLimit, &Limiter{
+				Name:   "api",
+				Policy: &FixedWindow{Limit: 1, Window: time.Minute},
+				Key:    func(r *goyave.Request) string { return r.Header().Get("X-Api-Key") },
+			})
+			subrouter.Get("/a", handler)
+			subrouter.Get("/b", handler)
+		})
+		return server
+	}
+
+	request := func(server *testutil.TestServer, uri string, header http.Header) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, uri, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		resp := server.TestRequest(req)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	t.Run("default_limiter", func(t *testing.T) {
+		server := prepare(t, &Middleware{
+			Limiter: &Limiter{Policy: &FixedWindow{Limit: 2, Window: time.Minute}},
+		})
+
+		resp := request(server, "/default", nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("RateLimit-Limit"))
+		assert.Equal(t, "1", resp.Header.Get("RateLimit-Remaining"))
+		assert.Equal(t, "60", resp.Header.Get("RateLimit-Reset"))
+		assert.Equal(t, "2;w=60", resp.Header.Get("RateLimit-Policy"))
+		assert.Empty(t, resp.Header.Get("Retry-After"))
+
+		resp = request(server, "/default", nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "0", resp.Header.Get("RateLimit-Remaining"))
+
+		resp = request(server, "/default", nil)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "0", resp.Header.Get("RateLimit-Remaining"))
+		assert.Equal(t, "60", resp.Header.Get("Retry-After"))
+
+		// Disabled with meta
+		for i := 0; i < 3; i++ {
+			resp = request(server, "/unlimited", nil)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Empty(t, resp.Header.Get("RateLimit-Limit"))
+		}
+
+		// Route-specific limiter
+		resp = request(server, "/login", nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "1", resp.Header.Get("RateLimit-Limit"))
+		resp = request(server, "/login", nil)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "30", resp.Header.Get("Retry-After"))
+	})
+
+	t.Run("no_default_limiter", func(t *testing.T) {
+		server := prepare(t, &Middleware{})
+		for i := 0; i < 3; i++ {
+			resp := request(server, "/default", nil)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Empty(t, resp.Header.Get("RateLimit-Limit"))
+		}
+	})
+
+	t.Run("router_limiter_shared", func(t *testing.T) {
+		server := prepare(t, &Middleware{})
+		keyA := http.Header{"X-Api-Key": []string{"a"}}
+		keyB := http.Header{"X-Api-Key": []string{"b"}}
+
+		assert.Equal(t, http.StatusOK, request(server, "/api/a", keyA).StatusCode)
+		assert.Equal(t, http.StatusTooManyRequests, request(server, "/api/b", keyA).StatusCode)
+		assert.Equal(t, http.StatusOK, request(server, "/api/b", keyB).StatusCode)
+	})
+
+	t.Run("store_error", func(t *testing.T) {
+		server := prepare(t, &Middleware{
+			Store:   errorStore{},
+			Limiter: &Limiter{Policy: &FixedWindow{Limit: 2, Window: time.Minute}},
+		})
+		resp := request(server, "/default", nil)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+
+	t.Run("store_error_fail_open", func(t *testing.T) {
+		server := prepare(t, &Middleware{
+			Store:    errorStore{},
+			FailOpen: true,
+			Limiter:  &Limiter{Policy: &FixedWindow{Limit: 2, Window: time.Minute}},
+		})
+		resp := request(server, "/default", nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("RateLimit-Limit"))
+	})
+}
+
+func TestSeconds(t *testing.T) {
+	cases := []struct {
+		d    time.Duration
+		want int64
+	}{
+		{d: 0, want: 0},
+		{d: -time.Second, want: 0},
+		{d: time.Millisecond, want: 1},
+		{d: time.Second, want: 1},
+		{d: 1500 * time.Millisecond, want: 2},
+	}
+	for _, c := range cases {
+		t.Run(strconv.FormatInt(int64(c.d), 10), func(t *testing.T) {
+			require.Equal(t, c.want, seconds(c.d))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// State of a rate limiting policy for a single client.
+// The meaning of `Value` and `Time` depends on the policy.
+type State struct {
+	// Time the start of the window for `FixedWindow`, the last refill for `TokenBucket`.
+	Time time.Time
+
+	// ExpiresAt the time after which the state is not relevant anymore and can be deleted.
+	ExpiresAt time.Time
+
+	// Value the number of requests made for `FixedWindow`, the number of tokens left for `TokenBucket`.
+	Value float64
+}
+
+// Store persists the state of rate limiting policies.
+//
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Update atomically loads the state identified by the given key, passes it
+	// to the update function and saves the returned state. The state given to the
+	// update function is `nil` if there is no state for this key yet.
+	//
+	// The update function may be called several times if the implementation
+	// needs to retry, so it should not have side effects besides capturing its result.
+	Update(ctx context.Context, key string, update func(state *State) State) error
+}
+
+// MemoryStore an in-memory `Store`. The state is not shared between multiple
+// instances of the application and is lost when the application stops.
+//
+// Expired states are periodically removed when the store is used.
+type MemoryStore struct {
+	states    map[string]State
+	lastSweep time.Time
+
+	// SweepInterval the minimum interval between two removals of expired states.
+	SweepInterval time.Duration
+
+	mu sync.Mutex
+}
+
+// NewMemoryStore creates a new in-memory store removing expired states every minute.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		states:        map[string]State{},
+		lastSweep:     time.Now(),
+		SweepInterval: time.Minute,
+	}
+}
+
+// Update atomically loads the state identified by the given key, passes it
+// to the update function and saves the returned state.
+func (s *MemoryStore) Update(_ context.Context, key string, update func(state *State) State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.states == nil {
+		s.states = map[string]State{}
+	}
+	now := time.Now()
+	if now.Sub(s.lastSweep) >= s.SweepInterval {
+		s.sweep(now)
+	}
+
+	var current *State
+	if state, ok := s.states[key]; ok {
+		current = &state
+	}
+	s.states[key] = update(current)
+	return nil
+}
+
+// Len returns the number of states stored.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.states)
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, state := range s.states {
+		if !state.ExpiresAt.After(now) {
+			delete(s.states, key)
+		}
+	}
+	s.lastSweep = now
+}
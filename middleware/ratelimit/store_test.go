@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	t.Run("Update", func(t *testing.T) {
+		store := NewMemoryStore()
+		expiresAt := time.Now().Add(time.Hour)
+
+		err := store.Update(context.Background(), "key", func(state *State) State {
+			assert.Nil(t, state)
+			return State{Value: 1, ExpiresAt: expiresAt}
+		})
+		require.NoError(t, err)
+
+		err = store.Update(context.Background(), "key", func(state *State) State {
+			require.NotNil(t, state)
+			assert.Equal(t, State{Value: 1, ExpiresAt: expiresAt}, *state)
+			return State{Value: state.Value + 1, ExpiresAt: expiresAt}
+		})
+		require.NoError(t, err)
+		assert.Equal(t, State{Value: 2, ExpiresAt: expiresAt}, store.states["key"])
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		store := NewMemoryStore()
+		wg := sync.WaitGroup{}
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = store.Update(context.Background(), "key", func(state *State) State {
+					if state == nil {
+						return State{Value: 1}
+					}
+					return State{Value: state.Value + 1}
+				})
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, float64(50), store.states["key"].Value)
+	})
+
+	t.Run("sweep", func(t *testing.T) {
+		store := NewMemoryStore()
+		store.states["expired"] = State{ExpiresAt: time.Now().Add(-time.Second)}
+		store.states["valid"] = State{ExpiresAt: time.Now().Add(time.Hour)}
+
+		// Sweep interval not elapsed
+		require.NoError(t, store.Update(context.Background(), "new", func(_ *State) State { return State{} }))
+		assert.Equal(t, 3, store.Len())
+
+		store.lastSweep = time.Now().Add(-2 * time.Minute)
+		require.NoError(t, store.Update(context.Background(), "new", func(_ *State) State {
+			return State{ExpiresAt: time.Now().Add(time.Hour)}
+		}))
+		assert.Equal(t, 2, store.Len())
+		assert.Contains(t, store.states, "valid")
+		assert.Contains(t, store.states, "new")
+	})
+}
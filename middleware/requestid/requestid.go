@@ -0,0 +1,223 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	stdslog "log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/slog"
+	"goyave.dev/goyave/v5/util/errors"
+)
+
+const (
+	// HeaderRequestID the default header used to accept and echo the request ID.
+	HeaderRequestID = "X-Request-ID"
+
+	// HeaderTraceparent the W3C Trace Context header.
+	HeaderTraceparent = "traceparent"
+
+	// MaxRequestIDLength the maximum length of an incoming request ID. Longer IDs are
+	// rejected and replaced with a generated one.
+	MaxRequestIDLength = 128
+)
+
+type contextKey struct{}
+
+// Trace contains the identifiers of a request used to correlate its logs
+// and to propagate the tracing context to downstream services.
+type Trace struct {
+	// RequestID the ID of the request, either accepted from the request header or generated.
+	RequestID string
+
+	// TraceID the 32 hex characters trace ID, shared by all the requests of a distributed trace.
+	TraceID string
+
+	// ParentID the 16 hex characters span ID of the caller, or an empty string if
+	// the request didn't contain a valid `traceparent` header.
+	ParentID string
+
+	// SpanID the 16 hex characters span ID generated for this request.
+	SpanID string
+
+	// Flags the trace flags (e.g. sampled).
+	Flags byte
+}
+
+// Traceparent returns the `traceparent` header value identifying this request's span.
+// Use it when calling downstream services to propagate the tracing context.
+func (t *Trace) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", t.TraceID, t.SpanID, t.Flags)
+}
+
+// Attrs returns the structured logging attributes identifying the request.
+func (t *Trace) Attrs() []stdslog.Attr {
+	return []stdslog.Attr{
+		stdslog.String("requestId", t.RequestID),
+		stdslog.String("traceId", t.TraceID),
+		stdslog.String("spanId", t.SpanID),
+	}
+}
+
+// NewContext returns a copy of the given context carrying the given trace.
+func NewContext(ctx context.Context, trace *Trace) context.Context {
+	return context.WithValue(ctx, contextKey{}, trace)
+}
+
+// FromContext returns the trace carried by the given context, or `nil`.
+func FromContext(ctx context.Context) *Trace {
+	trace, _ := ctx.Value(contextKey{}).(*Trace)
+	return trace
+}
+
+// Middleware assigns an ID and a tracing context to each request.
+//
+// If the request contains a valid request ID header (`X-Request-ID` by default), it is
+// accepted, otherwise a new UUID is generated. If the request contains a valid W3C
+// `traceparent` header, the trace ID and flags are kept and the caller's span ID becomes
+// the parent ID. Otherwise, a new trace is started. In both cases, a new span ID is generated
+// for the request.
+//
+// The resulting `*Trace` is stored in the request's context and can be retrieved
+// with `FromContext()`. Its attributes (`requestId`, `traceId` and `spanId`) are added to
+// the context's logging attributes, so they are included in the errors logged by `Response.Error()`,
+// in the panics logged by the recovery middleware and in the access logs.
+//
+// The request ID and the `traceparent` of the request's span are echoed in the response headers.
+//
+// This middleware should be registered as a global middleware.
+//
+// **Example:**
+//
+//	router.GlobalMiddleware(&requestid.Middleware{})
+//	router.GlobalMiddleware(log.CombinedLogMiddleware())
+type Middleware struct {
+	goyave.Component
+
+	// Header the name of the request ID header. Defaults to `X-Request-ID` if empty.
+	Header string
+
+	// Generator generates a new request ID. Defaults to a random UUID if nil.
+	Generator func() string
+
+	// IgnoreIncoming if `true`, the request ID and `traceparent` headers sent by the client
+	// are ignored. Enable this if the application is directly exposed to untrusted clients.
+	IgnoreIncoming bool
+}
+
+// Handle implementation of `goyave.Middleware`.
+func (m *Middleware) Handle(next goyave.Handler) goyave.Handler {
+	return func(response *goyave.Response, request *goyave.Request) {
+		header := m.Header
+		if header == "" {
+			header = HeaderRequestID
+		}
+
+		trace := &Trace{}
+		if !m.IgnoreIncoming {
+			trace.RequestID = sanitizeRequestID(request.Header().Get(header))
+			if traceID, parentID, flags, ok := ParseTraceparent(request.Header().Get(HeaderTraceparent)); ok {
+				trace.TraceID = traceID
+				trace.ParentID = parentID
+				trace.Flags = flags
+			}
+		}
+		if trace.RequestID == "" {
+			trace.RequestID = m.generateRequestID()
+		}
+		if trace.TraceID == "" {
+			trace.TraceID = randomHex(16)
+		}
+		trace.SpanID = randomHex(8)
+
+		ctx := NewContext(request.Context(), trace)
+		ctx = slog.ContextWithAttrs(ctx, trace.Attrs()...)
+		request.WithContext(ctx)
+
+		response.Header().Set(header, trace.RequestID)
+		response.Header().Set(HeaderTraceparent, trace.Traceparent())
+
+		next(response, request)
+	}
+}
+
+func (m *Middleware) generateRequestID() string {
+	if m.Generator != nil {
+		return m.Generator()
+	}
+	return uuid.NewString()
+}
+
+// ParseTraceparent parses a W3C Trace Context `traceparent` header value.
+// Returns the trace ID, the parent span ID and the trace flags. `ok` is `false`
+// if the value is not valid.
+//
+// Only the version "00" format is supported. Future versions are parsed according to
+// the version "00" format, as recommended by the specification.
+func ParseTraceparent(value string) (traceID, parentID string, flags byte, ok bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return "", "", 0, false
+	}
+	version, traceID, parentID, flagsStr := parts[0], parts[1], parts[2], parts[3]
+	if len(version) != 2 || !isLowerHex(version) || version == "ff" || (version == "00" && len(parts) != 4) {
+		return "", "", 0, false
+	}
+	if len(traceID) != 32 || !isLowerHex(traceID) || isZero(traceID) {
+		return "", "", 0, false
+	}
+	if len(parentID) != 16 || !isLowerHex(parentID) || isZero(parentID) {
+		return "", "", 0, false
+	}
+	if len(flagsStr) != 2 || !isLowerHex(flagsStr) {
+		return "", "", 0, false
+	}
+	f, err := hex.DecodeString(flagsStr)
+	if err != nil {
+		return "", "", 0, false
+	}
+	return traceID, parentID, f[0], true
+}
+
+func sanitizeRequestID(id string) string {
+	if len(id) > MaxRequestIDLength {
+		return ""
+	}
+	for _, c := range id {
+		// Only printable ASCII characters are accepted to prevent log injection.
+		if c < 0x21 || c > 0x7e {
+			return ""
+		}
+	}
+	return id
+}
+
+func isLowerHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func isZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	for {
+		if _, err := rand.Read(b); err != nil {
+			panic(errors.New(err))
+		}
+		id := hex.EncodeToString(b)
+		if !isZero(id) {
+			return id
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/config"
+	"goyave.dev/goyave/v5/slog"
+	"goyave.dev/goyave/v5/util/testutil"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	cases := []struct {
+		value    string
+		traceID  string
+		parentID string
+		flags    byte
+		ok       bool
+	}{
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", traceID: "4bf92f3577b34da6a3ce929d0e0e4736", parentID: "00f067aa0ba902b7", flags: 1, ok: true},
+		{value: " 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00 ", traceID: "4bf92f3577b34da6a3ce929d0e0e4736", parentID: "00f067aa0ba902b7", flags: 0, ok: true},
+		{value: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future", traceID: "4bf92f3577b34da6a3ce929d0e0e4736", parentID: "00f067aa0ba902b7", flags: 1, ok: true},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"},
+		{value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{value: "0-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01"},
+		{value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01"},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b-01"},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1"},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-zz"},
+		{value: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.value, func(t *testing.T) {
+			traceID, parentID, flags, ok := ParseTraceparent(c.value)
+			assert.Equal(t, c.ok, ok)
+			assert.Equal(t, c.traceID, traceID)
+			assert.Equal(t, c.parentID, parentID)
+			assert.Equal(t, c.flags, flags)
+		})
+	}
+}
+
+func TestTrace(t *testing.T) {
+	trace := &Trace{
+		RequestID: "abc",
+		TraceID:   "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:    "00f067aa0ba902b7",
+		Flags:     1,
+	}
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", trace.Traceparent())
+
+	assert.Nil(t, FromContext(context.Background()))
+	assert.Same(t, trace, FromContext(NewContext(context.Background(), trace)))
+}
+
+func TestMiddleware(t *testing.T) {
+	hexRegex := func(n int) *regexp.Regexp { return regexp.MustCompile(fmt.Sprintf("^[0-9a-f]{%d}$", n)) }
+
+	execute := func(t *testing.T, m *Middleware, header http.Header) (*http.Response, *Trace, string) {
+		logBuffer := &bytes.Buffer{}
+		cfg := config.LoadDefault()
+		cfg.Set("app.debug", false)
+		server := testutil.NewTestServerWithOptions(t, goyave.Options{Config: cfg, Logger: slog.New(slog.NewHandler(false, logBuffer))})
+		var trace *Trace
+		server.RegisterRoutes(func(_ *goyave.Server, router *goyave.Router) {
+			router.GlobalMiddleware(m)
+			router.Get("/test", func(response *goyave.Response, request *goyave.Request) {
+				trace = FromContext(request.Context())
+				response.Error(fmt.Errorf("test error"))
+			})
+		})
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		resp := server.TestRequest(req)
+		_ = resp.Body.Close()
+		return resp, trace, logBuffer.String()
+	}
+
+	t.Run("generate", func(t *testing.T) {
+		resp, trace, logs := execute(t, &Middleware{}, nil)
+		require.NotNil(t, trace)
+		assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`), trace.RequestID)
+		assert.Regexp(t, hexRegex(32), trace.TraceID)
+		assert.Regexp(t, hexRegex(16), trace.SpanID)
+		assert.Empty(t, trace.ParentID)
+		assert.Equal(t, byte(0), trace.Flags)
+
+		assert.Equal(t, trace.RequestID, resp.Header.Get(HeaderRequestID))
+		assert.Equal(t, trace.Traceparent(), resp.Header.Get(HeaderTraceparent))
+		assert.Contains(t, logs, fmt.Sprintf(`"requestId":"%s","traceId":"%s","spanId":"%s"`, trace.RequestID, trace.TraceID, trace.SpanID))
+	})
+
+	t.Run("accept_incoming", func(t *testing.T) {
+		header := http.Header{
+			"X-Request-Id": []string{"incoming-id"},
+			"Traceparent":  []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		}
+		resp, trace, _ := execute(t, &Middleware{}, header)
+		require.NotNil(t, trace)
+		assert.Equal(t, "incoming-id", trace.RequestID)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.TraceID)
+		assert.Equal(t, "00f067aa0ba902b7", trace.ParentID)
+		assert.NotEqual(t, "00f067aa0ba902b7", trace.SpanID)
+		assert.Regexp(t, hexRegex(16), trace.SpanID)
+		assert.Equal(t, byte(1), trace.Flags)
+		assert.Equal(t, "incoming-id", resp.Header.Get(HeaderRequestID))
+	})
+
+	t.Run("invalid_incoming", func(t *testing.T) {
+		header := http.Header{
+			"X-Request-Id": []string{"invalid id\n"},
+			"Traceparent":  []string{"invalid"},
+		}
+		_, trace, _ := execute(t, &Middleware{Generator: func() string { return "generated" }}, header)
+		require.NotNil(t, trace)
+		assert.Equal(t, "generated", trace.RequestID)
+		assert.NotEqual(t, "invalid", trace.TraceID)
+		assert.Empty(t, trace.ParentID)
+
+		header = http.Header{"X-Request-Id": []string{string(bytes.Repeat([]byte{'a'}, MaxRequestIDLength+1))}}
+		_, trace, _ = execute(t, &Middleware{Generator: func() string { return "generated" }}, header)
+		assert.Equal(t, "generated", trace.RequestID)
+	})
+
+	t.Run("ignore_incoming_custom_header", func(t *testing.T) {
+		header := http.Header{
+			"X-Correlation-Id": []string{"incoming-id"},
+			"Traceparent":      []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		}
+		m := &Middleware{Header: "X-Correlation-ID", IgnoreIncoming: true, Generator: func() string { return "generated" }}
+		resp, trace, _ := execute(t, m, header)
+		require.NotNil(t, trace)
+		assert.Equal(t, "generated", trace.RequestID)
+		assert.NotEqual(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.TraceID)
+		assert.Equal(t, "generated", resp.Header.Get("X-Correlation-ID"))
+		assert.Empty(t, resp.Header.Get(HeaderRequestID))
+	})
+}
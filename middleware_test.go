@@ -213,12 +213,17 @@ func TestLanguageMiddleware(t *testing.T) {
 	cases := []struct {
 		desc     string
 		lang     string
+		query    string
+		cookie   string
 		expected string
 	}{
 		{desc: "no_default", lang: "en-UK", expected: "en-UK"},
 		{desc: "default_provided", lang: "en-US", expected: "en-US"},
 		{desc: "default_not_provided", lang: "en-US", expected: "en-US"},
 		{desc: "priority", lang: "en-US;q=0.9, en-UK", expected: "en-UK"},
+		{desc: "query_ignored", query: "?lang=en-UK", lang: "en-US", expected: "en-US"},
+		{desc: "cookie_ignored", cookie: "en-UK", lang: "en-US", expected: "en-US"},
+		{desc: "none", expected: "en-US"},
 	}
 
 	for _, c := range cases {
@@ -230,10 +235,13 @@ func TestLanguageMiddleware(t *testing.T) {
 				executed = true
 			})
 
-			request := NewRequest(httptest.NewRequest(http.MethodGet, "/test", nil))
+			request := NewRequest(httptest.NewRequest(http.MethodGet, "/test"+c.query, nil))
 			if c.lang != "" {
 				request.Header().Set("Accept-Language", c.lang)
 			}
+			if c.cookie != "" {
+				request.Request().AddCookie(&http.Cookie{Name: "lang", Value: c.cookie})
+			}
 			response := NewResponse(server, request, httptest.NewRecorder())
 
 			handler(response, request)
@@ -661,8 +669,8 @@ func TestCORSMiddleware(t *testing.T) {
 			}
 			recorder := httptest.NewRecorder()
 			response := NewResponse(nil, request, recorder)
-			match := &routeMatch{
-				route: request.Route,
+			match := &RouteMatch{
+				Route: request.Route,
 			}
 
 			handler(response, request)
@@ -0,0 +1,51 @@
+package openapi
+
+import (
+	"net/http"
+	"sync"
+
+	"goyave.dev/goyave/v5"
+)
+
+// Controller serves the OpenAPI document generated from the server's routes.
+//
+// The document is generated on the first request so all the routes registered
+// when the server starts are documented, regardless of the order of registration.
+type Controller struct {
+	goyave.Component
+
+	// Options used to generate the document. May be `nil`.
+	Options *Options
+
+	// URI of the route serving the document. Defaults to "/openapi.json".
+	URI string
+
+	once     sync.Once
+	document *Document
+}
+
+// NewController create a new controller serving the OpenAPI document
+// generated with the given options.
+func NewController(opts *Options) *Controller {
+	return &Controller{
+		Options: opts,
+	}
+}
+
+// RegisterRoutes register the route serving the document on the given router.
+// The route is named "openapi".
+func (c *Controller) RegisterRoutes(router *goyave.Router) {
+	uri := c.URI
+	if uri == "" {
+		uri = "/openapi.json"
+	}
+	router.Get(uri, c.Serve).Name("openapi")
+}
+
+// Serve GET handler returning the OpenAPI document as JSON.
+func (c *Controller) Serve(response *goyave.Response, _ *goyave.Request) {
+	c.once.Do(func() {
+		c.document = Generate(c.Server(), c.Options)
+	})
+	response.JSON(http.StatusOK, c.document)
+}
@@ -0,0 +1,46 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/util/testutil"
+)
+
+func TestController(t *testing.T) {
+	server := prepareGeneratorTest(t)
+	controller := NewController(&Options{Info: Info{Title: "API", Version: "1.2.3"}})
+	server.RegisterRoutes(func(_ *goyave.Server, router *goyave.Router) {
+		router.Controller(controller)
+		router.Get("/registered-after", func(_ *goyave.Response, _ *goyave.Request) {})
+	})
+
+	resp := server.TestRequest(httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	document, err := testutil.ReadJSONBody[*Document](resp.Body)
+	assert.NoError(t, resp.Body.Close())
+	require.NoError(t, err)
+
+	assert.Equal(t, Version, document.OpenAPI)
+	assert.Equal(t, Info{Title: "API", Version: "1.2.3"}, document.Info)
+	assert.Contains(t, document.Paths, "/openapi.json")
+	assert.Contains(t, document.Paths, "/registered-after")
+	assert.Equal(t, "openapi", (*document.Paths["/openapi.json"])["get"].OperationID)
+
+	t.Run("custom_uri", func(t *testing.T) {
+		server := prepareGeneratorTest(t)
+		controller := NewController(nil)
+		controller.URI = "/docs/spec.json"
+		server.RegisterRoutes(func(_ *goyave.Server, router *goyave.Router) {
+			router.Controller(controller)
+		})
+
+		resp := server.TestRequest(httptest.NewRequest(http.MethodGet, "/docs/spec.json", nil))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NoError(t, resp.Body.Close())
+	})
+}
@@ -0,0 +1,177 @@
+package openapi
+
+import (
+	"encoding/json"
+
+	"goyave.dev/goyave/v5/util/errors"
+)
+
+// Version the version of the OpenAPI specification the generated documents comply with.
+const Version = "3.1.0"
+
+// Document the root object of an OpenAPI document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []*Server            `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components *Components          `json:"components,omitempty"`
+}
+
+// Info metadata about the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server an object representing a server hosting the API.
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem describes the operations available on a single path.
+// The keys are the lowercase HTTP methods (e.g. "get", "post").
+type PathItem map[string]*Operation
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	OperationID string                `json:"operationId,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+}
+
+// Parameter describes a single operation parameter. A parameter is identified
+// by the combination of its name and location ("path" or "query").
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema,omitempty"`
+}
+
+// RequestBody describes a single request body.
+type RequestBody struct {
+	Content  map[string]*MediaType `json:"content"`
+	Required bool                  `json:"required,omitempty"`
+}
+
+// MediaType provides the schema for the media type identified by its key.
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Response describes a single response from an API operation.
+type Response struct {
+	Description string `json:"description"`
+}
+
+// Components holds reusable objects for different aspects of the document.
+type Components struct {
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme defines a security scheme that can be used by the operations.
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	Name         string `json:"name,omitempty"`
+	In           string `json:"in,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// SecurityRequirement lists the security schemes required to execute an operation.
+// The keys are names of security schemes declared in the components.
+type SecurityRequirement map[string][]string
+
+// Schema a JSON Schema (draft 2020-12) describing a value.
+type Schema struct {
+	Type          Types              `json:"type,omitempty"`
+	Format        string             `json:"format,omitempty"`
+	Enum          []any              `json:"enum,omitempty"`
+	Pattern       string             `json:"pattern,omitempty"`
+	Minimum       *float64           `json:"minimum,omitempty"`
+	Maximum       *float64           `json:"maximum,omitempty"`
+	MinLength     *int               `json:"minLength,omitempty"`
+	MaxLength     *int               `json:"maxLength,omitempty"`
+	MinItems      *int               `json:"minItems,omitempty"`
+	MaxItems      *int               `json:"maxItems,omitempty"`
+	UniqueItems   bool               `json:"uniqueItems,omitempty"`
+	MinProperties *int               `json:"minProperties,omitempty"`
+	MaxProperties *int               `json:"maxProperties,omitempty"`
+	Items         *Schema            `json:"items,omitempty"`
+	Properties    map[string]*Schema `json:"properties,omitempty"`
+	Required      []string           `json:"required,omitempty"`
+}
+
+// Types the type(s) of a JSON Schema. A single type is marshaled as a string,
+// multiple types (e.g. for nullable values) are marshaled as an array.
+type Types []string
+
+// MarshalJSON marshals the types as a single string if there is only one type.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON unmarshals either a single type or an array of types.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return errors.New(err)
+	}
+	*t = multiple
+	return nil
+}
+
+// property returns the schema of the property identified by the given name,
+// creating it if it doesn't exist yet.
+func (s *Schema) property(name string) *Schema {
+	if s.Properties == nil {
+		s.Properties = make(map[string]*Schema, 1)
+	}
+	p, ok := s.Properties[name]
+	if !ok {
+		p = &Schema{}
+		s.Properties[name] = p
+	}
+	return p
+}
+
+// items returns the schema of the array elements, creating it if it doesn't exist yet.
+func (s *Schema) items() *Schema {
+	if s.Items == nil {
+		s.Items = &Schema{}
+	}
+	return s.Items
+}
+
+func (s *Schema) setType(t string) {
+	if len(s.Type) == 0 {
+		s.Type = Types{t}
+	}
+}
+
+func (s *Schema) hasType(t string) bool {
+	return len(s.Type) > 0 && s.Type[0] == t
+}
+
+func (s *Schema) addRequired(name string) {
+	for _, r := range s.Required {
+		if r == name {
+			return
+		}
+	}
+	s.Required = append(s.Required, name)
+}
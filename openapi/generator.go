@@ -0,0 +1,300 @@
+package openapi
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/auth"
+	"goyave.dev/goyave/v5/cors"
+)
+
+// DefaultSecurityScheme the name of the security scheme used by default
+// for the routes requiring authentication.
+const DefaultSecurityScheme = "bearerAuth"
+
+// Options for the generation of an OpenAPI document.
+type Options struct {
+	// Info metadata about the API. If the title is empty, the "app.name"
+	// config entry is used. If the version is empty, "1.0.0" is used.
+	Info Info
+
+	// Servers hosting the API. Defaults to a single server using
+	// the server's proxy base URL.
+	Servers []*Server
+
+	// SecuritySchemes added to the document components. Defaults to a single
+	// HTTP bearer scheme named "bearerAuth".
+	SecuritySchemes map[string]*SecurityScheme
+
+	// Security requirement of the operations whose route requires authentication
+	// (`auth.MetaAuth`). Defaults to the "bearerAuth" scheme.
+	Security []SecurityRequirement
+}
+
+// Generate an OpenAPI 3.1 document describing all the routes registered
+// in the given server's main router and its subrouters.
+//
+// The path parameters are extracted from the route URIs and the query parameters
+// and request bodies are generated from the route's validation rules (see `SchemaFromRules`).
+// Because `RuleSetFunc` can depend on the request, they are called with an empty request
+// matching the route and using the default language.
+//
+// The "HEAD" method is not documented if the route also matches "GET". The "OPTIONS"
+// method is not documented if the route has CORS options.
+//
+// If a route matches the same path and method as a route registered before it,
+// it is not documented because it can never be matched.
+func Generate(server *goyave.Server, opts *Options) *Document {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	g := &generator{
+		server: server,
+		document: &Document{
+			OpenAPI: Version,
+			Info:    opts.Info,
+			Servers: opts.Servers,
+			Paths:   make(map[string]*PathItem),
+		},
+		security: opts.Security,
+	}
+
+	if g.document.Info.Title == "" {
+		g.document.Info.Title = server.Config().GetString("app.name")
+	}
+	if g.document.Info.Version == "" {
+		g.document.Info.Version = "1.0.0"
+	}
+	if g.document.Servers == nil {
+		g.document.Servers = []*Server{{URL: server.ProxyBaseURL()}}
+	}
+	if g.security == nil {
+		g.security = []SecurityRequirement{{DefaultSecurityScheme: []string{}}}
+	}
+
+	g.walk(server.Router())
+
+	if g.secured {
+		schemes := opts.SecuritySchemes
+		if schemes == nil {
+			schemes = map[string]*SecurityScheme{
+				DefaultSecurityScheme: {Type: "http", Scheme: "bearer"},
+			}
+		}
+		g.document.Components = &Components{SecuritySchemes: schemes}
+	}
+	return g.document
+}
+
+type generator struct {
+	server   *goyave.Server
+	document *Document
+	security []SecurityRequirement
+
+	// secured true if at least one operation requires authentication.
+	secured bool
+}
+
+// walk the given router, documenting its subrouters first to
+// reflect the route matching precedence.
+func (g *generator) walk(router *goyave.Router) {
+	for _, subrouter := range router.GetSubrouters() {
+		g.walk(subrouter)
+	}
+	for _, route := range router.GetRoutes() {
+		g.addRoute(route)
+	}
+}
+
+func (g *generator) addRoute(route *goyave.Route) {
+	fullURI, _ := route.GetFullURIAndParameters()
+	path, pathParameters := convertURI(fullURI)
+
+	pathItem, ok := g.document.Paths[path]
+	if !ok {
+		pathItem = &PathItem{}
+		g.document.Paths[path] = pathItem
+	}
+
+	methods := route.GetMethods()
+	for _, method := range methods {
+		if !documentMethod(route, method, methods) {
+			continue
+		}
+		key := strings.ToLower(method)
+		if _, exists := (*pathItem)[key]; exists {
+			continue
+		}
+		(*pathItem)[key] = g.makeOperation(route, method, path, pathParameters)
+	}
+
+	if len(*pathItem) == 0 {
+		delete(g.document.Paths, path)
+	}
+}
+
+func documentMethod(route *goyave.Route, method string, methods []string) bool {
+	switch method {
+	case http.MethodHead:
+		return !lo.Contains(methods, http.MethodGet)
+	case http.MethodOptions:
+		options, ok := route.LookupMeta(goyave.MetaCORS)
+		return !ok || options == (*cors.Options)(nil)
+	}
+	return true
+}
+
+func (g *generator) makeOperation(route *goyave.Route, method, path string, pathParameters []*Parameter) *Operation {
+	operation := &Operation{
+		OperationID: route.GetName(),
+		Parameters:  append(make([]*Parameter, 0, len(pathParameters)), pathParameters...),
+		Responses: map[string]*Response{
+			"default": {Description: "Default response"},
+		},
+	}
+
+	validated := false
+	if rules := route.GetQueryValidationRules(); rules != nil {
+		validated = true
+		schema := SchemaFromRules(rules(g.newRequest(route, method, path)))
+		operation.Parameters = append(operation.Parameters, queryParameters(schema)...)
+	}
+	if rules := route.GetBodyValidationRules(); rules != nil {
+		validated = true
+		schema := SchemaFromRules(rules(g.newRequest(route, method, path)))
+		operation.RequestBody = &RequestBody{
+			Content: map[string]*MediaType{
+				bodyContentType(schema): {Schema: schema},
+			},
+			Required: len(schema.Required) > 0,
+		}
+	}
+	if validated {
+		operation.Responses[strconv.Itoa(http.StatusUnprocessableEntity)] = &Response{Description: http.StatusText(http.StatusUnprocessableEntity)}
+	}
+
+	if requireAuth, ok := route.LookupMeta(auth.MetaAuth); ok && requireAuth == true {
+		g.secured = true
+		operation.Security = g.security
+		operation.Responses[strconv.Itoa(http.StatusUnauthorized)] = &Response{Description: http.StatusText(http.StatusUnauthorized)}
+	}
+
+	if len(operation.Parameters) == 0 {
+		operation.Parameters = nil
+	}
+	return operation
+}
+
+// newRequest creates an empty request matching the given route, used
+// to call the route's `RuleSetFunc`.
+func (g *generator) newRequest(route *goyave.Route, method, path string) *goyave.Request {
+	request := goyave.NewRequest(&http.Request{
+		Method: method,
+		URL:    &url.URL{Path: path},
+		Header: http.Header{},
+	})
+	request.Route = route
+	request.RouteParams = map[string]string{}
+	request.Lang = g.server.Lang.GetDefault()
+	return request
+}
+
+// queryParameters converts the properties of the given query schema to query parameters.
+func queryParameters(schema *Schema) []*Parameter {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	parameters := make([]*Parameter, 0, len(names))
+	for _, name := range names {
+		parameters = append(parameters, &Parameter{
+			Name:     name,
+			In:       "query",
+			Required: lo.Contains(schema.Required, name),
+			Schema:   schema.Properties[name],
+		})
+	}
+	return parameters
+}
+
+// bodyContentType returns "multipart/form-data" if the given schema contains
+// at least one file, "application/json" otherwise.
+func bodyContentType(schema *Schema) string {
+	if hasFile(schema) {
+		return "multipart/form-data"
+	}
+	return "application/json"
+}
+
+func hasFile(schema *Schema) bool {
+	if schema.Format == "binary" {
+		return true
+	}
+	if schema.Items != nil && hasFile(schema.Items) {
+		return true
+	}
+	for _, p := range schema.Properties {
+		if hasFile(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertURI converts a route URI to an OpenAPI path and extracts its path parameters.
+// Parameters using a custom pattern (e.g. `{id:[0-9]+}`) are converted to `{id}` and
+// the pattern is added to the parameter's schema.
+func convertURI(uri string) (string, []*Parameter) {
+	var builder strings.Builder
+	builder.Grow(len(uri))
+	parameters := []*Parameter{}
+
+	for i := 0; i < len(uri); i++ {
+		if uri[i] != '{' {
+			builder.WriteByte(uri[i])
+			continue
+		}
+
+		// Find the matching closing brace. Patterns may contain braces too.
+		level := 0
+		end := i
+		for ; end < len(uri); end++ {
+			if uri[end] == '{' {
+				level++
+			} else if uri[end] == '}' {
+				level--
+				if level == 0 {
+					break
+				}
+			}
+		}
+
+		name, pattern, hasPattern := strings.Cut(uri[i+1:end], ":")
+		schema := &Schema{Type: Types{"string"}}
+		if hasPattern {
+			schema.Pattern = "^" + pattern + "$"
+		}
+		parameters = append(parameters, &Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   schema,
+		})
+		builder.WriteString("{" + name + "}")
+		i = end
+	}
+
+	path := builder.String()
+	if path == "" {
+		path = "/"
+	}
+	return path, parameters
+}
@@ -0,0 +1,178 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goyave.dev/goyave/v5"
+	"goyave.dev/goyave/v5/auth"
+	"goyave.dev/goyave/v5/config"
+	"goyave.dev/goyave/v5/cors"
+	"goyave.dev/goyave/v5/util/testutil"
+	"goyave.dev/goyave/v5/validation"
+)
+
+func prepareGeneratorTest(t *testing.T) *testutil.TestServer {
+	cfg := config.LoadDefault()
+	cfg.Set("app.name", "test-app")
+	return testutil.NewTestServerWithOptions(t, goyave.Options{Config: cfg})
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("routes", func(t *testing.T) {
+		server := prepareGeneratorTest(t)
+		router := server.Router()
+
+		handler := func(_ *goyave.Response, _ *goyave.Request) {}
+		router.Get("/", handler).Name("index")
+		users := router.Subrouter("/users")
+		users.Get("/", handler).Name("users.index").ValidateQuery(func(_ *goyave.Request) validation.RuleSet {
+			return validation.RuleSet{
+				{Path: "page", Rules: validation.List{validation.Int(), validation.Min(1)}},
+				{Path: "search", Rules: validation.List{validation.Required(), validation.String()}},
+			}
+		})
+		users.Get("/{id:[0-9]+}", handler).Name("users.show")
+		users.Delete("/{id:[0-9]+}", handler).SetMeta(auth.MetaAuth, true)
+		users.Post("/", handler).Name("users.store").ValidateBody(func(r *goyave.Request) validation.RuleSet {
+			assert.NotNil(t, r.Lang)
+			assert.Equal(t, "users.store", r.Route.GetName())
+			return validation.RuleSet{
+				{Path: "name", Rules: validation.List{validation.Required(), validation.String()}},
+				{Path: "avatar", Rules: validation.List{validation.File()}},
+			}
+		})
+		router.Get("/users/{id}", handler).Name("unreachable")
+		router.Route([]string{http.MethodHead}, "/ping", handler).Name("ping")
+		router.Get("/cors", handler).CORS(cors.Default())
+
+		document := Generate(server.Server, nil)
+
+		assert.Equal(t, Version, document.OpenAPI)
+		assert.Equal(t, Info{Title: "test-app", Version: "1.0.0"}, document.Info)
+		assert.Equal(t, []*Server{{URL: server.ProxyBaseURL()}}, document.Servers)
+		assert.Equal(t, &Components{
+			SecuritySchemes: map[string]*SecurityScheme{
+				DefaultSecurityScheme: {Type: "http", Scheme: "bearer"},
+			},
+		}, document.Components)
+
+		defaultResponse := map[string]*Response{"default": {Description: "Default response"}}
+		idParameter := &Parameter{Name: "id", In: "path", Required: true, Schema: &Schema{Type: Types{"string"}, Pattern: "^[0-9]+$"}}
+		expected := map[string]*PathItem{
+			"/": {
+				"get": {OperationID: "index", Responses: defaultResponse},
+			},
+			"/users": {
+				"get": {
+					OperationID: "users.index",
+					Parameters: []*Parameter{
+						{Name: "page", In: "query", Schema: &Schema{Type: Types{"integer"}, Minimum: floatPtr(1)}},
+						{Name: "search", In: "query", Required: true, Schema: &Schema{Type: Types{"string"}}},
+					},
+					Responses: map[string]*Response{
+						"default": {Description: "Default response"},
+						"422":     {Description: "Unprocessable Entity"},
+					},
+				},
+				"post": {
+					OperationID: "users.store",
+					RequestBody: &RequestBody{
+						Content: map[string]*MediaType{
+							"multipart/form-data": {Schema: &Schema{
+								Type: Types{"object"},
+								Properties: map[string]*Schema{
+									"name":   {Type: Types{"string"}},
+									"avatar": {Type: Types{"string"}, Format: "binary"},
+								},
+								Required: []string{"name"},
+							}},
+						},
+						Required: true,
+					},
+					Responses: map[string]*Response{
+						"default": {Description: "Default response"},
+						"422":     {Description: "Unprocessable Entity"},
+					},
+				},
+			},
+			"/users/{id}": {
+				"get": {OperationID: "users.show", Parameters: []*Parameter{idParameter}, Responses: defaultResponse},
+				"delete": {
+					Parameters: []*Parameter{idParameter},
+					Responses: map[string]*Response{
+						"default": {Description: "Default response"},
+						"401":     {Description: "Unauthorized"},
+					},
+					Security: []SecurityRequirement{{DefaultSecurityScheme: []string{}}},
+				},
+			},
+			"/ping": {
+				"head": {OperationID: "ping", Responses: defaultResponse},
+			},
+			"/cors": {
+				"get": {Responses: defaultResponse},
+			},
+		}
+		assert.Equal(t, expected, document.Paths)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		server := prepareGeneratorTest(t)
+		server.Router().Get("/", nil).SetMeta(auth.MetaAuth, true)
+
+		opts := &Options{
+			Info:            Info{Title: "API", Description: "description", Version: "2.0.0"},
+			Servers:         []*Server{{URL: "https://api.example.org"}},
+			SecuritySchemes: map[string]*SecurityScheme{"basicAuth": {Type: "http", Scheme: "basic"}},
+			Security:        []SecurityRequirement{{"basicAuth": {}}},
+		}
+		document := Generate(server.Server, opts)
+		assert.Equal(t, opts.Info, document.Info)
+		assert.Equal(t, opts.Servers, document.Servers)
+		assert.Equal(t, &Components{SecuritySchemes: opts.SecuritySchemes}, document.Components)
+		require.Contains(t, document.Paths, "/")
+		assert.Equal(t, opts.Security, (*document.Paths["/"])["get"].Security)
+	})
+
+	t.Run("no_auth", func(t *testing.T) {
+		server := prepareGeneratorTest(t)
+		server.Router().Get("/", nil)
+		document := Generate(server.Server, nil)
+		assert.Nil(t, document.Components)
+	})
+}
+
+func TestConvertURI(t *testing.T) {
+	cases := []struct {
+		uri            string
+		expectedPath   string
+		expectedParams []*Parameter
+	}{
+		{uri: "", expectedPath: "/", expectedParams: []*Parameter{}},
+		{uri: "/users", expectedPath: "/users", expectedParams: []*Parameter{}},
+		{
+			uri:          "/users/{id}/posts/{postId:[0-9]{1,3}}",
+			expectedPath: "/users/{id}/posts/{postId}",
+			expectedParams: []*Parameter{
+				{Name: "id", In: "path", Required: true, Schema: &Schema{Type: Types{"string"}}},
+				{Name: "postId", In: "path", Required: true, Schema: &Schema{Type: Types{"string"}, Pattern: "^[0-9]{1,3}$"}},
+			},
+		},
+		{
+			uri:          "/static{resource:.*}",
+			expectedPath: "/static{resource}",
+			expectedParams: []*Parameter{
+				{Name: "resource", In: "path", Required: true, Schema: &Schema{Type: Types{"string"}, Pattern: "^.*$"}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		path, params := convertURI(c.uri)
+		assert.Equal(t, c.expectedPath, path, c.uri)
+		assert.Equal(t, c.expectedParams, params, c.uri)
+	}
+}
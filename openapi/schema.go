@@ -0,0 +1,226 @@
+package openapi
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"goyave.dev/goyave/v5/util/walk"
+	"goyave.dev/goyave/v5/validation"
+)
+
+// SchemaFromRules converts the given validation rules to a JSON Schema.
+//
+// The type of each field is determined by its type validators (`Int()`, `String()`,
+// `Array()`, `File()`, etc). Type-dependent validators such as `Min()` or `Max()` are
+// translated to the matching keyword depending on this type. Validators that cannot
+// be expressed in JSON Schema (comparisons with other fields, database checks, etc)
+// are ignored.
+func SchemaFromRules(rules validation.Ruler) *Schema {
+	root := &Schema{}
+	for _, field := range rules.AsRules() {
+		applyField(root, field)
+	}
+	return root
+}
+
+// applyField explores the given schema following the field's path, creating the
+// intermediate schemas if needed, and applies the field's validators to the target schema.
+func applyField(schema *Schema, field *validation.Field) {
+	var parent *Schema
+	name := ""
+	for p := field.Path; p != nil; p = p.Next {
+		if p.Name != nil && *p.Name != "" {
+			schema.setType("object")
+			parent = schema
+			name = *p.Name
+			schema = schema.property(name)
+		}
+
+		switch p.Type {
+		case walk.PathTypeArray:
+			schema.setType("array")
+			parent = nil
+			schema = schema.items()
+		case walk.PathTypeObject:
+			schema.setType("object")
+		case walk.PathTypeElement:
+			applyValidators(schema, field.Validators)
+			if parent != nil && isRequired(field) {
+				parent.addRequired(name)
+			}
+		}
+	}
+
+	if field.Elements != nil {
+		applyField(schema, field.Elements)
+	}
+}
+
+func isRequired(field *validation.Field) bool {
+	for _, v := range field.Validators {
+		if _, ok := v.(*validation.RequiredValidator); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func applyValidators(schema *Schema, validators []validation.Validator) {
+	nullable := false
+	for _, v := range validators {
+		applyType(schema, v)
+		if _, ok := v.(*validation.NullableValidator); ok {
+			nullable = true
+		}
+	}
+
+	for _, v := range validators {
+		applyConstraint(schema, v)
+	}
+
+	if nullable && len(schema.Type) == 1 {
+		schema.Type = append(schema.Type, "null")
+	}
+}
+
+// applyType sets the type and format of the schema if the given validator is a type validator.
+func applyType(schema *Schema, v validation.Validator) {
+	switch v := v.(type) {
+	case *validation.StringValidator, *validation.TimezoneValidator, *validation.IPValidator:
+		schema.setType("string")
+	case *validation.IPv4Validator:
+		schema.setType("string")
+		schema.Format = "ipv4"
+	case *validation.IPv6Validator:
+		schema.setType("string")
+		schema.Format = "ipv6"
+	case *validation.UUIDValidator:
+		schema.setType("string")
+		schema.Format = "uuid"
+	case *validation.EmailValidator:
+		schema.setType("string")
+		schema.Format = "email"
+	case *validation.URLValidator:
+		schema.setType("string")
+		schema.Format = "uri"
+	case *validation.DateValidator:
+		schema.setType("string")
+		schema.Format = "date-time"
+		if len(v.Formats) == 1 && v.Formats[0] == time.DateOnly {
+			schema.Format = "date"
+		}
+	case *validation.FileValidator:
+		schema.setType("string")
+		schema.Format = "binary"
+	case *validation.BoolValidator:
+		schema.setType("boolean")
+	case *validation.ArrayValidator:
+		schema.setType("array")
+	case *validation.ObjectValidator:
+		schema.setType("object")
+	case *validation.Float32Validator:
+		schema.setType("number")
+		schema.Format = "float"
+	case *validation.Float64Validator:
+		schema.setType("number")
+		schema.Format = "double"
+	default:
+		name := v.Name()
+		if !v.IsType() || !(strings.HasPrefix(name, "int") || strings.HasPrefix(name, "uint")) {
+			return
+		}
+		schema.setType("integer")
+		switch strings.TrimPrefix(name, "u") {
+		case "int32":
+			schema.Format = "int32"
+		case "int64":
+			schema.Format = "int64"
+		}
+		if strings.HasPrefix(name, "uint") && schema.Minimum == nil {
+			schema.Minimum = floatPtr(0)
+		}
+	}
+}
+
+// applyConstraint translates the given validator to the matching keywords.
+// Type-dependent validators require the schema type to be set.
+func applyConstraint(schema *Schema, v validation.Validator) {
+	switch v := v.(type) {
+	case *validation.MinValidator:
+		applyMin(schema, v.Min)
+	case *validation.MaxValidator:
+		applyMax(schema, v.Max)
+	case *validation.BetweenValidator:
+		applyMin(schema, v.Min)
+		applyMax(schema, v.Max)
+	case *validation.SizeValidator:
+		applyMin(schema, float64(v.Size))
+		applyMax(schema, float64(v.Size))
+	case *validation.RegexValidator:
+		schema.Pattern = v.Regexp.String()
+	case *validation.AlphaValidator:
+		schema.Pattern = v.Regexp.String()
+	case *validation.AlphaNumValidator:
+		schema.Pattern = v.Regexp.String()
+	case *validation.AlphaDashValidator:
+		schema.Pattern = v.Regexp.String()
+	default:
+		switch v.Name() {
+		case "in":
+			schema.Enum = inValues(v)
+		case "distinct":
+			schema.UniqueItems = true
+		}
+	}
+}
+
+// inValues returns the accepted values of an `InValidator`. Reflection is used
+// because the validator is generic.
+func inValues(v validation.Validator) []any {
+	values := reflect.Indirect(reflect.ValueOf(v)).FieldByName("Values")
+	if values.Kind() != reflect.Slice {
+		return nil
+	}
+	enum := make([]any, 0, values.Len())
+	for i := 0; i < values.Len(); i++ {
+		enum = append(enum, values.Index(i).Interface())
+	}
+	return enum
+}
+
+func applyMin(schema *Schema, min float64) {
+	switch {
+	case schema.hasType("integer"), schema.hasType("number"):
+		schema.Minimum = floatPtr(min)
+	case schema.hasType("string") && schema.Format != "binary":
+		schema.MinLength = intPtr(math.Ceil(min))
+	case schema.hasType("array"):
+		schema.MinItems = intPtr(math.Ceil(min))
+	case schema.hasType("object"):
+		schema.MinProperties = intPtr(math.Ceil(min))
+	}
+}
+
+func applyMax(schema *Schema, max float64) {
+	switch {
+	case schema.hasType("integer"), schema.hasType("number"):
+		schema.Maximum = floatPtr(max)
+	case schema.hasType("string") && schema.Format != "binary":
+		schema.MaxLength = intPtr(math.Floor(max))
+	case schema.hasType("array"):
+		schema.MaxItems = intPtr(math.Floor(max))
+	case schema.hasType("object"):
+		schema.MaxProperties = intPtr(math.Floor(max))
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func intPtr(f float64) *int {
+	i := int(f)
+	return &i
+}
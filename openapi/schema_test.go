@@ -0,0 +1,155 @@
+package openapi
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goyave.dev/goyave/v5/validation"
+)
+
+func TestSchemaFromRules(t *testing.T) {
+	t.Run("types", func(t *testing.T) {
+		schema := SchemaFromRules(validation.RuleSet{
+			{Path: validation.CurrentElement, Rules: validation.List{validation.Required(), validation.Object()}},
+			{Path: "string", Rules: validation.List{validation.String()}},
+			{Path: "int", Rules: validation.List{validation.Int()}},
+			{Path: "int32", Rules: validation.List{validation.Int32()}},
+			{Path: "uint64", Rules: validation.List{validation.Uint64()}},
+			{Path: "float", Rules: validation.List{validation.Float64()}},
+			{Path: "bool", Rules: validation.List{validation.Bool()}},
+			{Path: "uuid", Rules: validation.List{validation.UUID()}},
+			{Path: "email", Rules: validation.List{validation.Email()}},
+			{Path: "url", Rules: validation.List{validation.URL()}},
+			{Path: "date", Rules: validation.List{validation.Date()}},
+			{Path: "datetime", Rules: validation.List{validation.Date("2006-01-02 15:04:05")}},
+			{Path: "ipv4", Rules: validation.List{validation.IPv4()}},
+			{Path: "json", Rules: validation.List{validation.JSON()}},
+		})
+
+		expected := &Schema{
+			Type: Types{"object"},
+			Properties: map[string]*Schema{
+				"string":   {Type: Types{"string"}},
+				"int":      {Type: Types{"integer"}},
+				"int32":    {Type: Types{"integer"}, Format: "int32"},
+				"uint64":   {Type: Types{"integer"}, Format: "int64", Minimum: floatPtr(0)},
+				"float":    {Type: Types{"number"}, Format: "double"},
+				"bool":     {Type: Types{"boolean"}},
+				"uuid":     {Type: Types{"string"}, Format: "uuid"},
+				"email":    {Type: Types{"string"}, Format: "email"},
+				"url":      {Type: Types{"string"}, Format: "uri"},
+				"date":     {Type: Types{"string"}, Format: "date"},
+				"datetime": {Type: Types{"string"}, Format: "date-time"},
+				"ipv4":     {Type: Types{"string"}, Format: "ipv4"},
+				"json":     {},
+			},
+		}
+		assert.Equal(t, expected, schema)
+	})
+
+	t.Run("constraints", func(t *testing.T) {
+		schema := SchemaFromRules(validation.RuleSet{
+			{Path: "name", Rules: validation.List{validation.Required(), validation.String(), validation.Between(2, 50)}},
+			{Path: "age", Rules: validation.List{validation.Int(), validation.Min(18), validation.Max(130)}},
+			{Path: "code", Rules: validation.List{validation.String(), validation.Size(4), validation.Regex(regexp.MustCompile(`^[A-Z]+$`))}},
+			{Path: "status", Rules: validation.List{validation.Required(), validation.String(), validation.In([]string{"active", "inactive"})}},
+			{Path: "tags", Rules: validation.List{validation.Array(), validation.Min(1), validation.Distinct[string]()}},
+			{Path: "tags[]", Rules: validation.List{validation.String(), validation.Max(10)}},
+			{Path: "comment", Rules: validation.List{validation.Nullable(), validation.String()}},
+		})
+
+		expected := &Schema{
+			Type: Types{"object"},
+			Properties: map[string]*Schema{
+				"name":   {Type: Types{"string"}, MinLength: intPtr(2), MaxLength: intPtr(50)},
+				"age":    {Type: Types{"integer"}, Minimum: floatPtr(18), Maximum: floatPtr(130)},
+				"code":   {Type: Types{"string"}, MinLength: intPtr(4), MaxLength: intPtr(4), Pattern: "^[A-Z]+$"},
+				"status": {Type: Types{"string"}, Enum: []any{"active", "inactive"}},
+				"tags": {
+					Type:        Types{"array"},
+					MinItems:    intPtr(1),
+					UniqueItems: true,
+					Items:       &Schema{Type: Types{"string"}, MaxLength: intPtr(10)},
+				},
+				"comment": {Type: Types{"string", "null"}},
+			},
+			Required: []string{"name", "status"},
+		}
+		assert.Equal(t, expected, schema)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		schema := SchemaFromRules(validation.RuleSet{
+			{Path: "user", Rules: validation.List{validation.Required(), validation.Object()}},
+			{Path: "user.email", Rules: validation.List{validation.Required(), validation.Email()}},
+			{Path: "items", Rules: validation.List{validation.Array()}},
+			{Path: "items[].id", Rules: validation.List{validation.Required(), validation.Int()}},
+			{Path: "matrix[][]", Rules: validation.List{validation.Float32()}},
+			{Path: "avatar", Rules: validation.List{validation.File(), validation.Max(512)}},
+		})
+
+		expected := &Schema{
+			Type: Types{"object"},
+			Properties: map[string]*Schema{
+				"user": {
+					Type:       Types{"object"},
+					Properties: map[string]*Schema{"email": {Type: Types{"string"}, Format: "email"}},
+					Required:   []string{"email"},
+				},
+				"items": {
+					Type: Types{"array"},
+					Items: &Schema{
+						Type:       Types{"object"},
+						Properties: map[string]*Schema{"id": {Type: Types{"integer"}}},
+						Required:   []string{"id"},
+					},
+				},
+				"matrix": {
+					Type: Types{"array"},
+					Items: &Schema{
+						Type:  Types{"array"},
+						Items: &Schema{Type: Types{"number"}, Format: "float"},
+					},
+				},
+				"avatar": {Type: Types{"string"}, Format: "binary"},
+			},
+			Required: []string{"user"},
+		}
+		assert.Equal(t, expected, schema)
+		assert.True(t, hasFile(schema))
+	})
+
+	t.Run("array_root", func(t *testing.T) {
+		schema := SchemaFromRules(validation.RuleSet{
+			{Path: validation.CurrentElement, Rules: validation.List{validation.Array(), validation.Max(3)}},
+			{Path: "[]", Rules: validation.List{validation.UUID()}},
+		})
+
+		expected := &Schema{
+			Type:     Types{"array"},
+			MaxItems: intPtr(3),
+			Items:    &Schema{Type: Types{"string"}, Format: "uuid"},
+		}
+		assert.Equal(t, expected, schema)
+	})
+}
+
+func TestTypes(t *testing.T) {
+	data, err := json.Marshal(Types{"string"})
+	require.NoError(t, err)
+	assert.Equal(t, `"string"`, string(data))
+
+	data, err = json.Marshal(Types{"string", "null"})
+	require.NoError(t, err)
+	assert.Equal(t, `["string","null"]`, string(data))
+
+	var types Types
+	require.NoError(t, json.Unmarshal([]byte(`"integer"`), &types))
+	assert.Equal(t, Types{"integer"}, types)
+	require.NoError(t, json.Unmarshal([]byte(`["integer","null"]`), &types))
+	assert.Equal(t, Types{"integer", "null"}, types)
+	assert.Error(t, json.Unmarshal([]byte(`123`), &types))
+}
@@ -11,6 +11,20 @@ import (
 type parameterizable struct {
 	regex      *regexp.Regexp
 	parameters []string
+
+	// segments the slash-separated segments of the URI. Only set
+	// if the URI is only made of static segments and parameters using
+	// the default pattern, meaning it can be matched without the regex.
+	segments []uriSegment
+}
+
+// uriSegment a slash-separated part of a URI. If "param" is true,
+// the segment is a parameter matching any non-empty string not containing
+// a slash and "value" is the name of the parameter. Otherwise,
+// "value" is the static string expected.
+type uriSegment struct {
+	value string
+	param bool
 }
 
 // compileParameters parse the route parameters and compiles their regexes if needed.
@@ -80,6 +94,95 @@ func (p *parameterizable) compileParameters(uri string, ends bool, regexCache ma
 		panic(fmt.Sprintf("route %s contains capture groups in its regexp. ", uri) +
 			"Only non-capturing groups are accepted: e.g. (?:pattern) instead of (pattern)")
 	}
+
+	p.segments = compileSegments(uri)
+}
+
+// compileSegments splits the given URI into segments. Returns `nil` if the URI
+// contains a segment that cannot be matched without a regex, that is to say
+// a parameter with a custom pattern, a parameter that doesn't span an entire
+// segment, or a static segment containing regex special characters.
+func compileSegments(uri string) []uriSegment {
+	parts := strings.Split(uri, "/")
+	segments := make([]uriSegment, 0, len(parts))
+	for _, part := range parts {
+		if len(part) > 2 && part[0] == '{' && part[len(part)-1] == '}' {
+			name := part[1 : len(part)-1]
+			if strings.ContainsAny(name, "{}:") {
+				return nil
+			}
+			segments = append(segments, uriSegment{value: name, param: true})
+			continue
+		}
+		if regexp.QuoteMeta(part) != part || strings.ContainsAny(part, "{}") {
+			return nil
+		}
+		segments = append(segments, uriSegment{value: part})
+	}
+	return segments
+}
+
+// matchSegments checks if the given path matches the compiled segments.
+// If "trailingSlash" is true, a single trailing slash is accepted in the path.
+//
+// The returned slice has the same format as a regex submatch: the first element is the
+// full match and the following elements are the parameter values, in order.
+// Returns `nil` if the path doesn't match.
+func (p *parameterizable) matchSegments(path string, trailingSlash bool) []string {
+	if params := p.matchSegmentsStrict(path); params != nil {
+		return params
+	}
+	if trailingSlash && len(path) > 0 && path[len(path)-1] == '/' {
+		if params := p.matchSegmentsStrict(path[:len(path)-1]); params != nil {
+			params[0] = path
+			return params
+		}
+	}
+	return nil
+}
+
+func (p *parameterizable) matchSegmentsStrict(path string) []string {
+	params := make([]string, 1, len(p.parameters)+1)
+	params[0] = path
+	last := len(p.segments) - 1
+	for i, segment := range p.segments {
+		var part string
+		if i == last {
+			if strings.IndexByte(path, '/') != -1 {
+				return nil
+			}
+			part = path
+		} else {
+			idx := strings.IndexByte(path, '/')
+			if idx == -1 {
+				return nil
+			}
+			part = path[:idx]
+			path = path[idx+1:]
+		}
+
+		if segment.param {
+			if part == "" {
+				return nil
+			}
+			params = append(params, part)
+		} else if part != segment.value {
+			return nil
+		}
+	}
+	return params
+}
+
+// matchPath checks if the given path matches the parameterizable's URI, using
+// the compiled segments if possible, the regex otherwise.
+//
+// The returned slice has the same format as a regex submatch.
+// Returns `nil` if the path doesn't match.
+func (p *parameterizable) matchPath(path string, trailingSlash bool) []string {
+	if p.segments != nil {
+		return p.matchSegments(path, trailingSlash)
+	}
+	return p.regex.FindStringSubmatch(path)
 }
 
 // braceIndices returns the first level curly brace indices from a string.
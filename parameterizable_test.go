@@ -1,6 +1,7 @@
 package goyave
 
 import (
+	"fmt"
 	"regexp"
 	"testing"
 
@@ -129,6 +130,43 @@ func (suite *ParameterizableTestSuite) TestRegexCache() {
 	suite.Same(p1.regex, p2.regex)
 }
 
+func (suite *ParameterizableTestSuite) TestCompileSegments() {
+	cases := []struct {
+		uri      string
+		expected []uriSegment
+	}{
+		{uri: "", expected: []uriSegment{{value: ""}}},
+		{uri: "/", expected: []uriSegment{{value: ""}, {value: ""}}},
+		{uri: "/product/{id}", expected: []uriSegment{{value: ""}, {value: "product"}, {value: "id", param: true}}},
+		{uri: "/product/{id}/{name}/edit", expected: []uriSegment{{value: ""}, {value: "product"}, {value: "id", param: true}, {value: "name", param: true}, {value: "edit"}}},
+		{uri: "/product/{id:[0-9]+}", expected: nil},
+		{uri: "/product-{id}", expected: nil},
+		{uri: "/file.json", expected: nil},
+		{uri: "/uri{resource:.*}", expected: nil},
+	}
+
+	for _, c := range cases {
+		suite.Equal(c.expected, compileSegments(c.uri), c.uri)
+	}
+}
+
+func (suite *ParameterizableTestSuite) TestMatchSegments() {
+	// The segment matching must be equivalent to the regex matching
+	uris := []string{"", "/", "/product", "/product/{id}", "/product/{id}/{name}", "/{a}/{b}/edit", "/product/"}
+	paths := []string{"", "/", "//", "/product", "/product/", "/product//", "/product/33", "/product/33/", "/product/33/test", "/product/33/test/", "/a/b/edit", "/a//edit", "/a/b/edit/", "product"}
+
+	for _, uri := range uris {
+		for _, ends := range []bool{true, false} {
+			p := &parameterizable{}
+			p.compileParameters(uri, ends, make(map[string]*regexp.Regexp, 1))
+			suite.Require().NotNil(p.segments, uri)
+			for _, path := range paths {
+				suite.Equal(p.regex.FindStringSubmatch(path), p.matchSegments(path, !ends), fmt.Sprintf("uri: %q, path: %q, ends: %v", uri, path, ends))
+			}
+		}
+	}
+}
+
 func (suite *ParameterizableTestSuite) TestGetParameters() {
 	p := &parameterizable{
 		parameters: []string{"a", "b"},
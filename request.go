@@ -28,6 +28,14 @@ type (
 	// ExtraQueryValidationError the key used in `Context.Extra` to
 	// store the query validation errors.
 	ExtraQueryValidationError struct{}
+
+	// ExtraBoundBody the key used in `Context.Extra` to
+	// store the request body converted to a DTO by `Bind`.
+	ExtraBoundBody struct{}
+
+	// ExtraBoundQuery the key used in `Context.Extra` to
+	// store the request query converted to a DTO by `BindQuery`.
+	ExtraBoundQuery struct{}
 )
 
 // Request represents an http request received by the server.
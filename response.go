@@ -2,6 +2,7 @@ package goyave
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -313,7 +314,11 @@ func (r *Response) Download(fs fs.StatFS, file string, fileName string) {
 // write to the response, or use your error status handler.
 func (r *Response) Error(err any) {
 	e := errorutil.NewSkip(err, 3) // Skipped: runtime.Callers, NewSkip, this func
-	r.server.Logger.Error(e)
+	ctx := context.Background()
+	if r.request != nil {
+		ctx = r.request.Context()
+	}
+	r.server.Logger.ErrorCtx(ctx, e)
 	r.error(e)
 }
 
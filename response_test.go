@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	stdslog "log/slog"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -338,6 +339,17 @@ func TestResponse(t *testing.T) {
 		)
 	})
 
+	t.Run("Error_context_attrs", func(t *testing.T) {
+		resp, _ := newTestReponse()
+		logBuffer := &bytes.Buffer{}
+		resp.server.Logger = slog.New(slog.NewHandler(false, logBuffer))
+		resp.server.config.Set("app.debug", false)
+		resp.request.WithContext(slog.ContextWithAttrs(resp.request.Context(), stdslog.String("requestId", "abc")))
+		resp.Error(fmt.Errorf("custom error"))
+
+		assert.Contains(t, logBuffer.String(), `"requestId":"abc"`)
+	})
+
 	t.Run("Error_no_debug_nil", func(t *testing.T) {
 		resp, _ := newTestReponse()
 		logBuffer := &bytes.Buffer{}
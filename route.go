@@ -49,25 +49,25 @@ func newRoute(handler Handler, name string) *Route {
 	}
 }
 
-func (r *Route) match(method string, match *routeMatch) bool {
-	if params := r.parameterizable.regex.FindStringSubmatch(match.currentPath); params != nil {
+func (r *Route) match(method string, match *RouteMatch) bool {
+	if params := r.parameterizable.matchPath(match.currentPath, false); params != nil {
 		if r.checkMethod(method) {
 			if len(params) > 1 {
 				match.mergeParams(r.makeParameters(params))
 			}
-			match.route = r
+			match.Route = r
 			return true
 		}
-		match.err = errMatchMethodNotAllowed
+		match.Err = ErrMatchMethodNotAllowed
 		return false
 	}
 
-	if match.err == nil {
+	if match.Err == nil {
 		// Don't override error if already set.
-		// Not nil error means it's either already errMatchNotFound
-		// or it's errMatchMethodNotAllowed, implying that a route has
+		// Not nil error means it's either already ErrMatchNotFound
+		// or it's ErrMatchMethodNotAllowed, implying that a route has
 		// already been matched but with wrong method.
-		match.err = errMatchNotFound
+		match.Err = ErrMatchNotFound
 	}
 	return false
 }
@@ -134,23 +134,27 @@ func (r *Route) LookupMeta(key string) (any, bool) {
 }
 
 // ValidateBody adds (or replace) validation rules for the request body.
+// The binder set by a previous call to `ValidateBodyInto()` is removed.
 func (r *Route) ValidateBody(validationRules RuleSetFunc) *Route {
 	validationMiddleware := findMiddleware[*validateRequestMiddleware](r.middleware)
 	if validationMiddleware == nil {
 		r.Middleware(&validateRequestMiddleware{BodyRules: validationRules})
 	} else {
 		validationMiddleware.BodyRules = validationRules
+		validationMiddleware.BodyBinder = nil
 	}
 	return r
 }
 
 // ValidateQuery adds (or replace) validation rules for the request query.
+// The binder set by a previous call to `ValidateQueryInto()` is removed.
 func (r *Route) ValidateQuery(validationRules RuleSetFunc) *Route {
 	validationMiddleware := findMiddleware[*validateRequestMiddleware](r.middleware)
 	if validationMiddleware == nil {
 		r.Middleware(&validateRequestMiddleware{QueryRules: validationRules})
 	} else {
 		validationMiddleware.QueryRules = validationRules
+		validationMiddleware.QueryBinder = nil
 	}
 	return r
 }
@@ -291,6 +295,24 @@ func (r *Route) GetParent() *Router {
 	return r.parent
 }
 
+// GetBodyValidationRules returns the function generating the validation rules
+// for the request body. Returns `nil` if the route doesn't validate its body.
+func (r *Route) GetBodyValidationRules() RuleSetFunc {
+	if m := findMiddleware[*validateRequestMiddleware](r.middleware); m != nil {
+		return m.BodyRules
+	}
+	return nil
+}
+
+// GetQueryValidationRules returns the function generating the validation rules
+// for the request query. Returns `nil` if the route doesn't validate its query.
+func (r *Route) GetQueryValidationRules() RuleSetFunc {
+	if m := findMiddleware[*validateRequestMiddleware](r.middleware); m != nil {
+		return m.QueryRules
+	}
+	return nil
+}
+
 // GetFullURIAndParameters get the full uri and parameters for this route and all its parent routers.
 func (r *Route) GetFullURIAndParameters() (string, []string) {
 	router := r.parent
@@ -252,6 +252,14 @@ func TestRoute(t *testing.T) {
 		assert.NotNil(t, route.GetHandler())
 		assert.Equal(t, router, route.GetParent())
 		assert.Equal(t, "/{name}/accessories", route.GetURI())
+		assert.Nil(t, route.GetBodyValidationRules())
+		assert.Nil(t, route.GetQueryValidationRules())
+
+		route.ValidateBody(routeTestValidationRules)
+		assert.NotNil(t, route.GetBodyValidationRules())
+		assert.Nil(t, route.GetQueryValidationRules())
+		route.ValidateQuery(routeTestValidationRules)
+		assert.NotNil(t, route.GetQueryValidationRules())
 	})
 
 	t.Run("Match", func(t *testing.T) {
@@ -271,32 +279,32 @@ func TestRoute(t *testing.T) {
 		}{
 			{route: route1, method: http.MethodGet, uri: "/product/33", expectedResult: true, expectedParameters: map[string]string{"id": "33"}, expectedError: nil},
 			{route: route1, method: http.MethodPost, uri: "/product/33", expectedResult: true, expectedParameters: map[string]string{"id": "33"}, expectedError: nil},
-			{route: route1, method: http.MethodPut, uri: "/product/33", expectedResult: false, expectedParameters: nil, expectedError: errMatchMethodNotAllowed},
-			{route: route1, method: http.MethodGet, uri: "/product/test", expectedResult: false, expectedParameters: nil, expectedError: errMatchNotFound},
+			{route: route1, method: http.MethodPut, uri: "/product/33", expectedResult: false, expectedParameters: nil, expectedError: ErrMatchMethodNotAllowed},
+			{route: route1, method: http.MethodGet, uri: "/product/test", expectedResult: false, expectedParameters: nil, expectedError: ErrMatchNotFound},
 			{route: route2, method: http.MethodGet, uri: "/product/666/test", expectedResult: true, expectedParameters: map[string]string{"id": "666", "name": "test"}, expectedError: nil},
 			{route: route3, method: http.MethodGet, uri: "/categories/lawn-mower/asc", expectedResult: true, expectedParameters: map[string]string{"category": "lawn-mower", "sort": "asc"}, expectedError: nil},
-			{route: route3, method: http.MethodGet, uri: "/categories/lawn-mower/notasc", expectedResult: false, expectedParameters: nil, expectedError: errMatchNotFound},
+			{route: route3, method: http.MethodGet, uri: "/categories/lawn-mower/notasc", expectedResult: false, expectedParameters: nil, expectedError: ErrMatchNotFound},
 			{route: route4, method: http.MethodGet, uri: "/product", expectedResult: true, expectedParameters: nil, expectedError: nil},
 		}
 
 		for _, c := range cases {
 			c := c
 			t.Run(fmt.Sprintf("%s_%s", c.method, c.uri), func(t *testing.T) {
-				match := routeMatch{currentPath: c.uri}
+				match := RouteMatch{currentPath: c.uri}
 				assert.Equal(t, c.expectedResult, c.route.match(c.method, &match))
-				assert.Equal(t, c.expectedParameters, match.parameters)
-				assert.Equal(t, c.expectedError, match.err)
+				assert.Equal(t, c.expectedParameters, match.Parameters)
+				assert.Equal(t, c.expectedError, match.Err)
 			})
 		}
 
 		t.Run("err_not_overridden", func(t *testing.T) {
-			match := routeMatch{currentPath: "/product/33"}
+			match := RouteMatch{currentPath: "/product/33"}
 			route1.match(http.MethodPut, &match)
-			assert.Equal(t, errMatchMethodNotAllowed, match.err)
+			assert.Equal(t, ErrMatchMethodNotAllowed, match.Err)
 
 			match.currentPath = "/product/test"
 			route1.match(http.MethodGet, &match)
-			assert.Equal(t, errMatchMethodNotAllowed, match.err)
+			assert.Equal(t, ErrMatchMethodNotAllowed, match.Err)
 		})
 	})
 }
@@ -26,10 +26,15 @@ const (
 	RouteNotFound         = "goyave.not-found"
 )
 
+// Route matching errors.
 var (
-	errMatchMethodNotAllowed = errors.New("Method not allowed for this route")
-	errMatchNotFound         = errors.New("No match for this URI")
+	// ErrMatchMethodNotAllowed at least one route matches the path but not the method.
+	ErrMatchMethodNotAllowed = errors.New("Method not allowed for this route")
+	// ErrMatchNotFound no route matches the path.
+	ErrMatchNotFound = errors.New("No match for this URI")
+)
 
+var (
 	methodNotAllowedRoute = newRoute(func(response *Response, _ *Request) {
 		response.Status(http.StatusMethodNotAllowed)
 	}, RouteMethodNotAllowed)
@@ -46,27 +51,36 @@ var (
 type Handler func(response *Response, request *Request)
 
 type routeMatcher interface {
-	match(method string, match *routeMatch) bool
+	match(method string, match *RouteMatch) bool
 }
 
-type routeMatch struct {
-	route       *Route
-	parameters  map[string]string
-	err         error
+// RouteMatch the result of matching a path and a method against a router.
+type RouteMatch struct {
+	// Route the matched route. If no route matched, this is a special route
+	// named `RouteNotFound` or `RouteMethodNotAllowed`.
+	Route *Route
+
+	// Parameters the route parameters extracted from the path, identified
+	// by their name. Includes the parameters of the parent routers' prefixes.
+	Parameters map[string]string
+
+	// Err `nil` if a route matched, `ErrMatchNotFound` or `ErrMatchMethodNotAllowed` otherwise.
+	Err error
+
 	currentPath string
 }
 
-func (rm *routeMatch) mergeParams(params map[string]string) {
-	if rm.parameters == nil {
-		rm.parameters = params
+func (rm *RouteMatch) mergeParams(params map[string]string) {
+	if rm.Parameters == nil {
+		rm.Parameters = params
 		return
 	}
 	for k, v := range params {
-		rm.parameters[k] = v
+		rm.Parameters[k] = v
 	}
 }
 
-func (rm *routeMatch) trimCurrentPath(fullMatch string) {
+func (rm *RouteMatch) trimCurrentPath(fullMatch string) {
 	length := len(fullMatch)
 	rm.currentPath = rm.currentPath[length:]
 }
@@ -88,6 +102,9 @@ type Router struct {
 	routes     []*Route
 	subrouters []*Router
 
+	routeTree      routeTree
+	subrouterIndex subrouterIndex
+
 	slashCount int
 }
 
@@ -265,8 +282,8 @@ func (r *Router) StatusHandler(handler StatusHandler, status int, additionalStat
 
 // ServeHTTP dispatches the handler registered in the matched route.
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	if req.URL.Scheme != "" && req.URL.Scheme != "http" {
-		address := r.server.getProxyAddress(r.server.config) + req.URL.Path
+	if req.URL.Scheme != "" && req.URL.Scheme != r.server.scheme() {
+		address := r.server.getProxyAddress(r.server.Config()) + req.URL.Path
 		query := req.URL.Query()
 		if len(query) != 0 {
 			address += "?" + query.Encode()
@@ -275,14 +292,43 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	match := routeMatch{currentPath: req.URL.Path}
+	match := RouteMatch{currentPath: req.URL.Path}
 	r.match(req.Method, &match)
 	r.requestHandler(&match, w, req)
 }
 
-// TODO export RouteMatch and add Match with string param function
-
-func (r *Router) match(method string, match *routeMatch) bool {
+// Match finds the route matching the given method and path, exactly like
+// it would be when serving a request. The path should not contain the query
+// nor the fragment. The path is relative to the parent of this router, so
+// for the main router, it is the full path of the request.
+//
+// Returns the match and `true` if a route matched. Otherwise, returns the match
+// with the special not found or method not allowed route and `false`. In this case,
+// `RouteMatch.Err` tells the reason why no route matched.
+//
+//	match, ok := router.Match(http.MethodGet, "/users/123")
+//	if ok {
+//		fmt.Println(match.Route.GetName(), match.Parameters["userID"])
+//	}
+func (r *Router) Match(method, path string) (*RouteMatch, bool) {
+	match := &RouteMatch{currentPath: path}
+	r.match(method, match)
+	if match.Parameters == nil {
+		match.Parameters = map[string]string{}
+	}
+	switch match.Route {
+	case notFoundRoute:
+		match.Err = ErrMatchNotFound
+		return match, false
+	case methodNotAllowedRoute:
+		match.Err = ErrMatchMethodNotAllowed
+		return match, false
+	}
+	match.Err = nil
+	return match, true
+}
+
+func (r *Router) match(method string, match *RouteMatch) bool {
 	// Check if router itself matches
 	var params []string
 	if r.parameterizable.regex != nil {
@@ -295,7 +341,7 @@ func (r *Router) match(method string, match *routeMatch) bool {
 			i = len(match.currentPath)
 		}
 		currentPath := match.currentPath[:i]
-		params = r.parameterizable.regex.FindStringSubmatch(currentPath)
+		params = r.parameterizable.matchPath(currentPath, true)
 	} else {
 		params = []string{""}
 	}
@@ -307,9 +353,10 @@ func (r *Router) match(method string, match *routeMatch) bool {
 		}
 
 		// Check in subrouters first
-		for _, router := range r.subrouters {
+		for _, candidate := range r.subrouterIndex.candidates(match.currentPath) {
+			router := candidate.router
 			if router.match(method, match) {
-				if router.prefix == "" && match.route == methodNotAllowedRoute {
+				if router.prefix == "" && match.Route == methodNotAllowedRoute {
 					// This allows route groups with subrouters having empty prefix.
 					continue
 				}
@@ -318,19 +365,27 @@ func (r *Router) match(method string, match *routeMatch) bool {
 		}
 
 		// Check if any route matches
-		for _, route := range r.routes {
-			if route.match(method, match) {
+		if len(r.routes) > 0 {
+			route, routeParams, err := r.routeTree.match(method, match.currentPath)
+			if route != nil {
+				if len(routeParams) > 1 {
+					match.mergeParams(route.makeParameters(routeParams))
+				}
+				match.Route = route
 				return true
 			}
+			if match.Err == nil || err == ErrMatchMethodNotAllowed {
+				match.Err = err
+			}
 		}
 	}
 
-	if match.err == errMatchMethodNotAllowed {
-		match.route = methodNotAllowedRoute
+	if match.Err == ErrMatchMethodNotAllowed {
+		match.Route = methodNotAllowedRoute
 		return true
 	}
 
-	match.route = notFoundRoute
+	match.Route = notFoundRoute
 	// Return true if the subrouter matched so we don't turn back and check other subrouters
 	return params != nil && len(params[0]) > 0
 }
@@ -382,6 +437,7 @@ func (r *Router) Subrouter(prefix string) *Router {
 		router.slashCount = strings.Count(prefix, "/")
 	}
 	r.subrouters = append(r.subrouters, router)
+	r.subrouterIndex.insert(router, len(r.subrouters)-1)
 	return router
 }
 
@@ -471,6 +527,7 @@ func (r *Router) registerRoute(methods []string, uri string, handler Handler) *R
 	}
 	route.compileParameters(route.uri, true, r.regexCache)
 	r.routes = append(r.routes, route)
+	r.routeTree.insert(route, len(r.routes)-1)
 	return route
 }
 
@@ -482,21 +539,21 @@ func (r *Router) Controller(controller Registrer) *Router {
 	return r
 }
 
-func (r *Router) requestHandler(match *routeMatch, w http.ResponseWriter, rawRequest *http.Request) {
+func (r *Router) requestHandler(match *RouteMatch, w http.ResponseWriter, rawRequest *http.Request) {
 	request := NewRequest(rawRequest)
-	request.Route = match.route
-	if match.parameters == nil {
+	request.Route = match.Route
+	if match.Parameters == nil {
 		request.RouteParams = map[string]string{}
 	} else {
-		request.RouteParams = match.parameters
+		request.RouteParams = match.Parameters
 	}
 	response := NewResponse(r.server, request, w)
-	handler := match.route.handler
+	handler := match.Route.handler
 
 	// Route-specific middleware is executed after router middleware
-	handler = match.route.applyMiddleware(handler)
+	handler = match.Route.applyMiddleware(handler)
 
-	parent := match.route.parent
+	parent := match.Route.parent
 	for parent != nil {
 		handler = parent.applyMiddleware(handler)
 		parent = parent.parent
@@ -515,7 +572,7 @@ func (r *Router) requestHandler(match *routeMatch, w http.ResponseWriter, rawReq
 }
 
 // finalize the request's life-cycle.
-func (r *Router) finalize(match *routeMatch, response *Response, request *Request) error {
+func (r *Router) finalize(match *RouteMatch, response *Response, request *Request) error {
 	if response.empty {
 		if response.status == 0 {
 			// If the response is empty, return status 204 to
@@ -535,11 +592,11 @@ func (r *Router) finalize(match *routeMatch, response *Response, request *Reques
 	return errorutil.New(response.close())
 }
 
-func (r *Router) getStatusHandler(match *routeMatch, status int) (StatusHandler, bool) {
-	if match.route.parent == nil {
+func (r *Router) getStatusHandler(match *RouteMatch, status int) (StatusHandler, bool) {
+	if match.Route.parent == nil {
 		h, ok := r.statusHandlers[status]
 		return h, ok
 	}
-	h, ok := match.route.parent.statusHandlers[status]
+	h, ok := match.Route.parent.statusHandlers[status]
 	return h, ok
 }
@@ -1,6 +1,7 @@
 package goyave
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -25,3 +26,30 @@ func BenchmarkServeHTTP(b *testing.B) {
 		s.router.ServeHTTP(httptest.NewRecorder(), req)
 	}
 }
+
+func BenchmarkServeHTTPManyRoutes(b *testing.B) {
+	s, _ := New(Options{Config: config.LoadDefault()})
+
+	handler := func(r *Response, _ *Request) {
+		r.Status(http.StatusOK)
+	}
+	s.RegisterRoutes(func(_ *Server, r *Router) {
+		for i := 0; i < 100; i++ {
+			resource := r.Subrouter(fmt.Sprintf("/resource-%d", i))
+			resource.Get("/", handler)
+			resource.Post("/", handler)
+			resource.Get("/{id}", handler)
+			resource.Patch("/{id}", handler)
+			resource.Delete("/{id}", handler)
+			resource.Get("/{id}/children/{childId:[0-9]+}", handler)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/resource-99/1/children/2", nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		s.router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+}
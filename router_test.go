@@ -555,10 +555,53 @@ func TestRouter(t *testing.T) {
 		for _, c := range cases {
 			c := c
 			t.Run(fmt.Sprintf("%s_%s", c.method, strings.ReplaceAll(c.path, "/", "_")), func(t *testing.T) {
-				match := routeMatch{currentPath: c.path}
+				match := RouteMatch{currentPath: c.path}
 				router.match(c.method, &match)
-				assert.Equal(t, c.expectedRoute, match.route.name)
+				assert.Equal(t, c.expectedRoute, match.Route.name)
 			})
 		}
 	})
+
+	t.Run("Match", func(t *testing.T) {
+		router := prepareRouterTest()
+		users := router.Subrouter("/users")
+		users.Get("/", nil).Name("users.index")
+		users.Get("/{userID}", nil).Name("users.show")
+		users.Post("/{userID}/posts/{postID:[0-9]+}", nil).Name("users.posts.update")
+
+		cases := []struct {
+			expectedParams map[string]string
+			expectedErr    error
+			method         string
+			path           string
+			expectedRoute  string
+			expectedOK     bool
+		}{
+			{method: http.MethodGet, path: "/users", expectedRoute: "users.index", expectedParams: map[string]string{}, expectedOK: true},
+			{method: http.MethodGet, path: "/users/123", expectedRoute: "users.show", expectedParams: map[string]string{"userID": "123"}, expectedOK: true},
+			{method: http.MethodPost, path: "/users/123/posts/456", expectedRoute: "users.posts.update", expectedParams: map[string]string{"userID": "123", "postID": "456"}, expectedOK: true},
+			{method: http.MethodGet, path: "/users/123/posts/456", expectedRoute: RouteMethodNotAllowed, expectedParams: map[string]string{}, expectedErr: ErrMatchMethodNotAllowed},
+			{method: http.MethodGet, path: "/users/123/posts/abc", expectedRoute: RouteNotFound, expectedParams: map[string]string{}, expectedErr: ErrMatchNotFound},
+			{method: http.MethodGet, path: "/not-found", expectedRoute: RouteNotFound, expectedParams: map[string]string{}, expectedErr: ErrMatchNotFound},
+		}
+
+		for _, c := range cases {
+			c := c
+			t.Run(fmt.Sprintf("%s_%s", c.method, strings.ReplaceAll(c.path, "/", "_")), func(t *testing.T) {
+				match, ok := router.Match(c.method, c.path)
+				assert.Equal(t, c.expectedOK, ok)
+				require.NotNil(t, match)
+				assert.Equal(t, c.expectedRoute, match.Route.GetName())
+				assert.Equal(t, c.expectedParams, match.Parameters)
+				assert.Equal(t, c.expectedErr, match.Err)
+			})
+		}
+
+		t.Run("no_routes", func(t *testing.T) {
+			match, ok := prepareRouterTest().Match(http.MethodGet, "/")
+			assert.False(t, ok)
+			assert.Equal(t, RouteNotFound, match.Route.GetName())
+			assert.Equal(t, ErrMatchNotFound, match.Err)
+		})
+	})
 }
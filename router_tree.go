@@ -0,0 +1,211 @@
+package goyave
+
+import (
+	"regexp"
+	"strings"
+)
+
+// indexedRoute a route and its position in its parent router's routes.
+type indexedRoute struct {
+	route *Route
+	index int
+}
+
+// indexedRouter a subrouter and its position in its parent router's subrouters.
+type indexedRouter struct {
+	router *Router
+	index  int
+}
+
+// routeNode a node of the route tree. Each level of the tree represents
+// a slash-separated segment of the route URIs.
+type routeNode struct {
+	static map[string]*routeNode
+	param  *routeNode
+
+	// routes ending at this node, in order of registration.
+	routes []indexedRoute
+}
+
+// routeTree indexes the routes of a single router so they can be matched without
+// trying every one of them. Routes that can be matched using their segments
+// are stored in a tree. The others are matched using their regex.
+type routeTree struct {
+	root        routeNode
+	regexRoutes []indexedRoute
+}
+
+func (t *routeTree) insert(route *Route, index int) {
+	entry := indexedRoute{route: route, index: index}
+	if route.segments == nil {
+		t.regexRoutes = append(t.regexRoutes, entry)
+		return
+	}
+
+	node := &t.root
+	for _, segment := range route.segments {
+		node = node.child(segment)
+	}
+	node.routes = append(node.routes, entry)
+}
+
+func (n *routeNode) child(segment uriSegment) *routeNode {
+	if segment.param {
+		if n.param == nil {
+			n.param = &routeNode{}
+		}
+		return n.param
+	}
+
+	if n.static == nil {
+		n.static = make(map[string]*routeNode, 1)
+	}
+	child, ok := n.static[segment.value]
+	if !ok {
+		child = &routeNode{}
+		n.static[segment.value] = child
+	}
+	return child
+}
+
+// lookup appends all the routes whose URI matches the given path to "dst",
+// regardless of their methods. The result is not sorted.
+func (n *routeNode) lookup(path string, dst []indexedRoute) []indexedRoute {
+	part, rest, hasRest := strings.Cut(path, "/")
+
+	if child, ok := n.static[part]; ok {
+		dst = child.collect(rest, hasRest, dst)
+	}
+	if n.param != nil && part != "" {
+		dst = n.param.collect(rest, hasRest, dst)
+	}
+	return dst
+}
+
+func (n *routeNode) collect(rest string, hasRest bool, dst []indexedRoute) []indexedRoute {
+	if hasRest {
+		return n.lookup(rest, dst)
+	}
+	return append(dst, n.routes...)
+}
+
+// match finds the route to use for the given method and path, with the same
+// precedence as if each route was tried in order of registration.
+//
+// Returns the matched route and its parameters (in the regex submatch format). If no
+// route matches, returns `nil` and the match error: `ErrMatchMethodNotAllowed` if
+// at least one route matches the path but not the method, `ErrMatchNotFound` otherwise.
+func (t *routeTree) match(method, path string) (*Route, []string, error) {
+	var buf [8]indexedRoute
+	candidates := t.root.lookup(path, buf[:0])
+
+	best := indexedRoute{index: -1}
+	var bestParams []string
+	pathMatched := len(candidates) > 0
+	for _, c := range candidates {
+		if (best.route == nil || c.index < best.index) && c.route.checkMethod(method) {
+			best = c
+		}
+	}
+
+	for _, c := range t.regexRoutes {
+		if best.route != nil && c.index > best.index {
+			break // Regex routes are in order of registration
+		}
+		params := c.route.regex.FindStringSubmatch(path)
+		if params == nil {
+			continue
+		}
+		pathMatched = true
+		if c.route.checkMethod(method) {
+			best = c
+			bestParams = params
+			break
+		}
+	}
+
+	if best.route == nil {
+		if pathMatched {
+			return nil, nil, ErrMatchMethodNotAllowed
+		}
+		return nil, nil, ErrMatchNotFound
+	}
+
+	if bestParams == nil {
+		bestParams = best.route.matchSegments(path, false)
+	}
+	return best.route, bestParams, nil
+}
+
+// subrouterIndex indexes the subrouters of a router using the
+// first segment of their prefix.
+type subrouterIndex struct {
+	static  map[string][]indexedRouter
+	dynamic []indexedRouter
+}
+
+func (i *subrouterIndex) insert(router *Router, index int) {
+	entry := indexedRouter{router: router, index: index}
+	key, ok := staticPrefixKey(router.prefix)
+	if !ok {
+		i.dynamic = append(i.dynamic, entry)
+		return
+	}
+	if i.static == nil {
+		i.static = make(map[string][]indexedRouter, 1)
+	}
+	i.static[key] = append(i.static[key], entry)
+}
+
+// candidates returns the subrouters that may match the given path,
+// in order of registration.
+func (i *subrouterIndex) candidates(path string) []indexedRouter {
+	static := i.static[prefixKey(path)]
+	if len(static) == 0 {
+		return i.dynamic
+	}
+	if len(i.dynamic) == 0 {
+		return static
+	}
+
+	// Merge both lists, keeping the order of registration
+	result := make([]indexedRouter, 0, len(static)+len(i.dynamic))
+	s, d := 0, 0
+	for s < len(static) && d < len(i.dynamic) {
+		if static[s].index < i.dynamic[d].index {
+			result = append(result, static[s])
+			s++
+		} else {
+			result = append(result, i.dynamic[d])
+			d++
+		}
+	}
+	result = append(result, static[s:]...)
+	return append(result, i.dynamic[d:]...)
+}
+
+// prefixKey returns the first segment of the given path, including
+// its leading slash. ("/categories/123" -> "/categories")
+func prefixKey(path string) string {
+	if len(path) <= 1 {
+		return path
+	}
+	if i := strings.IndexByte(path[1:], '/'); i != -1 {
+		return path[:i+1]
+	}
+	return path
+}
+
+// staticPrefixKey returns the key of the given router prefix and true if its first
+// segment is static. Returns false if the first segment needs a regex or contains
+// a parameter, or if the prefix is empty, meaning the router may match any path.
+func staticPrefixKey(prefix string) (string, bool) {
+	if prefix == "" {
+		return "", false
+	}
+	key := prefixKey(prefix)
+	if strings.ContainsAny(key, "{}") || regexp.QuoteMeta(key) != key {
+		return "", false
+	}
+	return key, true
+}
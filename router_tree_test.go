@@ -0,0 +1,137 @@
+package goyave
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteTree(t *testing.T) {
+	t.Run("precedence", func(t *testing.T) {
+		router := prepareRouterTest()
+
+		// Routes are matched in order of registration,
+		// regardless of how they are matched internally.
+		router.Get("/users/{id}", nil).Name("users.show")
+		router.Get("/users/me", nil).Name("users.me")
+		router.Post("/users/me", nil).Name("users.me.update")
+		router.Get("/users/{id:[0-9]+}/posts", nil).Name("users.posts.regex")
+		router.Get("/users/{id}/posts", nil).Name("users.posts")
+		router.Put("/users/{id}/posts", nil).Name("users.posts.update")
+		router.Get("/files/{path:.+}", nil).Name("files")
+		router.Get("/files/static", nil).Name("files.static")
+		router.Delete("/articles/{id}", nil).Name("articles.delete")
+		router.Get("/articles/{id:[0-9]+}", nil).Name("articles.show")
+
+		cases := []struct {
+			path          string
+			method        string
+			expectedRoute string
+		}{
+			{path: "/users/me", method: http.MethodGet, expectedRoute: "users.show"},
+			{path: "/users/me", method: http.MethodPost, expectedRoute: "users.me.update"},
+			{path: "/users/me", method: http.MethodPatch, expectedRoute: RouteMethodNotAllowed},
+			{path: "/users/123/posts", method: http.MethodGet, expectedRoute: "users.posts.regex"},
+			{path: "/users/abc/posts", method: http.MethodGet, expectedRoute: "users.posts"},
+			{path: "/users/123/posts", method: http.MethodPut, expectedRoute: "users.posts.update"},
+			{path: "/users/123/posts", method: http.MethodDelete, expectedRoute: RouteMethodNotAllowed},
+			{path: "/files/static", method: http.MethodGet, expectedRoute: "files"},
+			{path: "/files/a/b/c", method: http.MethodGet, expectedRoute: "files"},
+			{path: "/articles/123", method: http.MethodGet, expectedRoute: "articles.show"},
+			{path: "/articles/abc", method: http.MethodGet, expectedRoute: RouteMethodNotAllowed},
+			{path: "/articles/abc", method: http.MethodDelete, expectedRoute: "articles.delete"},
+			{path: "/unknown", method: http.MethodGet, expectedRoute: RouteNotFound},
+		}
+
+		for _, c := range cases {
+			c := c
+			t.Run(fmt.Sprintf("%s_%s", c.method, strings.ReplaceAll(c.path, "/", "_")), func(t *testing.T) {
+				match := RouteMatch{currentPath: c.path}
+				router.match(c.method, &match)
+				assert.Equal(t, c.expectedRoute, match.Route.name)
+			})
+		}
+	})
+
+	t.Run("subrouter_precedence", func(t *testing.T) {
+		router := prepareRouterTest()
+
+		dynamic := router.Subrouter("/{category}")
+		dynamic.Get("/products", nil).Name("category.products")
+		static := router.Subrouter("/shop")
+		static.Get("/products", nil).Name("shop.products")
+		static.Get("/cart", nil).Name("shop.cart")
+
+		cases := []struct {
+			path          string
+			expectedRoute string
+		}{
+			{path: "/shop/products", expectedRoute: "category.products"}, // Dynamic subrouter registered first
+			{path: "/shop/cart", expectedRoute: RouteNotFound},           // Matched by dynamic subrouter
+		}
+
+		for _, c := range cases {
+			match := RouteMatch{currentPath: c.path}
+			router.match(http.MethodGet, &match)
+			assert.Equal(t, c.expectedRoute, match.Route.name, c.path)
+		}
+	})
+
+	t.Run("params", func(t *testing.T) {
+		router := prepareRouterTest()
+		router.Get("/users/{id}/posts/{postId}", nil).Name("users.posts.show")
+		router.Subrouter("/categories/{category}").Get("/{id}", nil).Name("categories.show")
+
+		match := RouteMatch{currentPath: "/users/1/posts/2"}
+		router.match(http.MethodGet, &match)
+		assert.Equal(t, "users.posts.show", match.Route.name)
+		assert.Equal(t, map[string]string{"id": "1", "postId": "2"}, match.Parameters)
+
+		match = RouteMatch{currentPath: "/categories/food/3"}
+		router.match(http.MethodGet, &match)
+		assert.Equal(t, "categories.show", match.Route.name)
+		assert.Equal(t, map[string]string{"category": "food", "id": "3"}, match.Parameters)
+	})
+
+	t.Run("subrouterIndex", func(t *testing.T) {
+		router := prepareRouterTest()
+		a := router.Subrouter("/a")
+		group := router.Group()
+		b := router.Subrouter("/b/{id}")
+		a2 := router.Subrouter("/a/{id}")
+		param := router.Subrouter("/{name}")
+
+		routers := func(candidates []indexedRouter) []*Router {
+			result := make([]*Router, 0, len(candidates))
+			for _, c := range candidates {
+				result = append(result, c.router)
+			}
+			return result
+		}
+
+		assert.Equal(t, []*Router{a, group, a2, param}, routers(router.subrouterIndex.candidates("/a/1")))
+		assert.Equal(t, []*Router{group, b, param}, routers(router.subrouterIndex.candidates("/b")))
+		assert.Equal(t, []*Router{group, param}, routers(router.subrouterIndex.candidates("/c")))
+		assert.Equal(t, []*Router{group, param}, routers(router.subrouterIndex.candidates("")))
+	})
+
+	t.Run("prefixKey", func(t *testing.T) {
+		assert.Equal(t, "", prefixKey(""))
+		assert.Equal(t, "/", prefixKey("/"))
+		assert.Equal(t, "/a", prefixKey("/a"))
+		assert.Equal(t, "/a", prefixKey("/a/"))
+		assert.Equal(t, "/abc", prefixKey("/abc/def/ghi"))
+
+		key, ok := staticPrefixKey("/abc/{id}")
+		assert.True(t, ok)
+		assert.Equal(t, "/abc", key)
+
+		for _, prefix := range []string{"", "/{id}", "/abc{id}", "/v1.0"} {
+			_, ok = staticPrefixKey(prefix)
+			assert.False(t, ok, prefix)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package goyave
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
 	"fmt"
 	"log"
@@ -10,6 +11,7 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"syscall"
 	"time"
@@ -37,6 +39,17 @@ type Options struct {
 	// the default configuration using `config.Load()`.
 	Config *config.Config
 
+	// ReloadableConfig optionally provides a configuration that can be reloaded at runtime.
+	// If provided, `Config` is ignored and `Server.Config()` always returns the current
+	// configuration of the `Reloadable`. While the server is running, the configuration
+	// is reloaded each time the process receives `SIGHUP`.
+	//
+	// Settings used when the server is created (such as "server.port", the timeouts or
+	// the database connection) are not affected by a reload. The base URLs (see `Server.BaseURL()`
+	// and `Server.ProxyBaseURL()`) are updated when the "server" category changes.
+	// Components can subscribe to changes using `Reloadable.Subscribe()`.
+	ReloadableConfig *config.Reloadable
+
 	// Logger used by the server and propagated to all its components.
 	// If no logger is provided in the options, uses the default logger.
 	Logger *slog.Logger
@@ -47,6 +60,12 @@ type Options struct {
 	// If not provided, uses `osfs.FS` as a default.
 	LangFS fsutil.FS
 
+	// LanguageResolvers the chain of resolvers used to negotiate the language of each
+	// request. The first resolved language that is available is used. If none are
+	// available, the default language is used.
+	// If not provided, uses `DefaultLanguageResolvers()`.
+	LanguageResolvers []LanguageResolver
+
 	// ConnState specifies an optional callback function that is
 	// called when a client connection changes state. See the
 	// `http.ConnState` type and associated constants for details.
@@ -80,17 +99,42 @@ type Options struct {
 	// size of the request body.
 	// If zero, http.DefaultMaxHeaderBytes is used.
 	MaxHeaderBytes int
+
+	// TLSConfig optionally provides a TLS configuration to use when
+	// serving HTTPS. If not nil, or if the "server.tls.cert" and "server.tls.key"
+	// config entries are set, the server is started with TLS and
+	// HTTP/2 enabled.
+	//
+	// If the config entries are set, the certificate is provided by a
+	// `CertificateProvider` through the `GetCertificate` function of a copy of
+	// this configuration. The certificate is automatically reloaded when its files
+	// change, without restarting the server. Because the certificate would be provided
+	// twice, `New()` returns an error if the config entries are set while this configuration
+	// already defines `Certificates` or `GetCertificate`.
+	TLSConfig *tls.Config
+
+	// TLSFS the file system from which the TLS certificate and key
+	// defined by the "server.tls.cert" and "server.tls.key" config entries
+	// are loaded and watched.
+	// If not provided, uses `osfs.FS` as a default.
+	TLSFS fsutil.FS
 }
 
 // Server the central component of a Goyave application.
 type Server struct {
-	server *http.Server
-	config *config.Config
-	Lang   *lang.Languages
+	server         *http.Server
+	redirectServer atomic.Pointer[http.Server]
+	config         *config.Config
+	reloadable     *config.Reloadable
+	Lang           *lang.Languages
+
+	languageResolvers []LanguageResolver
 
 	router *Router
 	db     *gorm.DB
 
+	certificateProvider *CertificateProvider
+
 	services map[string]Service
 
 	// Logger the logger for default output
@@ -98,8 +142,8 @@ type Server struct {
 	Logger *slog.Logger
 
 	host         string
-	baseURL      string
-	proxyBaseURL string
+	baseURL      atomic.Pointer[string]
+	proxyBaseURL atomic.Pointer[string]
 
 	stopChannel chan struct{}
 	sigChannel  chan os.Signal
@@ -110,6 +154,7 @@ type Server struct {
 	shutdownHooks []func(*Server)
 
 	port int
+	tls  bool
 
 	state atomic.Uint32 // 0 -> created, 1 -> preparing, 2 -> ready, 3 -> stopped
 }
@@ -117,8 +162,11 @@ type Server struct {
 // New create a new `Server` using the given options.
 func New(opts Options) (*Server, error) {
 	cfg := opts.Config
+	if opts.ReloadableConfig != nil {
+		cfg = opts.ReloadableConfig.Config()
+	}
 
-	if opts.Config == nil {
+	if cfg == nil {
 		var err error
 		cfg, err = config.Load()
 		if err != nil {
@@ -142,6 +190,21 @@ func New(opts Options) (*Server, error) {
 		return nil, err
 	}
 
+	languageResolvers := opts.LanguageResolvers
+	if languageResolvers == nil {
+		languageResolvers = DefaultLanguageResolvers()
+	}
+
+	tlsFS := opts.TLSFS
+	if tlsFS == nil {
+		tlsFS = &osfs.FS{}
+	}
+
+	tlsConfig, certificateProvider, err := makeTLSConfig(cfg, opts.TLSConfig, tlsFS)
+	if err != nil {
+		return nil, err
+	}
+
 	port := cfg.GetInt("server.port")
 	host := cfg.GetString("server.host") + ":" + strconv.Itoa(port)
 
@@ -155,18 +218,23 @@ func New(opts Options) (*Server, error) {
 			ConnState:         opts.ConnState,
 			ConnContext:       opts.ConnContext,
 			MaxHeaderBytes:    opts.MaxHeaderBytes,
+			TLSConfig:         tlsConfig,
 		},
-		ctx:           context.Background(),
-		baseContext:   opts.BaseContext,
-		config:        cfg,
-		services:      make(map[string]Service),
-		Lang:          languages,
-		stopChannel:   make(chan struct{}, 1),
-		startupHooks:  []func(*Server){},
-		shutdownHooks: []func(*Server){},
-		host:          cfg.GetString("server.host"),
-		port:          port,
-		Logger:        slogger,
+		ctx:                 context.Background(),
+		baseContext:         opts.BaseContext,
+		config:              cfg,
+		reloadable:          opts.ReloadableConfig,
+		services:            make(map[string]Service),
+		Lang:                languages,
+		languageResolvers:   languageResolvers,
+		certificateProvider: certificateProvider,
+		stopChannel:         make(chan struct{}, 1),
+		startupHooks:        []func(*Server){},
+		shutdownHooks:       []func(*Server){},
+		host:                cfg.GetString("server.host"),
+		port:                port,
+		tls:                 tlsConfig != nil,
+		Logger:              slogger,
 	}
 	server.server.BaseContext = server.internalBaseContext
 	server.refreshURLs()
@@ -189,8 +257,57 @@ func (s *Server) internalBaseContext(_ net.Listener) context.Context {
 	return s.ctx
 }
 
+// makeTLSConfig returns the TLS configuration the server should use, or `nil`
+// if the server should not use TLS. If the certificate is defined in the config,
+// also returns the `CertificateProvider` responsible for it.
+func makeTLSConfig(cfg *config.Config, base *tls.Config, tlsFS fsutil.FS) (*tls.Config, *CertificateProvider, error) {
+	hasCert := cfg.Has("server.tls.cert")
+	hasKey := cfg.Has("server.tls.key")
+	if hasCert != hasKey {
+		return nil, nil, errors.New("both \"server.tls.cert\" and \"server.tls.key\" config entries must be set to enable TLS")
+	}
+	if base == nil && !hasCert {
+		return nil, nil, nil
+	}
+
+	var tlsConfig *tls.Config
+	if base != nil {
+		tlsConfig = base.Clone()
+	} else {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	if !hasCert {
+		return tlsConfig, nil, nil
+	}
+	if tlsConfig.GetCertificate != nil || len(tlsConfig.Certificates) > 0 {
+		return nil, nil, errors.New("\"server.tls.cert\" and \"server.tls.key\" config entries cannot be set if the TLS config option already provides certificates")
+	}
+
+	provider, err := NewCertificateProvider(tlsFS, cfg.GetString("server.tls.cert"), cfg.GetString("server.tls.key"))
+	if err != nil {
+		return nil, nil, err
+	}
+	tlsConfig.GetCertificate = provider.GetCertificate
+	return tlsConfig, provider, nil
+}
+
+// isTLS returns true if the server serves HTTPS. `http.Server.TLSConfig` cannot be used
+// for this because `http.Server` sets it when serving plain HTTP too.
+func (s *Server) isTLS() bool {
+	return s.tls
+}
+
+func (s *Server) scheme() string {
+	if s.isTLS() {
+		return "https"
+	}
+	return "http"
+}
+
 func (s *Server) getAddress(cfg *config.Config) string {
-	shouldShowPort := s.port != 80
+	scheme := s.scheme()
+	shouldShowPort := (scheme == "http" && s.port != 80) || (scheme == "https" && s.port != 443)
 	host := cfg.GetString("server.domain")
 	if len(host) == 0 {
 		host = cfg.GetString("server.host")
@@ -203,7 +320,7 @@ func (s *Server) getAddress(cfg *config.Config) string {
 		host += ":" + strconv.Itoa(s.port)
 	}
 
-	return "http://" + host
+	return scheme + "://" + host
 }
 
 func (s *Server) getProxyAddress(cfg *config.Config) string {
@@ -228,8 +345,11 @@ func (s *Server) getProxyAddress(cfg *config.Config) string {
 }
 
 func (s *Server) refreshURLs() {
-	s.baseURL = s.getAddress(s.config)
-	s.proxyBaseURL = s.getProxyAddress(s.config)
+	cfg := s.Config()
+	baseURL := s.getAddress(cfg)
+	proxyBaseURL := s.getProxyAddress(cfg)
+	s.baseURL.Store(&baseURL)
+	s.proxyBaseURL.Store(&proxyBaseURL)
 }
 
 // Service returns the service identified by the given name.
@@ -270,14 +390,14 @@ func (s *Server) Port() int {
 // If "server.domain" is set in the config, uses it instead
 // of an IP address.
 func (s *Server) BaseURL() string {
-	return s.baseURL
+	return *s.baseURL.Load()
 }
 
 // ProxyBaseURL returns the base URL of your application based on the "server.proxy" configuration.
 // This is useful when you want to generate an URL when your application is served behind a reverse proxy.
 // If "server.proxy.host" configuration is not set, returns the same value as "BaseURL()".
 func (s *Server) ProxyBaseURL() string {
-	return s.proxyBaseURL
+	return *s.proxyBaseURL.Load()
 }
 
 // IsReady returns true if the server has finished initializing and
@@ -312,8 +432,12 @@ func (s *Server) ClearShutdownHooks() {
 	s.shutdownHooks = []func(*Server){}
 }
 
-// Config returns the server's config.
+// Config returns the server's config. If the server was created with a reloadable
+// configuration, returns its current configuration.
 func (s *Server) Config() *config.Config {
+	if s.reloadable != nil {
+		return s.reloadable.Config()
+	}
 	return s.config
 }
 
@@ -358,7 +482,7 @@ func (s *Server) ReplaceDB(dialector gorm.Dialector) error {
 		return err
 	}
 
-	db, err := database.NewFromDialector(s.config, func() *slog.Logger { return s.Logger }, dialector)
+	db, err := database.NewFromDialector(s.Config(), func() *slog.Logger { return s.Logger }, dialector)
 	if err != nil {
 		return err
 	}
@@ -389,6 +513,12 @@ func (s *Server) Router() *Router {
 }
 
 // Start the server. This operation is blocking and returns when the server is closed.
+//
+// If TLS is enabled, the server serves HTTPS and HTTP/2. If the "server.tls.redirectPort"
+// config entry is set, an additional listener permanently redirecting all requests to
+// HTTPS (on the host requested by the client, or the proxy base URL) is started on this port. If the certificate is defined in the config,
+// its files are checked for changes every "server.tls.reloadInterval" seconds and the
+// certificate is reloaded automatically.
 func (s *Server) Start() error {
 	swapped := s.state.CompareAndSwap(0, 1)
 	if !swapped {
@@ -432,8 +562,37 @@ func (s *Server) Start() error {
 		}
 	}()
 
+	if s.isTLS() && s.Config().Has("server.tls.redirectPort") {
+		if err := s.startRedirectServer(); err != nil {
+			_ = ln.Close()
+			return err
+		}
+	}
+
 	s.state.Store(2)
 
+	if s.certificateProvider != nil {
+		if interval := s.Config().GetInt("server.tls.reloadInterval"); interval > 0 {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go s.certificateProvider.Watch(ctx, time.Duration(interval)*time.Second, func(err error) {
+				s.Logger.Error(err)
+			})
+		}
+	}
+
+	if s.reloadable != nil {
+		unsubscribe := s.reloadable.Subscribe("server", func(config.Change) {
+			s.refreshURLs()
+		})
+		defer unsubscribe()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go s.reloadable.WatchSignal(ctx, func(err error) {
+			s.Logger.Error(err)
+		})
+	}
+
 	go func(s *Server) {
 		if s.IsReady() {
 			// We check if the server is ready to prevent startup hook execution
@@ -443,13 +602,94 @@ func (s *Server) Start() error {
 			}
 		}
 	}(s)
-	if err := s.server.Serve(ln); err != nil && !stderrors.Is(err, http.ErrServerClosed) {
+	if err := s.serve(ln); err != nil && !stderrors.Is(err, http.ErrServerClosed) {
 		s.state.Store(3)
+		s.stopRedirectServer()
 		return errors.New(err)
 	}
 	return nil
 }
 
+func (s *Server) serve(ln net.Listener) error {
+	if s.isTLS() {
+		// Certificates are already loaded in the TLS config.
+		return s.server.ServeTLS(ln, "", "")
+	}
+	return s.server.Serve(ln)
+}
+
+// startRedirectServer starts listening on the port defined by the "server.tls.redirectPort"
+// config entry. All requests received on this port are permanently redirected to HTTPS.
+func (s *Server) startRedirectServer() error {
+	addr := s.host + ":" + strconv.Itoa(s.Config().GetInt("server.tls.redirectPort"))
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.New(err)
+	}
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           http.HandlerFunc(s.redirectHandler),
+		WriteTimeout:      s.server.WriteTimeout,
+		ReadTimeout:       s.server.ReadTimeout,
+		ReadHeaderTimeout: s.server.ReadHeaderTimeout,
+		IdleTimeout:       s.server.IdleTimeout,
+		ErrorLog:          s.server.ErrorLog,
+	}
+	s.redirectServer.Store(server)
+	go func(server *http.Server) {
+		if err := server.Serve(ln); err != nil && !stderrors.Is(err, http.ErrServerClosed) {
+			s.Logger.Error(errors.New(err))
+		}
+	}(server)
+	if s.state.Load() == 3 {
+		// Stop() was called while the server was starting and may not
+		// have seen the redirect server.
+		s.stopRedirectServer()
+	}
+	return nil
+}
+
+func (s *Server) stopRedirectServer() {
+	server := s.redirectServer.Load()
+	if server == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		s.Logger.Error(errors.NewSkip(err, 3))
+	}
+}
+
+// redirectHandler permanently redirects the request to HTTPS. If a proxy is configured,
+// the request is redirected to the proxy base URL. Otherwise, the target is built from the
+// host requested by the client, so the redirection works for clients that are not on the
+// same host as the server.
+func (s *Server) redirectHandler(w http.ResponseWriter, req *http.Request) {
+	http.Redirect(w, req, s.redirectURL(req)+req.URL.RequestURI(), http.StatusPermanentRedirect)
+}
+
+func (s *Server) redirectURL(req *http.Request) string {
+	if s.Config().Has("server.proxy.host") {
+		return s.ProxyBaseURL()
+	}
+	if req.Host == "" {
+		return s.BaseURL()
+	}
+	host := req.Host
+	if h, _, err := net.SplitHostPort(req.Host); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	if s.port == 443 {
+		if strings.Contains(host, ":") {
+			host = "[" + host + "]" // IPv6
+		}
+		return "https://" + host
+	}
+	return "https://" + net.JoinHostPort(host, strconv.Itoa(s.port))
+}
+
 // RegisterRoutes creates a new Router for this Server and runs the given `routeRegistrer`.
 func (s *Server) RegisterRoutes(routeRegistrer func(*Server, *Router)) {
 	routeRegistrer(s, s.router)
@@ -482,6 +722,7 @@ func (s *Server) Stop() {
 		signal.Stop(s.sigChannel)
 		close(s.sigChannel)
 	}
+	s.stopRedirectServer()
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	err := s.server.Shutdown(ctx)
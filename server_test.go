@@ -3,17 +3,30 @@ package goyave
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"math/big"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
 	"sync"
 	"syscall"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"embed"
@@ -27,6 +40,7 @@ import (
 	"goyave.dev/goyave/v5/slog"
 	"goyave.dev/goyave/v5/util/errors"
 	"goyave.dev/goyave/v5/util/fsutil"
+	"goyave.dev/goyave/v5/util/fsutil/osfs"
 )
 
 //go:embed resources
@@ -40,6 +54,36 @@ func (s *DummyService) Name() string {
 	return "dummy"
 }
 
+// generateTestCertificate creates a self-signed certificate for "127.0.0.1"
+// and "localhost" in a temporary directory. Returns the paths to the certificate
+// and the key files.
+func generateTestCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{Organization: []string{"Goyave"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
 func TestServer(t *testing.T) {
 	t.Run("New", func(t *testing.T) {
 		// Create a test config file (with only the app name)
@@ -172,6 +216,108 @@ func TestServer(t *testing.T) {
 		})
 	})
 
+	t.Run("getAddress_tls", func(t *testing.T) {
+		cfg := config.LoadDefault()
+		server := &Server{config: cfg, port: 443, tls: true}
+		assert.Equal(t, "https://127.0.0.1", server.getAddress(cfg))
+
+		server.port = 8443
+		assert.Equal(t, "https://127.0.0.1:8443", server.getAddress(cfg))
+
+		server.port = 80
+		assert.Equal(t, "https://127.0.0.1:80", server.getAddress(cfg))
+	})
+
+	t.Run("NewWithTLS", func(t *testing.T) {
+		certFile, keyFile := generateTestCertificate(t)
+
+		t.Run("config", func(t *testing.T) {
+			cfg := config.LoadDefault()
+			cfg.Set("server.tls.cert", certFile)
+			cfg.Set("server.tls.key", keyFile)
+			server, err := New(Options{Config: cfg})
+			require.NoError(t, err)
+			require.NotNil(t, server.server.TLSConfig)
+			require.NotNil(t, server.certificateProvider)
+			require.NotNil(t, server.server.TLSConfig.GetCertificate)
+			cert, err := server.server.TLSConfig.GetCertificate(&tls.ClientHelloInfo{})
+			require.NoError(t, err)
+			assert.NotNil(t, cert)
+			assert.Equal(t, "https://127.0.0.1:8080", server.BaseURL())
+		})
+
+		t.Run("options", func(t *testing.T) {
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			require.NoError(t, err)
+			tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS13}
+			server, err := New(Options{Config: config.LoadDefault(), TLSConfig: tlsConfig})
+			require.NoError(t, err)
+			require.NotNil(t, server.server.TLSConfig)
+			assert.NotSame(t, tlsConfig, server.server.TLSConfig) // The config is cloned
+			assert.Equal(t, uint16(tls.VersionTLS13), server.server.TLSConfig.MinVersion)
+			assert.Len(t, server.server.TLSConfig.Certificates, 1)
+			assert.Nil(t, server.certificateProvider)
+		})
+
+		t.Run("options_and_config_conflict", func(t *testing.T) {
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			require.NoError(t, err)
+			cfg := config.LoadDefault()
+			cfg.Set("server.tls.cert", certFile)
+			cfg.Set("server.tls.key", keyFile)
+
+			tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS13}
+			server, err := New(Options{Config: cfg, TLSConfig: tlsConfig})
+			require.Error(t, err)
+			assert.Nil(t, server)
+
+			getCertificate := func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return &cert, nil }
+			tlsConfig = &tls.Config{GetCertificate: getCertificate, MinVersion: tls.VersionTLS13}
+			server, err = New(Options{Config: cfg, TLSConfig: tlsConfig})
+			require.Error(t, err)
+			assert.Nil(t, server)
+		})
+
+		t.Run("options_without_certificate", func(t *testing.T) {
+			cfg := config.LoadDefault()
+			cfg.Set("server.tls.cert", certFile)
+			cfg.Set("server.tls.key", keyFile)
+			tlsConfig := &tls.Config{MinVersion: tls.VersionTLS13}
+			server, err := New(Options{Config: cfg, TLSConfig: tlsConfig})
+			require.NoError(t, err)
+			assert.NotNil(t, server.certificateProvider)
+			assert.Nil(t, tlsConfig.GetCertificate) // The caller's config is not modified
+			assert.Equal(t, uint16(tls.VersionTLS13), server.server.TLSConfig.MinVersion)
+			require.NotNil(t, server.server.TLSConfig.GetCertificate)
+		})
+
+		t.Run("fs", func(t *testing.T) {
+			cfg := config.LoadDefault()
+			cfg.Set("server.tls.cert", filepath.Base(certFile))
+			cfg.Set("server.tls.key", filepath.Base(keyFile))
+			server, err := New(Options{Config: cfg, TLSFS: osfs.New(filepath.Dir(certFile))})
+			require.NoError(t, err)
+			assert.NotNil(t, server.certificateProvider)
+		})
+
+		t.Run("missing_key", func(t *testing.T) {
+			cfg := config.LoadDefault()
+			cfg.Set("server.tls.cert", certFile)
+			server, err := New(Options{Config: cfg})
+			require.Error(t, err)
+			assert.Nil(t, server)
+		})
+
+		t.Run("invalid_cert", func(t *testing.T) {
+			cfg := config.LoadDefault()
+			cfg.Set("server.tls.cert", keyFile)
+			cfg.Set("server.tls.key", keyFile)
+			server, err := New(Options{Config: cfg})
+			require.Error(t, err)
+			assert.Nil(t, server)
+		})
+	})
+
 	t.Run("getProxyAddress", func(t *testing.T) {
 		t.Run("full", func(t *testing.T) {
 			cfg := config.LoadDefault()
@@ -408,6 +554,140 @@ func TestServer(t *testing.T) {
 		assert.Equal(t, uint32(3), server.state.Load())
 	})
 
+	t.Run("startRedirectServer_stopped", func(t *testing.T) {
+		cfg := config.LoadDefault()
+		cfg.Set("server.tls.redirectPort", 0)
+		server, err := New(Options{Config: cfg})
+		require.NoError(t, err)
+
+		// Stop() called while the server is starting
+		server.state.Store(3)
+		require.NoError(t, server.startRedirectServer())
+		redirectServer := server.redirectServer.Load()
+		require.NotNil(t, redirectServer)
+		assert.ErrorIs(t, redirectServer.ListenAndServe(), http.ErrServerClosed)
+	})
+
+	t.Run("redirectURL", func(t *testing.T) {
+		cfg := config.LoadDefault()
+		server := &Server{config: cfg, port: 8443, tls: true}
+		server.refreshURLs()
+
+		cases := []struct {
+			host     string
+			port     int
+			expected string
+		}{
+			{host: "example.org", port: 8443, expected: "https://example.org:8443"},
+			{host: "example.org:80", port: 8443, expected: "https://example.org:8443"},
+			{host: "example.org:80", port: 443, expected: "https://example.org"},
+			{host: "[::1]:80", port: 8443, expected: "https://[::1]:8443"},
+			{host: "[::1]", port: 443, expected: "https://[::1]"},
+			{host: "", port: 8443, expected: "https://127.0.0.1:8443"},
+		}
+		for _, c := range cases {
+			t.Run(c.host, func(t *testing.T) {
+				server.port = c.port
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Host = c.host
+				assert.Equal(t, c.expected, server.redirectURL(req))
+			})
+		}
+
+		t.Run("proxy", func(t *testing.T) {
+			cfg := config.LoadDefault()
+			cfg.Set("server.proxy.host", "proxy.example.org")
+			cfg.Set("server.proxy.protocol", "https")
+			cfg.Set("server.proxy.port", 443)
+			cfg.Set("server.proxy.base", "/base")
+			server := &Server{config: cfg, port: 8443, tls: true}
+			server.refreshURLs()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			assert.Equal(t, "https://proxy.example.org/base", server.redirectURL(req))
+
+			rec := httptest.NewRecorder()
+			req = httptest.NewRequest(http.MethodGet, "/path?query=value", nil)
+			server.redirectHandler(rec, req)
+			assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+			assert.Equal(t, "https://proxy.example.org/base/path?query=value", rec.Header().Get("Location"))
+		})
+	})
+
+	t.Run("StartTLS", func(t *testing.T) {
+		certFile, keyFile := generateTestCertificate(t)
+		cfg := config.LoadDefault()
+		cfg.Set("server.port", 0)
+		cfg.Set("server.tls.cert", certFile)
+		cfg.Set("server.tls.key", keyFile)
+		cfg.Set("server.tls.redirectPort", 8887)
+		server, err := New(Options{Config: cfg})
+		require.NoError(t, err)
+
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+
+		server.RegisterStartupHook(func(s *Server) {
+			defer wg.Done()
+			defer server.Stop()
+
+			client := &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+					ForceAttemptHTTP2: true,
+				},
+				CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+					return http.ErrUseLastResponse
+				},
+			}
+
+			res, err := client.Get(s.BaseURL())
+			if !assert.NoError(t, err) {
+				return
+			}
+			respBody, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, res.Body.Close())
+			assert.Equal(t, []byte("hello world"), respBody)
+			assert.Equal(t, 2, res.ProtoMajor)
+			assert.NotNil(t, res.TLS)
+
+			res, err = client.Get("http://127.0.0.1:8887/path?query=value")
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.NoError(t, res.Body.Close())
+			assert.Equal(t, http.StatusPermanentRedirect, res.StatusCode)
+			assert.Equal(t, s.BaseURL()+"/path?query=value", res.Header.Get("Location"))
+			// The target host is the one requested by the client
+			req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1:8887/path", nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			req.Host = "example.org:8887"
+			res, err = client.Do(req)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.NoError(t, res.Body.Close())
+			assert.Equal(t, "https://example.org:"+strconv.Itoa(s.Port())+"/path", res.Header.Get("Location"))
+		})
+
+		server.RegisterRoutes(func(_ *Server, router *Router) {
+			router.Get("/", func(r *Response, _ *Request) {
+				r.String(http.StatusOK, "hello world")
+			})
+		})
+
+		go func() {
+			err := server.Start()
+			assert.NoError(t, err)
+			wg.Done()
+		}()
+
+		wg.Wait()
+		assert.False(t, server.IsReady())
+	})
+
 	t.Run("Start_already_running", func(t *testing.T) {
 		server, err := New(Options{Config: config.LoadDefault()})
 		require.NoError(t, err)
@@ -519,6 +799,111 @@ func TestServer(t *testing.T) {
 		assert.False(t, server.IsReady())
 	})
 
+	t.Run("ReloadableConfig", func(t *testing.T) {
+		filesystem := fstest.MapFS{
+			"config.json": {Data: []byte(`{"server": {"port": 0}, "app": {"name": "before"}}`), ModTime: time.Now()},
+		}
+		reloadable, err := config.NewReloadable(&config.File{FS: filesystem, Path: "config.json"})
+		require.NoError(t, err)
+
+		server, err := New(Options{Config: config.LoadDefault(), ReloadableConfig: reloadable})
+		require.NoError(t, err)
+		assert.Equal(t, "before", server.Config().GetString("app.name"))
+
+		filesystem["config.json"] = &fstest.MapFile{Data: []byte(`{"server": {"port": 0}, "app": {"name": "after"}}`), ModTime: time.Now()}
+		_, err = reloadable.Reload()
+		require.NoError(t, err)
+		assert.Equal(t, "after", server.Config().GetString("app.name"))
+		assert.Same(t, reloadable.Config(), server.Config())
+
+		if runtime.GOOS == "windows" {
+			t.Skip("Testing on a windows machine. Cannot test proc signals")
+		}
+
+		// Prevent the process from being terminated if the signal is received
+		// before the server starts listening to it.
+		sigChannel := make(chan os.Signal, 1)
+		signal.Notify(sigChannel, syscall.SIGHUP)
+		defer signal.Stop(sigChannel)
+
+		changed := make(chan string, 1)
+		reloadable.Subscribe("app.name", func(change config.Change) {
+			changed <- change.Value.(string)
+		})
+		proc, err := os.FindProcess(os.Getpid())
+		require.NoError(t, err)
+
+		// Written before the server starts to avoid racing with the signal watcher
+		filesystem["config.json"] = &fstest.MapFile{Data: []byte(`{"server": {"port": 0}, "app": {"name": "signal"}}`), ModTime: time.Now()}
+
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+		server.RegisterStartupHook(func(s *Server) {
+			defer wg.Done()
+			defer s.Stop()
+			timeout := time.After(time.Second)
+			for {
+				require.NoError(t, proc.Signal(syscall.SIGHUP))
+				select {
+				case value := <-changed:
+					assert.Equal(t, "signal", value)
+					assert.Equal(t, "signal", s.Config().GetString("app.name"))
+					return
+				case <-timeout:
+					assert.Fail(t, "config not reloaded on SIGHUP")
+					return
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+		})
+
+		go func() {
+			err := server.Start()
+			assert.NoError(t, err)
+			wg.Done()
+		}()
+		wg.Wait()
+	})
+
+	t.Run("ReloadableConfig_URLs", func(t *testing.T) {
+		filesystem := fstest.MapFS{
+			"config.json": {Data: []byte(`{"server": {"port": 0, "domain": "before.example.org"}}`), ModTime: time.Now()},
+		}
+		reloadable, err := config.NewReloadable(&config.File{FS: filesystem, Path: "config.json"})
+		require.NoError(t, err)
+
+		server, err := New(Options{ReloadableConfig: reloadable})
+		require.NoError(t, err)
+
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+		server.RegisterStartupHook(func(s *Server) {
+			defer wg.Done()
+			defer s.Stop()
+			port := strconv.Itoa(s.Port())
+			assert.Equal(t, "http://before.example.org:"+port, s.BaseURL())
+			assert.Equal(t, "http://before.example.org:"+port, s.ProxyBaseURL())
+
+			filesystem["config.json"] = &fstest.MapFile{Data: []byte(`{"server": {"port": 0, "domain": "after.example.org", "proxy": {"host": "proxy.example.org", "protocol": "https", "port": 443, "base": "/app"}}}`), ModTime: time.Now()}
+			_, err := reloadable.Reload()
+			require.NoError(t, err)
+			assert.Equal(t, "http://after.example.org:"+port, s.BaseURL())
+			assert.Equal(t, "https://proxy.example.org/app", s.ProxyBaseURL())
+
+			recorder := httptest.NewRecorder()
+			s.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "https://after.example.org/test", nil))
+			assert.Equal(t, http.StatusPermanentRedirect, recorder.Code)
+			assert.Equal(t, "https://proxy.example.org/app/test", recorder.Header().Get("Location"))
+		})
+
+		go func() {
+			err := server.Start()
+			assert.NoError(t, err)
+			wg.Done()
+		}()
+		wg.Wait()
+	})
+
 	t.Run("Context", func(t *testing.T) {
 		type baseContextKey struct{}
 		type connContextKey struct{}
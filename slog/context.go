@@ -0,0 +1,30 @@
+package slog
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+type contextAttrsKey struct{}
+
+// ContextWithAttrs returns a copy of the given context carrying the given attributes,
+// in addition to the ones already carried by the parent context.
+//
+// These attributes are added to the records logged by `Logger` when the
+// context is passed to one of its methods (e.g. `ErrorCtx()`). This is used to correlate
+// the logs of a single request, for example by adding a request ID.
+func ContextWithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	parent := AttrsFromContext(ctx)
+	return context.WithValue(ctx, contextAttrsKey{}, append(slices.Clip(parent), attrs...))
+}
+
+// AttrsFromContext returns the attributes carried by the given context, or `nil`.
+// The returned slice should not be modified.
+func AttrsFromContext(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(contextAttrsKey{}).([]slog.Attr)
+	return attrs
+}
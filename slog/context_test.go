@@ -0,0 +1,38 @@
+package slog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextAttrs(t *testing.T) {
+	assert.Nil(t, AttrsFromContext(context.Background()))
+	assert.Nil(t, AttrsFromContext(nil)) //nolint:staticcheck
+
+	ctx := ContextWithAttrs(context.Background(), slog.String("a", "1"))
+	ctx2 := ContextWithAttrs(ctx, slog.String("b", "2"))
+	ctx3 := ContextWithAttrs(ctx, slog.String("c", "3"))
+
+	assert.Equal(t, []slog.Attr{slog.String("a", "1")}, AttrsFromContext(ctx))
+	assert.Equal(t, []slog.Attr{slog.String("a", "1"), slog.String("b", "2")}, AttrsFromContext(ctx2))
+	assert.Equal(t, []slog.Attr{slog.String("a", "1"), slog.String("c", "3")}, AttrsFromContext(ctx3))
+
+	t.Run("logger", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		l := New(slog.NewJSONHandler(buf, nil))
+		l.ErrorCtx(ctx2, assert.AnError)
+		assert.Contains(t, buf.String(), `"a":"1","b":"2"`)
+
+		buf.Reset()
+		l.InfoWithSource(ctx, 0, "message")
+		assert.Contains(t, buf.String(), `"a":"1"`)
+
+		buf.Reset()
+		l.Error(assert.AnError)
+		assert.NotContains(t, buf.String(), `"a":"1"`)
+	})
+}
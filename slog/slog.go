@@ -18,6 +18,9 @@ type unwrapper interface {
 
 // Logger an extension of standard `*slog.Logger` overriding the `Error()` and `ErrorCtx()`
 // functions so they take an error as parameter and handle `*errors.Error` gracefully.
+//
+// The methods taking a context as parameter add the attributes carried by this
+// context (see `ContextWithAttrs`) to the logged records.
 type Logger struct {
 	*slog.Logger
 }
@@ -77,6 +80,7 @@ func (l *Logger) logError(ctx context.Context, source uintptr, err error, args .
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	r.AddAttrs(AttrsFromContext(ctx)...)
 
 	switch e := err.(type) {
 	case *errors.Error:
@@ -97,6 +101,7 @@ func (l *Logger) log(ctx context.Context, level slog.Level, source uintptr, msg
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	r.AddAttrs(AttrsFromContext(ctx)...)
 
 	_ = l.Handler().Handle(ctx, r)
 }
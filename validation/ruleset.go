@@ -107,6 +107,24 @@ func (r RuleSet) convert(path string, _ *FieldRules, _ uint) Rules {
 	return r.asRulesWithPrefix(path)
 }
 
+// Append the given validators to the rules of the field identified by the given path.
+// If the RuleSet doesn't contain a `List` for this path yet, a new field is added.
+// Returns the resulting RuleSet.
+//
+// This is useful to add custom validators to a generated RuleSet (see `FromStruct`).
+func (r RuleSet) Append(path string, validators ...Validator) RuleSet {
+	for _, f := range r {
+		if f.Path != path {
+			continue
+		}
+		if list, ok := f.Rules.(List); ok {
+			f.Rules = append(list, validators...)
+			return r
+		}
+	}
+	return append(r, &FieldRules{Path: path, Rules: List(validators)})
+}
+
 // AsRules converts this RuleSet to a Rules structure.
 func (r RuleSet) AsRules() Rules {
 	return r.asRulesWithPrefix("")
@@ -0,0 +1,509 @@
+package validation
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"goyave.dev/goyave/v5/util/errors"
+)
+
+const (
+	// StructTag the name of the struct tag containing the rules
+	// applied to a field by `FromStruct`.
+	StructTag = "validate"
+
+	// StructTagElements the name of the struct tag containing the rules
+	// applied to the elements of a slice or array field by `FromStruct`.
+	StructTagElements = "validateElements"
+)
+
+// TagRuleFunc creates a new validator from the raw parameters of a rule
+// found in a struct tag. The given type is the type of the field (or of the elements
+// of the field if the rule comes from the `StructTagElements` tag), without pointer indirection.
+//
+// For example, for the rule "between:1|10", the parameters are "1|10".
+type TagRuleFunc func(fieldType reflect.Type, params string) (Validator, error)
+
+var (
+	tagRulesMu sync.RWMutex
+	tagRules   = map[string]TagRuleFunc{
+		"required":           noParams(func() Validator { return Required() }),
+		"nullable":           noParams(func() Validator { return Nullable() }),
+		"string":             noParams(func() Validator { return String() }),
+		"int":                noParams(func() Validator { return Int() }),
+		"int8":               noParams(func() Validator { return Int8() }),
+		"int16":              noParams(func() Validator { return Int16() }),
+		"int32":              noParams(func() Validator { return Int32() }),
+		"int64":              noParams(func() Validator { return Int64() }),
+		"uint":               noParams(func() Validator { return Uint() }),
+		"uint8":              noParams(func() Validator { return Uint8() }),
+		"uint16":             noParams(func() Validator { return Uint16() }),
+		"uint32":             noParams(func() Validator { return Uint32() }),
+		"uint64":             noParams(func() Validator { return Uint64() }),
+		"float32":            noParams(func() Validator { return Float32() }),
+		"float64":            noParams(func() Validator { return Float64() }),
+		"bool":               noParams(func() Validator { return Bool() }),
+		"array":              noParams(func() Validator { return Array() }),
+		"object":             noParams(func() Validator { return Object() }),
+		"file":               noParams(func() Validator { return File() }),
+		"image":              noParams(func() Validator { return Image() }),
+		"email":              noParams(func() Validator { return Email() }),
+		"url":                noParams(func() Validator { return URL() }),
+		"ip":                 noParams(func() Validator { return IP() }),
+		"ipv4":               noParams(func() Validator { return IPv4() }),
+		"ipv6":               noParams(func() Validator { return IPv6() }),
+		"json":               noParams(func() Validator { return JSON() }),
+		"timezone":           noParams(func() Validator { return Timezone() }),
+		"alpha":              noParams(func() Validator { return Alpha() }),
+		"alpha_num":          noParams(func() Validator { return AlphaNum() }),
+		"alpha_dash":         noParams(func() Validator { return AlphaDash() }),
+		"digits":             noParams(func() Validator { return Digits() }),
+		"trim":               noParams(func() Validator { return Trim() }),
+		"min":                floatParam(func(f float64) Validator { return Min(f) }),
+		"max":                floatParam(func(f float64) Validator { return Max(f) }),
+		"between":            tagRuleBetween,
+		"size":               tagRuleSize,
+		"uuid":               tagRuleUUID,
+		"date":               stringParams(func(p []string) Validator { return Date(p...) }),
+		"regex":              tagRuleRegex,
+		"starts_with":        stringParams(func(p []string) Validator { return StartsWith(p...) }),
+		"ends_with":          stringParams(func(p []string) Validator { return EndsWith(p...) }),
+		"doesnt_start_with":  stringParams(func(p []string) Validator { return DoesntStartWith(p...) }),
+		"doesnt_end_with":    stringParams(func(p []string) Validator { return DoesntEndWith(p...) }),
+		"extension":          stringParams(func(p []string) Validator { return Extension(p...) }),
+		"mime":               stringParams(func(p []string) Validator { return MIME(p...) }),
+		"keys_in":            stringParams(func(p []string) Validator { return KeysIn(p...) }),
+		"same":               pathParam(func(p string) Validator { return Same(p) }),
+		"different":          pathParam(func(p string) Validator { return Different(p) }),
+		"greater_than":       pathParam(func(p string) Validator { return GreaterThan(p) }),
+		"greater_than_equal": pathParam(func(p string) Validator { return GreaterThanEqual(p) }),
+		"lower_than":         pathParam(func(p string) Validator { return LowerThan(p) }),
+		"lower_than_equal":   pathParam(func(p string) Validator { return LowerThanEqual(p) }),
+		"before_field":       pathParam(func(p string) Validator { return BeforeField(p) }),
+		"before_equal_field": pathParam(func(p string) Validator { return BeforeEqualField(p) }),
+		"after_field":        pathParam(func(p string) Validator { return AfterField(p) }),
+		"after_equal_field":  pathParam(func(p string) Validator { return AfterEqualField(p) }),
+		"date_equals_field":  pathParam(func(p string) Validator { return DateEqualsField(p) }),
+		"file_count":         uintParam(func(u uint) Validator { return FileCount(u) }),
+		"min_file_count":     uintParam(func(u uint) Validator { return MinFileCount(u) }),
+		"max_file_count":     uintParam(func(u uint) Validator { return MaxFileCount(u) }),
+		"file_count_between": tagRuleFileCountBetween,
+		"in":                 tagRuleIn,
+		"not_in":             tagRuleNotIn,
+		"distinct":           tagRuleDistinct,
+	}
+)
+
+// RegisterTagRule registers a new rule usable in the `StructTag` and `StructTagElements` struct tags.
+// Panics if a rule with the same name already exists.
+func RegisterTagRule(name string, rule TagRuleFunc) {
+	tagRulesMu.Lock()
+	defer tagRulesMu.Unlock()
+	if _, ok := tagRules[name]; ok {
+		panic(errors.Errorf("tag rule %q already exists", name))
+	}
+	tagRules[name] = rule
+}
+
+// FromStruct generates a new RuleSet from the `StructTag` ("validate") and `StructTagElements`
+// ("validateElements") struct tags of the given type. T should be a struct or a pointer to a struct.
+//
+// Rules are separated by commas. Parameters follow the rule name after a colon and are separated
+// by pipes. Commas in parameters must be escaped with a backslash. The `regex` rule takes its
+// parameter as-is.
+//
+//	type UserDTO struct {
+//		Name  string   `json:"name" validate:"required,string,between:2|255"`
+//		Role  string   `json:"role" validate:"required,string,in:admin|user"`
+//		Tags  []string `json:"tags" validate:"array,max:10" validateElements:"string,max:32"`
+//		Address AddressDTO `json:"address" validate:"required,object"`
+//	}
+//
+// The field names are taken from the "json" struct tag. Fields without a "json" tag use
+// their Go name, fields whose "json" tag is "-" and unexported fields are ignored.
+// Nested structs (and slices of structs) are explored, their rules use the paths of the nested
+// fields (e.g. "address.city" or "items[].id"). Fields of embedded structs are promoted.
+//
+// A rule on the root element ensures the validated value is an object.
+//
+// Custom validators can be added programmatically to the returned RuleSet using `RuleSet.Append`,
+// or made available to struct tags using `RegisterTagRule`.
+//
+// The validators are created on each call, so this function can be used in a `RuleSetFunc`.
+func FromStruct[T any]() (RuleSet, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, errors.Errorf("cannot generate RuleSet from non-struct type %s", t)
+	}
+
+	rules := RuleSet{
+		{Path: CurrentElement, Rules: List{Object()}},
+	}
+	return structRules(t, "", rules, map[reflect.Type]struct{}{})
+}
+
+// MustFromStruct generates a new RuleSet from the struct tags of the given type.
+// Panics if the RuleSet cannot be generated. See `FromStruct` for more details.
+func MustFromStruct[T any]() RuleSet {
+	rules, err := FromStruct[T]()
+	if err != nil {
+		panic(errors.NewSkip(err, 3))
+	}
+	return rules
+}
+
+func structRules(t reflect.Type, prefix string, rules RuleSet, exploring map[reflect.Type]struct{}) (RuleSet, error) {
+	if _, ok := exploring[t]; ok {
+		return rules, nil // Recursive type
+	}
+	exploring[t] = struct{}{}
+	defer delete(exploring, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, hasName := jsonFieldName(field)
+		if name == "-" {
+			continue
+		}
+		fieldType := indirectType(field.Type)
+		if field.Anonymous && !hasName && fieldType.Kind() == reflect.Struct {
+			// Fields of embedded structs are promoted, even if the struct type is unexported
+			var err error
+			rules, err = structRules(fieldType, prefix, rules, exploring)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		path := prefix + name
+		validators, err := parseTagRules(field.Tag.Get(StructTag), fieldType)
+		if err != nil {
+			return nil, errors.Errorf("%s: %w", path, err)
+		}
+		if len(validators) > 0 {
+			rules = append(rules, &FieldRules{Path: path, Rules: List(validators)})
+		}
+
+		switch fieldType.Kind() {
+		case reflect.Struct:
+			rules, err = structRules(fieldType, path+".", rules, exploring)
+		case reflect.Slice, reflect.Array:
+			elemType := indirectType(fieldType.Elem())
+			validators, err = parseTagRules(field.Tag.Get(StructTagElements), elemType)
+			if err != nil {
+				return nil, errors.Errorf("%s[]: %w", path, err)
+			}
+			if len(validators) > 0 {
+				rules = append(rules, &FieldRules{Path: path + "[]", Rules: List(validators)})
+			}
+			if elemType.Kind() == reflect.Struct {
+				rules, err = structRules(elemType, path+"[].", rules, exploring)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return field.Name, false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return field.Name, false
+	}
+	return name, true
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// parseTagRules converts the given struct tag value to a list of validators.
+func parseTagRules(tag string, fieldType reflect.Type) ([]Validator, error) {
+	if tag == "" {
+		return nil, nil
+	}
+
+	tagRulesMu.RLock()
+	defer tagRulesMu.RUnlock()
+
+	rules := splitTag(tag)
+	validators := make([]Validator, 0, len(rules))
+	for _, r := range rules {
+		name, params, _ := strings.Cut(strings.TrimSpace(r), ":")
+		if name == "" {
+			continue
+		}
+		ruleFunc, ok := tagRules[name]
+		if !ok {
+			return nil, errors.Errorf("unknown rule %q", name)
+		}
+		v, err := ruleFunc(fieldType, params)
+		if err != nil {
+			return nil, errors.Errorf("rule %q: %w", name, err)
+		}
+		validators = append(validators, v)
+	}
+	return validators, nil
+}
+
+// splitTag splits the given tag value on commas, unless they are escaped with a backslash.
+func splitTag(tag string) []string {
+	result := []string{}
+	var builder strings.Builder
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			builder.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			result = append(result, builder.String())
+			builder.Reset()
+		default:
+			builder.WriteByte(tag[i])
+		}
+	}
+	return append(result, builder.String())
+}
+
+func splitParams(params string) []string {
+	if params == "" {
+		return []string{}
+	}
+	return strings.Split(params, "|")
+}
+
+func expectParams(params string, count int) ([]string, error) {
+	p := splitParams(params)
+	if len(p) != count {
+		return nil, errors.Errorf("expected %d parameter(s), got %d", count, len(p))
+	}
+	return p, nil
+}
+
+func noParams(constructor func() Validator) TagRuleFunc {
+	return func(_ reflect.Type, params string) (Validator, error) {
+		if _, err := expectParams(params, 0); err != nil {
+			return nil, err
+		}
+		return constructor(), nil
+	}
+}
+
+func floatParam(constructor func(float64) Validator) TagRuleFunc {
+	return func(_ reflect.Type, params string) (Validator, error) {
+		if _, err := expectParams(params, 1); err != nil {
+			return nil, err
+		}
+		f, err := strconv.ParseFloat(params, 64)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+		return constructor(f), nil
+	}
+}
+
+func uintParam(constructor func(uint) Validator) TagRuleFunc {
+	return func(_ reflect.Type, params string) (Validator, error) {
+		if _, err := expectParams(params, 1); err != nil {
+			return nil, err
+		}
+		u, err := strconv.ParseUint(params, 10, 0)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+		return constructor(uint(u)), nil
+	}
+}
+
+func stringParams(constructor func([]string) Validator) TagRuleFunc {
+	return func(_ reflect.Type, params string) (Validator, error) {
+		return constructor(splitParams(params)), nil
+	}
+}
+
+func pathParam(constructor func(string) Validator) TagRuleFunc {
+	return func(_ reflect.Type, params string) (Validator, error) {
+		if _, err := expectParams(params, 1); err != nil {
+			return nil, err
+		}
+		return constructor(params), nil
+	}
+}
+
+func tagRuleBetween(_ reflect.Type, params string) (Validator, error) {
+	p, err := expectParams(params, 2)
+	if err != nil {
+		return nil, err
+	}
+	min, err := strconv.ParseFloat(p[0], 64)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	max, err := strconv.ParseFloat(p[1], 64)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	return Between(min, max), nil
+}
+
+func tagRuleFileCountBetween(_ reflect.Type, params string) (Validator, error) {
+	p, err := expectParams(params, 2)
+	if err != nil {
+		return nil, err
+	}
+	min, err := strconv.ParseUint(p[0], 10, 0)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	max, err := strconv.ParseUint(p[1], 10, 0)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	return FileCountBetween(uint(min), uint(max)), nil
+}
+
+func tagRuleSize(_ reflect.Type, params string) (Validator, error) {
+	if _, err := expectParams(params, 1); err != nil {
+		return nil, err
+	}
+	size, err := strconv.Atoi(params)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	return Size(size), nil
+}
+
+func tagRuleUUID(_ reflect.Type, params string) (Validator, error) {
+	p := splitParams(params)
+	versions := make([]uuid.Version, 0, len(p))
+	for _, v := range p {
+		version, err := strconv.ParseUint(v, 10, 8)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+		versions = append(versions, uuid.Version(version))
+	}
+	return UUID(versions...), nil
+}
+
+func tagRuleRegex(_ reflect.Type, params string) (Validator, error) {
+	regex, err := regexp.Compile(params)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	return Regex(regex), nil
+}
+
+func tagRuleIn(fieldType reflect.Type, params string) (Validator, error) {
+	return comparableRule(fieldType, params, comparableIn)
+}
+
+func tagRuleNotIn(fieldType reflect.Type, params string) (Validator, error) {
+	return comparableRule(fieldType, params, comparableNotIn)
+}
+
+func tagRuleDistinct(fieldType reflect.Type, params string) (Validator, error) {
+	if _, err := expectParams(params, 0); err != nil {
+		return nil, err
+	}
+	if fieldType.Kind() != reflect.Slice && fieldType.Kind() != reflect.Array {
+		return nil, errors.Errorf("cannot use on non-slice type %s", fieldType)
+	}
+	return comparableRule(indirectType(fieldType.Elem()), "", comparableDistinct)
+}
+
+type comparableRuleType int
+
+const (
+	comparableIn comparableRuleType = iota
+	comparableNotIn
+	comparableDistinct
+)
+
+// comparableRule creates a generic validator using the given type as type parameter.
+func comparableRule(t reflect.Type, params string, ruleType comparableRuleType) (Validator, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return newComparableRule(params, ruleType, func(s string) (string, error) { return s, nil })
+	case reflect.Int:
+		return newComparableRule(params, ruleType, strconv.Atoi)
+	case reflect.Int8:
+		return newComparableRule(params, ruleType, parseInt[int8])
+	case reflect.Int16:
+		return newComparableRule(params, ruleType, parseInt[int16])
+	case reflect.Int32:
+		return newComparableRule(params, ruleType, parseInt[int32])
+	case reflect.Int64:
+		return newComparableRule(params, ruleType, parseInt[int64])
+	case reflect.Uint:
+		return newComparableRule(params, ruleType, parseUint[uint])
+	case reflect.Uint8:
+		return newComparableRule(params, ruleType, parseUint[uint8])
+	case reflect.Uint16:
+		return newComparableRule(params, ruleType, parseUint[uint16])
+	case reflect.Uint32:
+		return newComparableRule(params, ruleType, parseUint[uint32])
+	case reflect.Uint64:
+		return newComparableRule(params, ruleType, parseUint[uint64])
+	case reflect.Float32:
+		return newComparableRule(params, ruleType, parseFloat[float32])
+	case reflect.Float64:
+		return newComparableRule(params, ruleType, parseFloat[float64])
+	}
+	return nil, errors.Errorf("unsupported type %s", t)
+}
+
+func newComparableRule[T comparable](params string, ruleType comparableRuleType, parse func(string) (T, error)) (Validator, error) {
+	if ruleType == comparableDistinct {
+		return Distinct[T](), nil
+	}
+
+	p := splitParams(params)
+	values := make([]T, 0, len(p))
+	for _, param := range p {
+		v, err := parse(param)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+		values = append(values, v)
+	}
+	if ruleType == comparableNotIn {
+		return NotIn(values), nil
+	}
+	return In(values), nil
+}
+
+func parseInt[T int8 | int16 | int32 | int64](s string) (T, error) {
+	i, err := strconv.ParseInt(s, 10, reflect.TypeOf(T(0)).Bits())
+	return T(i), err
+}
+
+func parseUint[T uint | uint8 | uint16 | uint32 | uint64](s string) (T, error) {
+	i, err := strconv.ParseUint(s, 10, reflect.TypeOf(T(0)).Bits())
+	return T(i), err
+}
+
+func parseFloat[T float32 | float64](s string) (T, error) {
+	f, err := strconv.ParseFloat(s, reflect.TypeOf(T(0)).Bits())
+	return T(f), err
+}
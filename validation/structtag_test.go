@@ -0,0 +1,194 @@
+package validation
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goyave.dev/goyave/v5/lang"
+)
+
+type structTagAddress struct {
+	City    string `json:"city" validate:"required,string,max:100"`
+	Country string `json:"country" validate:"string,size:2"`
+}
+
+type structTagItem struct {
+	ID       int64 `json:"id" validate:"required,int64,in:1|2|3"`
+	Quantity uint  `json:"quantity" validate:"uint,between:1|10"`
+}
+
+type structTagEmbedded struct {
+	CreatedAt time.Time `json:"createdAt" validate:"date:2006-01-02"`
+}
+
+type structTagNode struct {
+	Name     string           `json:"name" validate:"string"`
+	Children []*structTagNode `json:"children" validate:"array"`
+}
+
+type structTagDTO struct {
+	structTagEmbedded
+	Name       string            `json:"name" validate:"required,string,between:2|255"`
+	Email      string            `json:"email,omitempty" validate:"required,email"`
+	Role       string            `json:"role" validate:"string,in:admin|user"`
+	Code       string            `json:"code" validate:"string,regex:^[a-z]{2\\,4}$"`
+	NoJSONTag  *string           `validate:"nullable,string"`
+	Tags       []string          `json:"tags" validate:"array,distinct" validateElements:"string,max:32"`
+	Address    structTagAddress  `json:"address" validate:"required,object"`
+	Items      []structTagItem   `json:"items" validate:"array"`
+	Tree       structTagNode     `json:"tree"`
+	Ignored    string            `json:"-" validate:"required"`
+	Untagged   string            `json:"untagged"`
+	Metadata   map[string]string `json:"metadata" validate:"object"`
+	unexported string            //nolint:unused
+}
+
+func TestFromStruct(t *testing.T) {
+	t.Run("rules", func(t *testing.T) {
+		rules, err := FromStruct[*structTagDTO]()
+		require.NoError(t, err)
+
+		expected := RuleSet{
+			{Path: CurrentElement, Rules: List{Object()}},
+			{Path: "createdAt", Rules: List{Date("2006-01-02")}},
+			{Path: "name", Rules: List{Required(), String(), Between(2, 255)}},
+			{Path: "email", Rules: List{Required(), Email()}},
+			{Path: "role", Rules: List{String(), In([]string{"admin", "user"})}},
+			{Path: "code", Rules: List{String(), Regex(regexp.MustCompile("^[a-z]{2,4}$"))}},
+			{Path: "NoJSONTag", Rules: List{Nullable(), String()}},
+			{Path: "tags", Rules: List{Array(), Distinct[string]()}},
+			{Path: "tags[]", Rules: List{String(), Max(32)}},
+			{Path: "address", Rules: List{Required(), Object()}},
+			{Path: "address.city", Rules: List{Required(), String(), Max(100)}},
+			{Path: "address.country", Rules: List{String(), Size(2)}},
+			{Path: "items", Rules: List{Array()}},
+			{Path: "items[].id", Rules: List{Required(), Int64(), In([]int64{1, 2, 3})}},
+			{Path: "items[].quantity", Rules: List{Uint(), Between(1, 10)}},
+			{Path: "tree.name", Rules: List{String()}},
+			{Path: "tree.children", Rules: List{Array()}},
+			{Path: "metadata", Rules: List{Object()}},
+		}
+		assert.Equal(t, expected, rules)
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		data := map[string]any{
+			"name":    "John",
+			"email":   "not an email",
+			"role":    "root",
+			"address": map[string]any{"city": "Paris"},
+			"items":   []any{map[string]any{"id": 4}},
+		}
+		errs, errsBag := Validate(&Options{
+			Data:     data,
+			Rules:    MustFromStruct[structTagDTO](),
+			Language: lang.New().GetDefault(),
+		})
+		require.Empty(t, errsBag)
+		require.NotNil(t, errs)
+		assert.Contains(t, errs.Fields, "email")
+		assert.Contains(t, errs.Fields, "role")
+		assert.Contains(t, errs.Fields, "items")
+		assert.NotContains(t, errs.Fields, "name")
+		assert.NotContains(t, errs.Fields, "address")
+	})
+
+	t.Run("Append", func(t *testing.T) {
+		rules := MustFromStruct[structTagAddress]()
+		custom := Trim()
+		rules = rules.Append("city", custom).Append("zip", String())
+
+		assert.Equal(t, List{Required(), String(), Max(100), custom}, rules[1].Rules)
+		assert.Equal(t, &FieldRules{Path: "zip", Rules: List{String()}}, rules[3])
+
+		rules = RuleSet{{Path: "composed", Rules: RuleSet{}}}.Append("composed", String())
+		assert.Len(t, rules, 2)
+		assert.Equal(t, List{String()}, rules[1].Rules)
+	})
+
+	t.Run("RegisterTagRule", func(t *testing.T) {
+		RegisterTagRule("test_custom", func(fieldType reflect.Type, params string) (Validator, error) {
+			assert.Equal(t, reflect.TypeOf(""), fieldType)
+			assert.Equal(t, "a|b", params)
+			return StartsWith(splitParams(params)...), nil
+		})
+		t.Cleanup(func() {
+			tagRulesMu.Lock()
+			delete(tagRules, "test_custom")
+			tagRulesMu.Unlock()
+		})
+
+		type dto struct {
+			Field string `json:"field" validate:"test_custom:a|b"`
+		}
+		rules, err := FromStruct[dto]()
+		require.NoError(t, err)
+		assert.Equal(t, List{StartsWith("a", "b")}, rules[1].Rules)
+
+		assert.Panics(t, func() {
+			RegisterTagRule("required", nil)
+		})
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := FromStruct[string]()
+		assert.Error(t, err)
+
+		type unknownRule struct {
+			Field string `validate:"unknown"`
+		}
+		_, err = FromStruct[unknownRule]()
+		assert.ErrorContains(t, err, `Field: unknown rule "unknown"`)
+
+		type invalidParam struct {
+			Field int `validate:"min:abc"`
+		}
+		_, err = FromStruct[invalidParam]()
+		assert.ErrorContains(t, err, `Field: rule "min"`)
+
+		type wrongParamCount struct {
+			Field int `validate:"between:1"`
+		}
+		_, err = FromStruct[wrongParamCount]()
+		assert.ErrorContains(t, err, "expected 2 parameter(s), got 1")
+
+		type unexpectedParam struct {
+			Field int `validate:"required:1"`
+		}
+		_, err = FromStruct[unexpectedParam]()
+		assert.Error(t, err)
+
+		type unsupportedIn struct {
+			Field bool `validate:"in:true"`
+		}
+		_, err = FromStruct[unsupportedIn]()
+		assert.ErrorContains(t, err, "unsupported type bool")
+
+		type distinctNotSlice struct {
+			Field string `validate:"distinct"`
+		}
+		_, err = FromStruct[distinctNotSlice]()
+		assert.Error(t, err)
+
+		type invalidElements struct {
+			Field []string `validateElements:"unknown"`
+		}
+		_, err = FromStruct[invalidElements]()
+		assert.ErrorContains(t, err, "Field[]")
+
+		assert.Panics(t, func() {
+			MustFromStruct[string]()
+		})
+	})
+}
+
+func TestSplitTag(t *testing.T) {
+	assert.Equal(t, []string{""}, splitTag(""))
+	assert.Equal(t, []string{"required", "string"}, splitTag("required,string"))
+	assert.Equal(t, []string{"regex:^a{1,2}$", "max:3"}, splitTag(`regex:^a{1\,2}$,max:3`))
+	assert.Equal(t, []string{`a\b`}, splitTag(`a\b`))
+}
@@ -607,10 +607,8 @@ func translateFieldName(lang *lang.Language, fieldName string) string {
 		}
 		fieldName = f
 	}
-	entry := "validation.fields." + fieldName
-	name := lang.Get(entry)
-	if name == entry {
-		return fieldName
+	if name, ok := lang.Lookup("validation.fields." + fieldName); ok {
+		return name
 	}
-	return name
+	return fieldName
 }
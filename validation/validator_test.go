@@ -159,6 +159,24 @@ func TestValidateExtraNotNil(t *testing.T) {
 	assert.NotNil(t, options.Extra)
 }
 
+func TestValidateMissingFieldNames(t *testing.T) {
+	languages := lang.New()
+	missing := []string{}
+	languages.SetMissingLineHandler(func(_ *lang.Language, line string) {
+		missing = append(missing, line)
+	})
+	options := &Options{
+		Data:     map[string]any{},
+		Language: languages.GetDefault(),
+		Rules: RuleSet{
+			{Path: "property", Rules: List{Required()}},
+		},
+	}
+	errs, _ := Validate(options)
+	assert.Equal(t, []string{"The property is required."}, errs.Fields["property"].Errors)
+	assert.Empty(t, missing) // Field names without translation are not reported
+}
+
 func TestValidate(t *testing.T) {
 	cases := []struct {
 		desc                 string